@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"bountyos-v8/internal/adapters/storage"
+	"bountyos-v8/internal/adapters/ui"
+	"bountyos-v8/internal/config"
+	"bountyos-v8/internal/core"
+	"bountyos-v8/internal/pipeline"
+	"bountyos-v8/internal/security"
+)
+
+// ingest holds what the stages of the ingest pipeline share.
+type ingest struct {
+	storage     storage.Store
+	links       *security.LinkValidator // nil without VALIDATE_LINKS_HTTP
+	oracle      core.PriceOracle
+	webUI       *ui.WebUI
+	desktop     core.Notifier
+	discord     core.Notifier // nil without DISCORD_WEBHOOK_URL
+	minScore    int
+	filterStage core.FilterStage
+	thresholds  core.RewardThresholds
+	dedup       core.DedupOptions
+
+	// linkMu orders link verdicts against the store stage. pending counts
+	// the bounties per URL submitted for a check and not yet stored;
+	// unreachable holds verdicts that arrived before their bounty was.
+	linkMu      sync.Mutex
+	pending     map[string]int
+	unreachable map[string]bool
+}
+
+// stages returns the ingest pipeline: validate and enrich listings
+// concurrently, store them one at a time so duplicate checks see every
+// earlier save, then alert on a pool of their own so a slow webhook never
+// holds up storing.
+func (in *ingest) stages(cfg *config.Config) []pipeline.Stage {
+	return []pipeline.Stage{
+		{Name: "validate", Workers: cfg.PipelineValidateWorkers, Queue: cfg.PipelineQueueSize, Handle: in.validate},
+		{Name: "enrich", Workers: cfg.PipelineEnrichWorkers, Queue: cfg.PipelineQueueSize, Handle: in.enrich, Discard: in.dropLink},
+		{Name: "store", Workers: 1, Queue: cfg.PipelineQueueSize, Handle: in.store, Discard: in.dropLink},
+		{Name: "alert", Workers: cfg.PipelineAlertWorkers, Queue: cfg.PipelineQueueSize, Handle: in.alert},
+	}
+}
+
+// validate drops listings whose URL is invalid. It submits the rest for a
+// link check without waiting on it; see unreachableLink.
+func (in *ingest) validate(ctx context.Context, bounty core.Bounty) (core.Bounty, bool) {
+	bounty.URL = security.NormalizeURL(bounty.URL)
+	if bounty.URL == "" || !security.ValidateURL(bounty.URL) {
+		logger.Warn("Skipping bounty with invalid URL: %s", bounty.URL)
+		return bounty, false
+	}
+
+	if in.links != nil {
+		url := bounty.URL
+		in.linkMu.Lock()
+		in.pending[url]++
+		in.linkMu.Unlock()
+		in.links.Submit(ctx, url, func(reachable bool) {
+			if !reachable {
+				in.unreachableLink(url)
+			}
+		})
+	}
+	return bounty, true
+}
+
+// unreachableLink acts on a failed link check. A bounty still on its way to
+// the store stage is skipped there; one already stored is deleted. Cached
+// verdicts arrive at once, so only a bounty's first sighting can be stored
+// and alerted before its check fails.
+func (in *ingest) unreachableLink(url string) {
+	in.linkMu.Lock()
+	defer in.linkMu.Unlock()
+	if in.pending[url] > 0 {
+		in.unreachable[url] = true
+		return
+	}
+	deleted, err := in.storage.Delete(url)
+	if err != nil {
+		logger.Error("Error deleting bounty with unreachable URL: %v", err)
+	} else if deleted {
+		logger.Warn("Deleted bounty with unreachable URL: %s", url)
+	}
+}
+
+// linkChecked reports whether the bounty at url may be stored, and marks
+// it no longer pending. The caller holds linkMu.
+func (in *ingest) linkChecked(url string) bool {
+	if in.links == nil {
+		return true
+	}
+	unreachable := in.unreachable[url]
+	if in.pending[url]--; in.pending[url] <= 0 {
+		delete(in.pending, url)
+		delete(in.unreachable, url)
+	}
+	return !unreachable
+}
+
+// dropLink releases the pending link check of a bounty that left the
+// pipeline after validate without reaching the store stage.
+func (in *ingest) dropLink(bounty core.Bounty) {
+	in.linkMu.Lock()
+	defer in.linkMu.Unlock()
+	in.linkChecked(bounty.URL)
+}
+
+// enrich assigns the bounty its ID, sanitizes it and values its reward. A
+// bounty it drops releases its pending link check.
+func (in *ingest) enrich(ctx context.Context, bounty core.Bounty) (out core.Bounty, ok bool) {
+	defer func() {
+		if !ok {
+			in.dropLink(bounty)
+		}
+	}()
+	if bounty.SourceID == "" {
+		bounty.SourceID = bounty.ID
+	}
+	bounty.ID = core.BountyID(bounty.URL)
+	bounty.Title = security.SanitizeString(bounty.Title)
+	bounty.Platform = security.SanitizeString(bounty.Platform)
+	bounty.Reward = security.SanitizeString(bounty.Reward)
+	bounty.Currency = security.SanitizeString(bounty.Currency)
+	bounty.Description = security.SanitizeString(bounty.Description)
+	bounty.ApplyReward()
+
+	priceCtx, cancelPrice := context.WithTimeout(ctx, 10*time.Second)
+	if err := core.EstimateUSD(priceCtx, in.oracle, &bounty); err != nil && !errors.Is(err, core.ErrPriceUnavailable) {
+		logger.Warn("Failed to value %s reward: %v", bounty.RewardToken, err)
+	}
+	cancelPrice()
+	return bounty, true
+}
+
+// store links duplicates, refreshes known listings and saves new ones. It
+// passes on only new bounties worth an alert.
+func (in *ingest) store(ctx context.Context, bounty core.Bounty) (core.Bounty, bool) {
+	in.linkMu.Lock()
+	defer in.linkMu.Unlock()
+	if !in.linkChecked(bounty.URL) {
+		logger.Warn("Skipping bounty with unreachable URL: %s", bounty.URL)
+		return bounty, false
+	}
+
+	source := core.SourceOf(bounty)
+	existing, match, err := in.storage.FindDuplicate(bounty, in.dedup)
+	if err != nil {
+		logger.Error("Error checking if bounty is new: %v", err)
+		return bounty, false
+	}
+
+	if match != "" {
+		source.Match = match
+		if err := in.storage.RecordSource(existing.URL, source); err != nil {
+			logger.Error("Error recording bounty source: %v", err)
+		}
+		if match != core.MatchURL {
+			logger.Info("Linked %s to %s (%s match)", bounty.URL, existing.URL, match)
+			return bounty, false
+		}
+
+		// Known listing: record the sighting and any changed fields
+		bounty.URL, bounty.ID = existing.URL, existing.ID
+		changes, err := in.storage.Refresh(bounty)
+		if err != nil {
+			logger.Error("Error refreshing bounty: %v", err)
+		}
+		for _, change := range changes {
+			if change.Field == "description" {
+				logger.Info("Bounty %s changed description", bounty.URL)
+				continue
+			}
+			logger.Info("Bounty %s changed %s: %q -> %q", bounty.URL, change.Field, change.OldValue, change.NewValue)
+		}
+		return bounty, false
+	}
+
+	if bounty.Status == "" {
+		bounty.Status = core.StatusOpen
+	}
+	if bounty.Status == core.StatusOpen && bounty.ExpiresAt != nil && bounty.ExpiresAt.Before(time.Now()) {
+		bounty.Status = core.StatusExpired
+	}
+
+	rejectReason := in.thresholds.Reject(&bounty)
+	if rejectReason != "" {
+		logger.Info("Filtered %s before %s: %s", bounty.URL, in.filterStage, rejectReason)
+		if err := in.storage.SaveRejection(core.NewRejection(bounty, rejectReason, in.filterStage)); err != nil {
+			logger.Error("Error recording rejection: %v", err)
+		}
+		if in.filterStage == core.FilterBeforeSave {
+			return bounty, false
+		}
+	}
+
+	// Calculate score
+	scored := core.ExplainUrgency(&bounty)
+	bounty.Score = scored.Total
+	bounty.ScoreBreakdown = scored.Breakdown
+
+	// Save to storage
+	if err := in.storage.Save(bounty); err != nil {
+		logger.Error("Error saving bounty: %v", err)
+		return bounty, false
+	}
+	source.Match = core.MatchURL
+	if err := in.storage.RecordSource(bounty.URL, source); err != nil {
+		logger.Error("Error recording bounty source: %v", err)
+	}
+	in.webUI.Broadcast(bounty)
+
+	// Alert if the bounty is open, its score is high enough and the reward
+	// thresholds passed
+	return bounty, bounty.Score >= in.minScore && rejectReason == "" && bounty.Status == core.StatusOpen
+}
+
+// alert sends the desktop and Discord notifications.
+func (in *ingest) alert(ctx context.Context, bounty core.Bounty) (core.Bounty, bool) {
+	if err := in.desktop.Alert(bounty); err != nil {
+		logger.Error("Error sending desktop notification: %v", err)
+	}
+	if in.discord != nil {
+		if err := in.discord.Alert(bounty); err != nil {
+			logger.Error("Error sending Discord notification: %v", err)
+		}
+	}
+	return bounty, true
+}
@@ -1,25 +1,32 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"bountyos-v8/internal/adapters/pricing"
 	"bountyos-v8/internal/adapters/scanners"
 	"bountyos-v8/internal/adapters/storage"
 	"bountyos-v8/internal/adapters/ui"
 	"bountyos-v8/internal/config"
 	"bountyos-v8/internal/core"
 	"bountyos-v8/internal/notify"
+	"bountyos-v8/internal/pipeline"
 	"bountyos-v8/internal/security"
 	"github.com/fatih/color"
 )
@@ -29,6 +36,7 @@ var logger *security.SecureLogger
 func main() {
 	configPath := flag.String("config", config.DefaultPath, "Path to config file")
 	noUI := flag.Bool("no-ui", false, "Disable terminal UI")
+	demo := flag.Bool("demo", false, "Run only the demo scanner against a separate demo database")
 	flag.Parse()
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -40,6 +48,7 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
 		os.Exit(1)
 	}
+	cfg.CanonicalizeScanners(scanners.CanonicalName)
 
 	headless := strings.EqualFold(os.Getenv("HEADLESS"), "true")
 	disableUI := cfg.NoUI || *noUI || headless
@@ -78,6 +87,31 @@ func main() {
 		defer logFile.Close()
 	}
 
+	if *demo {
+		cfg.DemoMode = true
+	}
+	if cfg.DemoMode {
+		// Sample listings must never reach the real database or webhooks.
+		cfg.EnabledScanners = []string{"DEMO"}
+		cfg.StorageDriver = storage.DriverSQLite
+		cfg.StoragePath = filepath.Join(filepath.Dir(cfg.StoragePath), "demo-bounties.db")
+		cfg.DiscordWebhookURL = ""
+		cfg.ValidateLinksHTTP = false
+	}
+
+	if flag.Arg(0) == "migrate" {
+		os.Exit(runMigrate(cfg.StorageDriver, cfg.StorageTarget(), flag.Args()[1:], os.Stdout))
+	}
+	if flag.Arg(0) == "search" {
+		if !cfg.AutoMigrate {
+			if err := checkSchema(cfg.StorageDriver, cfg.StorageTarget()); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		}
+		os.Exit(runSearch(cfg.StorageDriver, cfg.StorageTarget(), flag.Args()[1:], os.Stdout))
+	}
+
 	if cfg.DisableRateLimitSleep {
 		os.Setenv("BOUNTYOS_DISABLE_RATE_LIMIT_SLEEP", "1")
 	}
@@ -94,26 +128,58 @@ func main() {
 		P2PMethods:       cfg.P2PMethods,
 		FiatMethods:      cfg.FiatMethods,
 	})
+	rules := &rulesFile{path: cfg.ScoringRulesPath}
+	if _, err := rules.reload(); err != nil {
+		logger.Error("Failed to load scoring rules: %v", err)
+		os.Exit(1)
+	}
 
 	githubToken := cfg.GitHubToken
 	logger.RegisterToken(githubToken)
+	logger.RegisterToken(cfg.WebAPIToken)
 	logger.Info("Starting BountyOS v8: Obsidian with enhanced security")
+	if cfg.DemoMode {
+		logger.Warn("Demo mode: emitting sample bounties into %s", cfg.StoragePath)
+	}
+
+	if !cfg.AutoMigrate {
+		if err := checkSchema(cfg.StorageDriver, cfg.StorageTarget()); err != nil {
+			logger.Error("Storage schema check failed: %v", err)
+			os.Exit(1)
+		}
+	}
 
 	// Initialize components
-	storage, err := storage.NewSQLiteStorage(cfg.StoragePath)
+	storage, err := storage.Open(cfg.StorageDriver, cfg.StorageTarget())
 	if err != nil {
 		logger.Error("Failed to initialize storage: %v", err)
 		os.Exit(1)
 	}
 	defer storage.Close()
 
-	pruned, err := storage.PurgeInvalidURLs(ctx, cfg.ValidateLinksHTTP, time.Duration(cfg.LinkValidationTimeout)*time.Second)
-	if err != nil {
-		logger.Warn("Failed to purge invalid URLs: %v", err)
-	} else if pruned > 0 {
-		logger.Info("Pruned %d invalid bounties from storage", pruned)
+	// Reachability checks run on a worker pool, cached in storage, so
+	// neither startup nor ingest waits on them
+	var links *security.LinkValidator
+	if cfg.ValidateLinksHTTP {
+		links = security.NewLinkValidator(storage, security.LinkValidatorOptions{
+			Workers:  cfg.LinkValidationWorkers,
+			PerHost:  cfg.LinkValidationPerHost,
+			Timeout:  time.Duration(cfg.LinkValidationTimeout) * time.Second,
+			CacheTTL: time.Duration(cfg.LinkCacheTTLHours) * time.Hour,
+			Budget:   cfg.LinkValidationBudget,
+		})
+		links.Start(ctx)
 	}
 
+	go func() {
+		pruned, err := storage.PurgeInvalidURLs(ctx, links)
+		if err != nil {
+			logger.Warn("Failed to purge invalid URLs: %v", err)
+		} else if pruned > 0 {
+			logger.Info("Pruned %d invalid bounties from storage", pruned)
+		}
+	}()
+
 	notifier := notify.NewDesktopNotifier()
 	discordWebhook := cfg.DiscordWebhookURL
 	discordNotifier := notify.NewDiscordNotifier(discordWebhook)
@@ -121,57 +187,30 @@ func main() {
 		logger.Info("Discord notifications enabled")
 	}
 
+	oracle := buildPriceOracle(cfg)
+
 	// Initialize and start Web UI
-	webUI := ui.NewWebUI(storage, cfg.WebPort, cfg.APIBountiesLimit, cfg.APIStatsLimit, cfg.WebFetchIntervalSeconds, cfg.WebStaticDir)
+	webUI := ui.NewWebUI(storage, cfg.WebPort, cfg.APIBountiesLimit, cfg.WebFetchIntervalSeconds, cfg.WebStaticDir)
+	webUI.SetAccess(cfg.WebBindAddress, cfg.WebAPIToken)
+	if ip := net.ParseIP(cfg.WebBindAddress); cfg.WebAPIToken == "" && (ip == nil || !ip.IsLoopback()) && cfg.WebBindAddress != "localhost" {
+		logger.Warn("Web UI listens on %s without WEB_API_TOKEN; anyone who can reach it can change tracking and payments", cfg.WebBindAddress)
+	}
+	webUI.SetPriceOracle(oracle)
 	if err := webUI.Start(ctx); err != nil {
 		logger.Error("Failed to start Web UI: %v", err)
 	}
 	defer webUI.Stop()
 
 	// Initialize scanners
-	enabled := make(map[string]bool)
-	for _, name := range cfg.EnabledScanners {
-		enabled[strings.ToUpper(strings.TrimSpace(name))] = true
-	}
-	knownScanners := map[string]bool{
-		"GITHUB_AGGREGATOR": true,
-		"GITHUB":            true,
-		"SUPERTEAM":         true,
-		"BOUNTYCASTER":      true,
-	}
-	for name := range enabled {
-		if !knownScanners[name] {
-			logger.Warn("Unknown scanner in config: %s", name)
+	scannersList := buildScanners(cfg)
+	health := scanners.NewHealthTracker()
+	for _, s := range scannersList {
+		health.Track(s.Name())
+		if stateful, ok := s.Scanner.(scanners.StatefulScanner); ok {
+			stateful.SetStateStore(storage)
 		}
 	}
-
-	var scannersList []core.Scanner
-	addScanner := func(name string, scanner core.Scanner) {
-		if len(enabled) == 0 || enabled[name] {
-			scannersList = append(scannersList, scanner)
-			return
-		}
-	}
-
-	githubScanner := scanners.NewGitHubScanner(githubToken, scanners.GitHubScannerConfig{
-		Labels:   cfg.GitHubLabels,
-		BaseURL:  cfg.GitHubBaseURL,
-		PerPage:  cfg.GitHubPerPage,
-		MaxPages: cfg.GitHubMaxPages,
-	})
-	superteamScanner := scanners.NewSuperteamScanner(scanners.SuperteamScannerConfig{
-		BaseURL:  cfg.SuperteamBaseURL,
-		Statuses: cfg.SuperteamStatuses,
-	})
-	bountycasterScanner := scanners.NewBountycasterScanner(scanners.BountycasterScannerConfig{
-		BaseURL:  cfg.BountycasterBaseURL,
-		Statuses: cfg.BountycasterStatuses,
-	})
-
-	addScanner("GITHUB_AGGREGATOR", githubScanner)
-	addScanner("GITHUB", githubScanner)
-	addScanner("SUPERTEAM", superteamScanner)
-	addScanner("BOUNTYCASTER", bountycasterScanner)
+	webUI.SetHealthSource(health)
 
 	if len(scannersList) == 0 {
 		logger.Error("No scanners enabled; check ENABLED_SCANNERS in config")
@@ -181,89 +220,84 @@ func main() {
 	// Channel for bounties
 	bountyChan := make(chan core.Bounty, 100)
 
+	// Keep stored scores current as bounties age and rules change
+	go rescoreLoop(ctx, storage, rules, time.Duration(cfg.RescoreIntervalSeconds)*time.Second)
+
+	// Archive or delete bounties retired for RETENTION_DAYS and reclaim space
+	retention := storageRetention(cfg)
+	go maintenanceLoop(ctx, storage, retention, time.Duration(cfg.MaintenanceIntervalHours)*time.Hour,
+		time.Duration(cfg.VacuumIntervalHours)*time.Hour)
+
 	// Start signal handling
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	// Start scanning loop
-	go func() {
-		// Initial scan
-		scanAll(ctx, scannersList, bountyChan)
-
-		ticker := time.NewTicker(time.Duration(cfg.PollIntervalSeconds) * time.Second)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				scanAll(ctx, scannersList, bountyChan)
+	// After a clean pass, listings a source stopped returning are closed
+	// once they have been missing for CLOSE_MISSING_AFTER_HOURS.
+	closeAfter := time.Duration(cfg.CloseMissingAfterHours) * time.Hour
+	closeMissing := func(platforms []string, started time.Time) {
+		for _, platform := range platforms {
+			closed, err := storage.CloseMissing(platform, started.Add(-closeAfter))
+			if err != nil {
+				logger.Error("Error closing missing %s bounties: %v", platform, err)
+			} else if closed > 0 {
+				logger.Info("Closed %d %s bounties no longer listed", closed, platform)
 			}
 		}
-	}()
-
-	// Process bounties
-	minScore := cfg.MinScore
+	}
 
-	go func() {
-		for bounty := range bountyChan {
-			bounty.URL = security.NormalizeURL(bounty.URL)
-			if bounty.URL == "" || !security.ValidateURL(bounty.URL) {
-				logger.Warn("Skipping bounty with invalid URL: %s", bounty.URL)
-				continue
-			}
+	// Each scanner polls on its own adaptive schedule
+	isNew := func(url string) bool {
+		isNew, err := storage.IsNew(security.NormalizeURL(url))
+		return err == nil && isNew
+	}
+	for _, s := range scannersList {
+		go runScanner(ctx, s, health, bountyChan, isNew, closeMissing)
+	}
 
-			if cfg.ValidateLinksHTTP {
-				timeout := time.Duration(cfg.LinkValidationTimeout) * time.Second
-				checkCtx, cancel := context.WithTimeout(ctx, timeout)
-				ok := security.ValidateURLReachable(checkCtx, bounty.URL, timeout)
-				cancel()
-				if !ok {
-					logger.Warn("Skipping bounty with unreachable URL: %s", bounty.URL)
-					continue
+	// The link check budget refills every POLL_INTERVAL_SECONDS
+	if links != nil {
+		go func() {
+			ticker := time.NewTicker(time.Duration(cfg.PollIntervalSeconds) * time.Second)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					links.BeginCycle()
 				}
 			}
+		}()
+	}
 
-			bounty.Title = security.SanitizeString(bounty.Title)
-			bounty.Platform = security.SanitizeString(bounty.Platform)
-			bounty.Reward = security.SanitizeString(bounty.Reward)
-			bounty.Currency = security.SanitizeString(bounty.Currency)
-			bounty.Description = security.SanitizeString(bounty.Description)
-
-			isNew, err := storage.IsNew(bounty.URL)
-			if err != nil {
-				logger.Error("Error checking if bounty is new: %v", err)
-				continue
-			}
-
-			if !isNew {
-				continue
-			}
-
-			// Calculate score
-			bounty.Score = core.CalculateUrgency(&bounty)
-
-			// Save to storage
-			if err := storage.Save(bounty); err != nil {
-				logger.Error("Error saving bounty: %v", err)
-				continue
-			}
-			webUI.Broadcast(bounty)
-
-			// Send notification if score is high enough
-			if bounty.Score >= minScore {
-				if err := notifier.Alert(bounty); err != nil {
-					logger.Error("Error sending desktop notification: %v", err)
-				}
-				if discordWebhook != "" {
-					if err := discordNotifier.Alert(bounty); err != nil {
-						logger.Error("Error sending Discord notification: %v", err)
-					}
-				}
-			}
-		}
-	}()
+	// Process bounties through the staged ingest pipeline
+	flow := &ingest{
+		storage:     storage,
+		links:       links,
+		oracle:      oracle,
+		webUI:       webUI,
+		desktop:     notifier,
+		minScore:    cfg.MinScore,
+		filterStage: core.FilterStage(cfg.RewardFilterStage),
+		thresholds: core.RewardThresholds{
+			MinUSD:           cfg.MinUSDValue,
+			MinByToken:       cfg.MinCurrencyAmounts,
+			DropUnquantified: cfg.DropUnquantifiedRewards,
+		},
+		dedup: core.DedupOptions{
+			TitleSimilarity: cfg.DedupTitleSimilarity,
+			Window:          time.Duration(cfg.DedupWindowDays) * 24 * time.Hour,
+		},
+		pending:     make(map[string]int),
+		unreachable: make(map[string]bool),
+	}
+	if discordWebhook != "" {
+		flow.discord = discordNotifier
+	}
+	ingestPipeline := pipeline.New(flow.stages(cfg)...)
+	webUI.SetPipelineSource(ingestPipeline)
+	go ingestPipeline.Run(ctx, bountyChan)
 
 	// Display UI if not disabled
 	var uiWG sync.WaitGroup
@@ -271,7 +305,7 @@ func main() {
 		uiWG.Add(1)
 		go func() {
 			defer uiWG.Done()
-			displayUI(ctx, storage, cfg.UIRefreshSeconds, cfg.TUIRecentLimit)
+			displayUI(ctx, storage, health, readCommands(ctx, os.Stdin), cfg.UIRefreshSeconds, cfg.TUIRecentLimit)
 		}()
 	}
 
@@ -299,28 +333,250 @@ func openLogFile(path string) *os.File {
 	return file
 }
 
-// scanAll executes all scanners concurrently and waits for them to complete.
-// It ensures that all found bounties are sent to the bountyChan before returning.
-func scanAll(ctx context.Context, scanners []core.Scanner, bountyChan chan<- core.Bounty) {
-	var wg sync.WaitGroup
-	for _, scanner := range scanners {
-		wg.Add(1)
-		go func(s core.Scanner) {
-			defer wg.Done()
-			ch, err := s.Scan(ctx)
-			if err != nil {
-				logger.Error("Error scanning %s: %v", s.Name(), err)
-				return
+// rulesFile tracks SCORING_RULES_PATH so edits are picked up without a restart.
+type rulesFile struct {
+	path    string
+	modTime time.Time
+}
+
+// reload installs the rules file when it changed since the last call and
+// reports whether it did. An empty path keeps the built-in rules.
+func (f *rulesFile) reload() (bool, error) {
+	if f.path == "" {
+		return false, nil
+	}
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return false, err
+	}
+	if info.ModTime().Equal(f.modTime) {
+		return false, nil
+	}
+	rules, err := config.LoadScoringRules(f.path)
+	if err != nil {
+		return false, err
+	}
+	if err := core.SetScoringRules(rules); err != nil {
+		return false, err
+	}
+	f.modTime = info.ModTime()
+	logger.Info("Loaded %d scoring rules from %s", len(rules), f.path)
+	return true, nil
+}
+
+// rescoreLoop expires overdue bounties and recomputes stored scores on
+// startup and every interval so recency decay and rule changes reach the
+// TUI and web API.
+func rescoreLoop(ctx context.Context, storage storage.Store, rules *rulesFile, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := rules.reload(); err != nil {
+			logger.Warn("Keeping previous scoring rules: %v", err)
+		}
+		if expired, err := storage.ExpireDue(time.Now()); err != nil {
+			logger.Error("Error expiring bounties: %v", err)
+		} else if expired > 0 {
+			logger.Info("Marked %d bounties expired", expired)
+		}
+		updated, err := storage.Rescore(core.ExplainUrgency)
+		if err != nil {
+			logger.Error("Error re-scoring bounties: %v", err)
+		} else if updated > 0 {
+			logger.Info("Re-scored %d bounties", updated)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// storageRetention returns the retention policy configured by
+// RETENTION_DAYS and RETENTION_ACTION.
+func storageRetention(cfg *config.Config) storage.RetentionPolicy {
+	policy := storage.RetentionPolicy{After: time.Duration(cfg.RetentionDays) * 24 * time.Hour}
+	if cfg.RetentionAction == "archive" {
+		policy.ArchiveDir = cfg.ArchiveDir
+	}
+	return policy
+}
+
+// buildPriceOracle returns the oracle selected by PRICE_ORACLE. The static
+// PRICE_TABLE also backs the HTTP oracle for tokens the API cannot price.
+func buildPriceOracle(cfg *config.Config) core.PriceOracle {
+	static := pricing.NewStaticOracle(cfg.PriceTable)
+	switch cfg.PriceOracle {
+	case "http":
+		logger.Info("Pricing rewards via %s", cfg.PriceOracleURL)
+		return pricing.NewHTTPOracle(pricing.HTTPOracleConfig{
+			BaseURL:  cfg.PriceOracleURL,
+			TTL:      time.Duration(cfg.PriceCacheSeconds) * time.Second,
+			IDs:      cfg.PriceCoinIDs,
+			Fallback: static,
+		})
+	case "static", "":
+		return static
+	default:
+		logger.Warn("Unknown PRICE_ORACLE %q; using static price table", cfg.PriceOracle)
+		return static
+	}
+}
+
+// buildScanners instantiates every scanner named in ENABLED_SCANNERS through
+// the scanner registry. Aliases of the same registration are built once.
+func buildScanners(cfg *config.Config) []scheduledScanner {
+	// Never every registered scanner: that includes DEMO and the templates
+	names := cfg.EnabledScanners
+	if len(names) == 0 {
+		names = config.Default().EnabledScanners
+	}
+
+	seen := make(map[string]bool, len(names))
+	defaults := scanners.Schedule{
+		Interval:      time.Duration(cfg.PollIntervalSeconds) * time.Second,
+		MinInterval:   time.Duration(cfg.PollMinIntervalSeconds) * time.Second,
+		MaxInterval:   time.Duration(cfg.PollMaxIntervalSeconds) * time.Second,
+		JitterPercent: cfg.PollJitterPercent,
+	}
+	var out []scheduledScanner
+	for _, name := range names {
+		instance := scanners.CanonicalName(name)
+		if seen[instance] {
+			continue
+		}
+		seen[instance] = true
+
+		opts := cfg.ScannerOptions(instance)
+		scanner, err := scanners.Build(name, opts)
+		if err != nil {
+			if errors.Is(err, scanners.ErrUnknownScanner) {
+				logger.Warn("Unknown scanner in config: %s", name)
+			} else {
+				logger.Error("Failed to initialize scanner: %v", err)
 			}
-			for bounty := range ch {
-				bountyChan <- bounty
+			continue
+		}
+		schedule, err := scanners.ResolveSchedule(opts, defaults)
+		if err != nil {
+			logger.Error("Failed to initialize scanner %s: %v", instance, err)
+			continue
+		}
+		out = append(out, scheduledScanner{Scanner: scanner, schedule: schedule})
+	}
+	return out
+}
+
+// scheduledScanner is an enabled scanner with its polling schedule.
+type scheduledScanner struct {
+	core.Scanner
+	schedule scanners.Schedule
+}
+
+// runScanner scans s on its own schedule until ctx is done. When a pass
+// finishes without errors and returned everything listed, onClean receives
+// the platforms it covered.
+func runScanner(ctx context.Context, s scheduledScanner, health *scanners.HealthTracker, bountyChan chan<- core.Bounty, isNew func(url string) bool, onClean func(platforms []string, started time.Time)) {
+	poller := scanners.NewPoller(s.schedule)
+	for {
+		fresh, failed := scanOnce(ctx, s.Scanner, health, bountyChan, isNew, onClean)
+		if ctx.Err() != nil {
+			return
+		}
+
+		wait := poller.Next(fresh, failed)
+		health.Schedule(s.Name(), poller.Interval(), time.Now().Add(wait))
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// scanOnce runs one pass of s, passing what it finds to bountyChan. It
+// returns how many of those bounties were not stored yet, and whether the
+// pass failed outright.
+func scanOnce(ctx context.Context, s core.Scanner, health *scanners.HealthTracker, bountyChan chan<- core.Bounty, isNew func(url string) bool, onClean func(platforms []string, started time.Time)) (int, bool) {
+	started := time.Now()
+	var errsMu sync.Mutex
+	var errs []error
+	scanCtx := scanners.WithErrorSink(ctx, func(err error) {
+		errsMu.Lock()
+		defer errsMu.Unlock()
+		errs = append(errs, err)
+	})
+	var partial atomic.Bool
+	scanCtx = scanners.WithPartialFlag(scanCtx, &partial)
+
+	ch, err := s.Scan(scanCtx)
+	if err != nil {
+		logger.Error("Error scanning %s: %v", s.Name(), err)
+		health.Record(s.Name(), started, 0, []error{err})
+		return 0, true
+	}
+	count, fresh := 0, 0
+	platforms := make(map[string]bool)
+	for bounty := range ch {
+		platforms[security.SanitizeString(bounty.Platform)] = true
+		if isNew(bounty.URL) {
+			fresh++
+		}
+		select {
+		case bountyChan <- bounty:
+		case <-ctx.Done():
+		}
+		count++
+	}
+	if ctx.Err() != nil {
+		return fresh, false
+	}
+
+	errsMu.Lock()
+	defer errsMu.Unlock()
+	health.Record(s.Name(), started, count, errs)
+
+	// A partial pass left out listings that are still open. A clean pass
+	// covers every platform the scanner emits, even ones it returned
+	// nothing for.
+	if len(errs) == 0 && !partial.Load() && onClean != nil {
+		if lister, ok := s.(scanners.PlatformScanner); ok {
+			for _, platform := range lister.Platforms() {
+				platforms[security.SanitizeString(platform)] = true
 			}
-		}(scanner)
+		}
+		names := make([]string, 0, len(platforms))
+		for platform := range platforms {
+			names = append(names, platform)
+		}
+		onClean(names, started)
 	}
-	wg.Wait()
+	return fresh, len(errs) > 0 && count == 0
 }
 
-func displayUI(ctx context.Context, storage *storage.SQLiteStorage, refreshSeconds int, recentLimit int) {
+// readCommands forwards each line typed into the TUI until ctx ends.
+func readCommands(ctx context.Context, r io.Reader) <-chan string {
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			select {
+			case ch <- strings.TrimSpace(scanner.Text()):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func displayUI(ctx context.Context, storage storage.Store, health *scanners.HealthTracker, commands <-chan string, refreshSeconds int, recentLimit int) {
 	// Display header
 	green := color.New(color.FgGreen).SprintFunc()
 	cyan := color.New(color.FgCyan).SprintFunc()
@@ -333,86 +589,246 @@ func displayUI(ctx context.Context, storage *storage.SQLiteStorage, refreshSecon
 	ticker := time.NewTicker(time.Duration(refreshSeconds) * time.Second)
 	defer ticker.Stop()
 
+	// selected is the 1-based row shown in the detail view; 0 hides it.
+	selected := 0
+	// rowIDs maps the row numbers of the last render to bounty IDs.
+	var rowIDs []string
+	notice := ""
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case cmd, ok := <-commands:
+			if !ok {
+				commands = nil
+				continue
+			}
+			notice = ""
+			if row, err := strconv.Atoi(cmd); err == nil && row > 0 {
+				selected = row
+			} else if cmd != "" {
+				notice = runTrackCommand(storage, rowIDs, cmd)
+			} else {
+				selected = 0
+			}
 		case <-ticker.C:
-			var sb strings.Builder
+		}
 
-			// Move cursor to top-left
-			sb.WriteString("\033[H")
+		var sb strings.Builder
 
-			// Header
-			sb.WriteString(green("========================================================\n"))
-			sb.WriteString(green("   🕷️  BOUNTY OS v8: OBSIDIAN // SNIPER ACTIVE\n"))
-			sb.WriteString(green("========================================================\n"))
-			sb.WriteString("Press Ctrl+C to exit\n\n")
+		// Move cursor to top-left
+		sb.WriteString("\033[H")
 
-			// Get recent bounties
-			bounties, err := storage.GetRecent(recentLimit)
-			if err != nil {
-				logger.Error("Error getting bounties: %v", err)
-				continue
+		// Header
+		sb.WriteString(green("========================================================\n"))
+		sb.WriteString(green("   🕷️  BOUNTY OS v8: OBSIDIAN // SNIPER ACTIVE\n"))
+		sb.WriteString(green("========================================================\n"))
+		sb.WriteString("Press Ctrl+C to exit\n\n")
+
+		// Scanner health
+		sb.WriteString("SCANNERS: ")
+		for i, h := range health.Snapshot() {
+			if i > 0 {
+				sb.WriteString("  ")
+			}
+			switch h.Status {
+			case core.HealthOK:
+				sb.WriteString(green("● " + h.Name))
+			case core.HealthDegraded:
+				sb.WriteString(yellow("◐ " + h.Name))
+			case core.HealthFailing:
+				sb.WriteString(red(fmt.Sprintf("✖ %s (%s)", h.Name, h.LastErrorKind)))
+			default:
+				sb.WriteString("○ " + h.Name)
 			}
+		}
+		sb.WriteString("\033[K\n\n")
 
-			// Sort by score
-			sort.Slice(bounties, func(i, j int) bool {
-				return bounties[i].Score > bounties[j].Score
-			})
-
-			// Print table header
-			sb.WriteString(fmt.Sprintf("%-8s | %-12s | %-10s | %-15s | %s\n", "SCORE", "PLATFORM", "PAYOUT", "PAYMENT", "TASK"))
-			sb.WriteString(strings.Repeat("-", 100) + "\n")
-
-			// Print bounties
-			for _, bounty := range bounties {
-				scoreStr := fmt.Sprintf("%d", bounty.Score)
-				if bounty.Score >= 80 {
-					scoreStr = red("⚡ " + scoreStr) // Critical
-				} else if bounty.Score >= 50 {
-					scoreStr = green(scoreStr) // Good
-				} else if bounty.Score >= 30 {
-					scoreStr = yellow(scoreStr) // Moderate
-				}
+		// Get recent bounties
+		bounties, err := storage.GetRecent(recentLimit)
+		if err != nil {
+			logger.Error("Error getting bounties: %v", err)
+			continue
+		}
 
-				// Payment styling
-				payStr := bounty.Reward
-				currencyUpper := strings.ToUpper(bounty.Currency)
-
-				if strings.Contains(currencyUpper, "USDC") ||
-					strings.Contains(currencyUpper, "USDT") ||
-					strings.Contains(currencyUpper, "SOL") ||
-					strings.Contains(currencyUpper, "ETH") {
-					payStr = cyan(payStr) // Crypto
-				} else if strings.Contains(currencyUpper, "CASHAPP") {
-					payStr = green(payStr) // Cash App
-				} else if strings.Contains(currencyUpper, "PAYPAL") {
-					payStr = yellow(payStr) // PayPal
-				}
+		// Sort by score
+		sort.Slice(bounties, func(i, j int) bool {
+			return bounties[i].Score > bounties[j].Score
+		})
 
-				platform := bounty.Platform
-				if len(platform) > 12 {
-					platform = platform[:12]
-				}
+		// Print table header
+		sb.WriteString(fmt.Sprintf("%-3s %-8s | %-12s | %-10s | %-15s | %-8s | %s\n", "#", "SCORE", "PLATFORM", "PAYOUT", "PAYMENT", "USD", "TASK"))
+		sb.WriteString(strings.Repeat("-", 100) + "\n")
+
+		tracked, err := storage.ListTracking("")
+		if err != nil {
+			logger.Error("Error getting tracked bounties: %v", err)
+		}
+		states := make(map[string]core.PipelineState, len(tracked))
+		for _, t := range tracked {
+			states[t.URL] = t.State
+		}
+
+		rowIDs = rowIDs[:0]
+
+		// Print bounties
+		for i, bounty := range bounties {
+			rowIDs = append(rowIDs, bounty.ID)
+			scoreStr := fmt.Sprintf("%d", bounty.Score)
+			if bounty.Score >= 80 {
+				scoreStr = red("⚡ " + scoreStr) // Critical
+			} else if bounty.Score >= 50 {
+				scoreStr = green(scoreStr) // Good
+			} else if bounty.Score >= 30 {
+				scoreStr = yellow(scoreStr) // Moderate
+			}
+
+			// Payment styling
+			payStr := bounty.Reward
+			currencyUpper := strings.ToUpper(bounty.Currency)
+
+			if strings.Contains(currencyUpper, "USDC") ||
+				strings.Contains(currencyUpper, "USDT") ||
+				strings.Contains(currencyUpper, "SOL") ||
+				strings.Contains(currencyUpper, "ETH") {
+				payStr = cyan(payStr) // Crypto
+			} else if strings.Contains(currencyUpper, "CASHAPP") {
+				payStr = green(payStr) // Cash App
+			} else if strings.Contains(currencyUpper, "PAYPAL") {
+				payStr = yellow(payStr) // PayPal
+			}
+
+			platform := bounty.Platform
+			if len(platform) > 12 {
+				platform = platform[:12]
+			}
+
+			currency := bounty.Currency
+			if len(currency) > 10 {
+				currency = currency[:10]
+			}
+
+			usd := "-"
+			if bounty.RewardUSD > 0 {
+				usd = fmt.Sprintf("$%.0f", bounty.RewardUSD)
+			}
+
+			title := bounty.Title[:min(len(bounty.Title), 40)]
+			if state, ok := states[bounty.URL]; ok {
+				title = yellow("["+string(state)+"] ") + title
+			}
+
+			sb.WriteString(fmt.Sprintf("%-3d %-8s | %-12s | %-10s | %-15s | %-8s | %s\033[K\n",
+				i+1, scoreStr, platform, currency, payStr, usd, title))
+			sb.WriteString(fmt.Sprintf("               └─ LINK: %s\033[K\n", bounty.URL))
+		}
 
-				currency := bounty.Currency
-				if len(currency) > 10 {
-					currency = currency[:10]
+		// Pipeline: tracked bounties, numbered after the open listings so
+		// closed ones can still be moved along.
+		if len(tracked) > 0 {
+			sb.WriteString("\n" + green("PIPELINE") + "\033[K\n")
+			for _, t := range tracked {
+				rowIDs = append(rowIDs, t.BountyID)
+				payout := "-"
+				if t.ExpectedPayout > 0 {
+					payout = strings.TrimSpace(fmt.Sprintf("%g %s", t.ExpectedPayout, t.PayoutCurrency))
+				}
+				sb.WriteString(fmt.Sprintf("%-3d %-12s | %-12s | %-15s | %s\033[K\n",
+					len(rowIDs), t.State, t.Platform[:min(len(t.Platform), 12)], payout, t.Title[:min(len(t.Title), 40)]))
+				if t.Notes != "" {
+					sb.WriteString(fmt.Sprintf("               └─ NOTES: %s\033[K\n", t.Notes))
 				}
+			}
+		}
 
-				sb.WriteString(fmt.Sprintf("%-8s | %-12s | %-10s | %-15s | %s\n",
-					scoreStr, platform, currency, payStr, bounty.Title[:min(len(bounty.Title), 40)]))
-				sb.WriteString(fmt.Sprintf("           └─ LINK: %s\n", bounty.URL))
+		// Detail view for the selected row, numbered like the track commands
+		if selected > 0 && selected <= len(rowIDs) {
+			if bounty, err := storage.GetByID(rowIDs[selected-1]); err != nil {
+				sb.WriteString("\n" + cyan(fmt.Sprintf("DETAIL #%d: unavailable (%v)", selected, err)) + "\033[K\n")
+			} else {
+				sb.WriteString("\n" + cyan(fmt.Sprintf("DETAIL #%d: %s", selected, bounty.Title)) + "\033[K\n")
+				sb.WriteString(fmt.Sprintf("  %s %s on %s — score %d\033[K\n", bounty.Reward, bounty.Currency, bounty.Platform, bounty.Score))
+				if len(bounty.ScoreBreakdown) == 0 {
+					sb.WriteString("  (no score breakdown recorded)\033[K\n")
+				}
+				for _, c := range bounty.ScoreBreakdown {
+					sb.WriteString(fmt.Sprintf("  %s\033[K\n", c))
+				}
 			}
+		}
+
+		sb.WriteString("\n")
+		if notice != "" {
+			sb.WriteString(notice + "\033[K\n")
+		}
+		sb.WriteString("Last updated: " + time.Now().Format("15:04:05") + "\033[K\n")
+		sb.WriteString("Row number + Enter for details; w/c/i/s/p <row> to watch/claim/start/submit/mark paid,\033[K\n")
+		sb.WriteString("n <row> <notes>, e <row> <amount> [token] for expected payout, u <row> to untrack: \033[J")
 
-			sb.WriteString("\n")
-			sb.WriteString("Last updated: " + time.Now().Format("15:04:05") + "   ")
+		// Output everything at once
+		fmt.Print(sb.String())
+	}
+}
 
-			// Output everything at once
-			fmt.Print(sb.String())
+// trackKeys maps TUI command letters to pipeline states.
+var trackKeys = map[string]core.PipelineState{
+	"w": core.StateWatch,
+	"c": core.StateClaimed,
+	"i": core.StateInProgress,
+	"s": core.StateSubmitted,
+	"p": core.StatePaid,
+}
+
+// runTrackCommand applies a "<key> <row> [args]" pipeline command typed into
+// the TUI and returns a status line describing the outcome.
+func runTrackCommand(storage storage.Store, rowIDs []string, cmd string) string {
+	fields := strings.Fields(cmd)
+	if len(fields) < 2 {
+		return fmt.Sprintf("Unknown command %q", cmd)
+	}
+	key := strings.ToLower(fields[0])
+	row, err := strconv.Atoi(fields[1])
+	if err != nil || row < 1 || row > len(rowIDs) {
+		return fmt.Sprintf("No row %s", fields[1])
+	}
+	id := rowIDs[row-1]
+
+	var update core.TrackingUpdate
+	switch key {
+	case "u":
+		if err := storage.DeleteTracking(id); err != nil {
+			return fmt.Sprintf("Untrack failed: %v", err)
+		}
+		return fmt.Sprintf("Row %d untracked", row)
+	case "n":
+		notes := strings.Join(fields[2:], " ")
+		update.Notes = &notes
+	case "e":
+		if len(fields) < 3 {
+			return "Usage: e <row> <amount> [token]"
 		}
+		amount, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil || amount < 0 {
+			return fmt.Sprintf("Invalid amount %q", fields[2])
+		}
+		update.ExpectedPayout = &amount
+		if len(fields) > 3 {
+			update.PayoutCurrency = &fields[3]
+		}
+	default:
+		state, ok := trackKeys[key]
+		if !ok {
+			return fmt.Sprintf("Unknown command %q", cmd)
+		}
+		update.State = &state
+	}
+
+	tracking, err := storage.UpdateTracking(id, update)
+	if err != nil {
+		return fmt.Sprintf("Update failed: %v", err)
 	}
+	return fmt.Sprintf("Row %d: %s (%s)", row, tracking.State, tracking.Title)
 }
 
 func min(a, b int) int {
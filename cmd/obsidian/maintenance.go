@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"bountyos-v8/internal/adapters/storage"
+	"bountyos-v8/internal/core"
+)
+
+// maintenanceLoop applies the retention policy on startup and every
+// interval, and vacuums the database once vacuumEvery has passed since the
+// last vacuum in the audit log. A zero vacuumEvery never vacuums.
+func maintenanceLoop(ctx context.Context, store storage.Store, policy storage.RetentionPolicy, interval, vacuumEvery time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		removed, err := storage.ApplyRetention(store, policy, time.Now())
+		if err != nil {
+			logger.Error("Error applying retention policy: %v", err)
+		}
+		if removed > 0 {
+			logger.Info("Retention removed %d retired bounties", removed)
+		}
+		if vacuumEvery > 0 {
+			vacuumIfDue(ctx, store, vacuumEvery)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// vacuumIfDue vacuums store when no vacuum is recorded within every, and
+// records the outcome in the audit log.
+func vacuumIfDue(ctx context.Context, store storage.Store, every time.Duration) {
+	last, err := store.GetAuditLog(core.AuditVacuum, 1)
+	if err != nil {
+		logger.Error("Error reading audit log: %v", err)
+		return
+	}
+	if len(last) > 0 && time.Since(last[0].At) < every {
+		return
+	}
+
+	entry := core.AuditEntry{Action: core.AuditVacuum}
+	started := time.Now()
+	if err := store.Vacuum(ctx); err != nil {
+		logger.Error("Error vacuuming database: %v", err)
+		entry.Detail = "vacuum failed: " + err.Error()
+	} else {
+		logger.Info("Vacuumed database in %s", time.Since(started).Round(time.Millisecond))
+	}
+	if err := store.RecordAudit(entry); err != nil {
+		logger.Error("Error recording vacuum: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"bountyos-v8/internal/adapters/storage"
+)
+
+const migrateUsage = "usage: obsidian migrate status|up"
+
+// runMigrate implements "obsidian migrate status" and "obsidian migrate up"
+// against the database dsn opened with driver, returning the process exit
+// code.
+func runMigrate(driver, dsn string, args []string, out io.Writer) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "status":
+		states, err := storage.MigrationStatus(driver, dsn)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read schema version: %v\n", err)
+			return 1
+		}
+		if driver == storage.DriverSQLite {
+			fmt.Fprintf(out, "Database: %s\n", dsn)
+		} else {
+			// Connection strings may carry a password.
+			fmt.Fprintf(out, "Database: %s\n", driver)
+		}
+		for _, state := range states {
+			applied := "pending"
+			if state.AppliedAt != nil {
+				applied = "applied " + state.AppliedAt.Local().Format(time.DateTime)
+			}
+			fmt.Fprintf(out, "  %3d  %-34s %s\n", state.Version, state.Name, applied)
+		}
+		return 0
+	case "up":
+		applied, err := storage.MigrateUp(driver, dsn)
+		for _, state := range applied {
+			fmt.Fprintf(out, "Applied %d: %s\n", state.Version, state.Name)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Migration failed: %v\n", err)
+			return 1
+		}
+		if len(applied) == 0 {
+			fmt.Fprintf(out, "Schema is up to date at version %d\n", storage.SchemaVersion())
+		}
+		return 0
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+}
+
+// checkSchema fails when AUTO_MIGRATE is off and the database dsn has
+// pending migrations.
+func checkSchema(driver, dsn string) error {
+	if driver == storage.DriverMemory {
+		return nil
+	}
+	states, err := storage.MigrationStatus(driver, dsn)
+	if err != nil {
+		return err
+	}
+	pending := 0
+	for _, state := range states {
+		if state.AppliedAt == nil {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d pending schema migration(s); run `obsidian migrate up`", pending)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"bountyos-v8/internal/adapters/storage"
+	"bountyos-v8/internal/core"
+)
+
+const searchUsage = "usage: obsidian search [flags] <query>"
+
+// runSearch implements "obsidian search", printing full-text matches from
+// the database dsn opened with driver, and returns the process exit code.
+func runSearch(driver, dsn string, args []string, out io.Writer) int {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, searchUsage)
+		fs.PrintDefaults()
+	}
+	platform := fs.String("platform", "", "Only this platform, e.g. GITHUB or GITHUB/BOUNTY")
+	payment := fs.String("payment", "", "Only this payment type: crypto, p2p or fiat")
+	status := fs.String("status", "", "Only this status: open, closed, expired or claimed")
+	minScore := fs.Int("min-score", -1, "Minimum score")
+	maxScore := fs.Int("max-score", -1, "Maximum score")
+	minUSD := fs.Float64("min-usd", 0, "Minimum reward in USD")
+	maxUSD := fs.Float64("max-usd", 0, "Maximum reward in USD")
+	since := fs.String("since", "", "Created on or after this date (YYYY-MM-DD or RFC 3339)")
+	until := fs.String("until", "", "Created before this date (YYYY-MM-DD or RFC 3339)")
+	limit := fs.Int("limit", 20, "Results per page")
+	offset := fs.Int("offset", 0, "Results to skip")
+	asJSON := fs.Bool("json", false, "Print the result page as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	filters := core.SearchFilters{
+		Platform:    *platform,
+		PaymentType: *payment,
+		MinUSD:      *minUSD,
+		MaxUSD:      *maxUSD,
+		Limit:       *limit,
+		Offset:      *offset,
+	}
+	if *status != "" {
+		filters.Status = core.ParseBountyStatus(*status)
+	}
+	if *minScore >= 0 {
+		filters.MinScore = minScore
+	}
+	if *maxScore >= 0 {
+		filters.MaxScore = maxScore
+	}
+	for _, date := range []struct {
+		flag  string
+		value string
+		dst   *time.Time
+	}{{"since", *since, &filters.CreatedAfter}, {"until", *until, &filters.CreatedBefore}} {
+		if date.value == "" {
+			continue
+		}
+		t, err := parseSearchDate(date.value)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid -%s %q: want YYYY-MM-DD or RFC 3339\n", date.flag, date.value)
+			return 2
+		}
+		*date.dst = t
+	}
+
+	store, err := storage.Open(driver, dsn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open storage: %v\n", err)
+		return 1
+	}
+	defer store.Close()
+
+	result, err := store.Search(strings.Join(fs.Args(), " "), filters)
+	if errors.Is(err, core.ErrInvalidQuery) {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Search failed: %v\n", err)
+		return 1
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return 1
+		}
+		return 0
+	}
+	if result.Total == 0 {
+		fmt.Fprintln(out, "No matching bounties")
+		return 0
+	}
+	for _, b := range result.Bounties {
+		fmt.Fprintf(out, "%3d  %-16s %-18.18s %s\n     %s\n", b.Score, b.Platform, b.Reward, b.Title, b.URL)
+	}
+	fmt.Fprintf(out, "Showing %d-%d of %d\n", result.Offset+1, result.Offset+len(result.Bounties), result.Total)
+	return 0
+}
+
+func parseSearchDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
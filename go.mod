@@ -5,6 +5,7 @@ go 1.22
 require (
 	github.com/fatih/color v1.15.0
 	github.com/gorilla/websocket v1.5.3
+	github.com/lib/pq v1.10.9
 	github.com/mattn/go-sqlite3 v1.14.23
 	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,161 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"bountyos-v8/internal/core"
+	"bountyos-v8/internal/security"
+)
+
+// HTTPOracle fetches prices from a CoinGecko-compatible /simple/price
+// endpoint and caches each token for a TTL. Tokens it cannot price are
+// delegated to the fallback oracle.
+type HTTPOracle struct {
+	client   *http.Client
+	baseURL  string
+	ttl      time.Duration
+	ids      map[string]string
+	fallback core.PriceOracle
+
+	mu    sync.Mutex
+	cache map[string]cachedPrice
+}
+
+type HTTPOracleConfig struct {
+	BaseURL  string
+	TTL      time.Duration
+	IDs      map[string]string // token symbol -> API coin id
+	Fallback core.PriceOracle
+}
+
+type cachedPrice struct {
+	price     float64
+	err       error
+	fetchedAt time.Time
+}
+
+// DefaultCoinIDs maps common reward tokens to CoinGecko coin ids.
+func DefaultCoinIDs() map[string]string {
+	return map[string]string{
+		"BTC":   "bitcoin",
+		"ETH":   "ethereum",
+		"SOL":   "solana",
+		"USDC":  "usd-coin",
+		"USDT":  "tether",
+		"DAI":   "dai",
+		"MATIC": "matic-network",
+		"AVAX":  "avalanche-2",
+		"ARB":   "arbitrum",
+		"OP":    "optimism",
+	}
+}
+
+func NewHTTPOracle(cfg HTTPOracleConfig) *HTTPOracle {
+	baseURL := strings.TrimRight(strings.TrimSpace(cfg.BaseURL), "/")
+	if baseURL == "" {
+		baseURL = "https://api.coingecko.com/api/v3"
+	}
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+	ids := DefaultCoinIDs()
+	for token, id := range cfg.IDs {
+		token = strings.ToUpper(strings.TrimSpace(token))
+		if token != "" && strings.TrimSpace(id) != "" {
+			ids[token] = strings.TrimSpace(id)
+		}
+	}
+
+	return &HTTPOracle{
+		client:   security.SecureHTTPClient(),
+		baseURL:  baseURL,
+		ttl:      ttl,
+		ids:      ids,
+		fallback: cfg.Fallback,
+		cache:    make(map[string]cachedPrice),
+	}
+}
+
+func (o *HTTPOracle) PriceUSD(ctx context.Context, token string) (float64, error) {
+	token = strings.ToUpper(strings.TrimSpace(token))
+
+	o.mu.Lock()
+	cached, ok := o.cache[token]
+	o.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < o.ttl {
+		return o.result(ctx, token, cached.price, cached.err)
+	}
+
+	price, err := o.fetch(ctx, token)
+	if err != nil && ctx.Err() != nil {
+		return 0, err
+	}
+
+	// Failures are cached too so an outage costs one request per TTL.
+	o.mu.Lock()
+	o.cache[token] = cachedPrice{price: price, err: err, fetchedAt: time.Now()}
+	o.mu.Unlock()
+
+	if err != nil {
+		security.GetLogger().Warn("Price lookup for %s failed: %v", token, err)
+	}
+	return o.result(ctx, token, price, err)
+}
+
+func (o *HTTPOracle) result(ctx context.Context, token string, price float64, err error) (float64, error) {
+	if err == nil {
+		return price, nil
+	}
+	if o.fallback != nil {
+		return o.fallback.PriceUSD(ctx, token)
+	}
+	return 0, err
+}
+
+func (o *HTTPOracle) fetch(ctx context.Context, token string) (float64, error) {
+	id, ok := o.ids[token]
+	if !ok {
+		return 0, fmt.Errorf("%w: no coin id for %s", core.ErrPriceUnavailable, token)
+	}
+
+	endpoint := o.baseURL + "/simple/price?ids=" + url.QueryEscape(id) + "&vs_currencies=usd"
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	security.SecureRequest(req, "")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("price API returned status %d", resp.StatusCode)
+	}
+
+	var payload map[string]map[string]float64
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return 0, fmt.Errorf("invalid price payload: %w", err)
+	}
+	price := payload[id]["usd"]
+	if price <= 0 {
+		return 0, fmt.Errorf("%w: %s missing from price API response", core.ErrPriceUnavailable, token)
+	}
+	return price, nil
+}
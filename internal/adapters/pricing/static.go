@@ -0,0 +1,45 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"bountyos-v8/internal/core"
+)
+
+// StaticOracle prices tokens from a fixed table, typically PRICE_TABLE in
+// config. It is also used as the fallback for HTTPOracle.
+type StaticOracle struct {
+	prices map[string]float64
+}
+
+// DefaultPrices covers dollar-pegged symbols so fiat and stablecoin rewards
+// are valued even without a configured table.
+func DefaultPrices() map[string]float64 {
+	return map[string]float64{
+		"USD":  1,
+		"USDC": 1,
+		"USDT": 1,
+		"DAI":  1,
+	}
+}
+
+func NewStaticOracle(prices map[string]float64) *StaticOracle {
+	table := DefaultPrices()
+	for token, price := range prices {
+		token = strings.ToUpper(strings.TrimSpace(token))
+		if token == "" || price <= 0 {
+			continue
+		}
+		table[token] = price
+	}
+	return &StaticOracle{prices: table}
+}
+
+func (o *StaticOracle) PriceUSD(ctx context.Context, token string) (float64, error) {
+	if price, ok := o.prices[strings.ToUpper(strings.TrimSpace(token))]; ok {
+		return price, nil
+	}
+	return 0, fmt.Errorf("%w: no static price for %s", core.ErrPriceUnavailable, token)
+}
@@ -55,6 +55,23 @@ type BountycasterRewardSummary struct {
 	} `json:"token"`
 }
 
+func init() {
+	Register(Registration{
+		Name:        "BOUNTYCASTER",
+		Description: "Bountycaster Farcaster bounties",
+		Schema: ConfigSchema{
+			{Key: "base_url", Type: FieldString, Default: "https://www.bountycaster.xyz/api/v1/bounties", Description: "Listings API endpoint"},
+			{Key: "statuses", Type: FieldStringList, Default: []string{"open"}, Description: "Listing statuses to fetch"},
+		},
+		Factory: func(opts Options) (core.Scanner, error) {
+			return NewBountycasterScanner(BountycasterScannerConfig{
+				BaseURL:  opts.String("base_url"),
+				Statuses: opts.StringList("statuses"),
+			}), nil
+		},
+	})
+}
+
 func NewBountycasterScanner(cfg BountycasterScannerConfig) *BountycasterScanner {
 	baseURL := strings.TrimRight(cfg.BaseURL, "/")
 	if baseURL == "" {
@@ -76,6 +93,11 @@ func (s *BountycasterScanner) Name() string {
 	return "Bountycaster"
 }
 
+// Platforms returns the platform of every bounty the scanner emits.
+func (s *BountycasterScanner) Platforms() []string {
+	return []string{"BOUNTYCASTER"}
+}
+
 func (s *BountycasterScanner) Scan(ctx context.Context) (<-chan core.Bounty, error) {
 	ch := make(chan core.Bounty)
 
@@ -85,7 +107,7 @@ func (s *BountycasterScanner) Scan(ctx context.Context) (<-chan core.Bounty, err
 		for _, status := range s.normalizedStatuses() {
 			if err := s.scanStatus(ctx, status, ch); err != nil {
 				security.GetLogger().Error("Error fetching Bountycaster (%s): %v", status, err)
-				s.emitMockBounties(ch, status)
+				reportScanError(ctx, err)
 			}
 		}
 	}()
@@ -114,12 +136,12 @@ func (s *BountycasterScanner) scanStatus(ctx context.Context, status string, ch
 		return err
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("unexpected status %d from %s: %s", resp.StatusCode, url, responseSnippet(body))
+		return newStatusError(url, resp.StatusCode, body)
 	}
 
 	var results BountycasterResponse
 	if err := json.Unmarshal(body, &results); err != nil {
-		return fmt.Errorf("invalid JSON from %s: %w (snippet: %s)", url, err, responseSnippet(body))
+		return newDecodeError(url, err, body)
 	}
 
 	for _, item := range results.Bounties {
@@ -181,6 +203,7 @@ func (s *BountycasterScanner) scanStatus(ctx context.Context, status string, ch
 			Description: item.SummaryText,
 			Tags:        tags,
 			PaymentType: paymentType,
+			Status:      core.ParseBountyStatus(status),
 		}
 
 		select {
@@ -237,43 +260,3 @@ func responseSnippet(body []byte) string {
 	}
 	return security.SanitizeString(snippet)
 }
-
-func (s *BountycasterScanner) emitMockBounties(ch chan<- core.Bounty, status string) {
-	statusTag := status
-	if statusTag == "" {
-		statusTag = "active"
-	}
-	idSuffix := "-" + statusTag
-	urlSuffix := "?status=" + statusTag
-
-	mockBounties := []core.Bounty{
-		{
-			ID:          "bc-1" + idSuffix,
-			Title:       "Dune Dashboard for Seamless Protocol",
-			Platform:    "BOUNTYCASTER",
-			Reward:      "15000",
-			Currency:    "USDC",
-			URL:         "https://bountycaster.xyz/bounty/0x11ce0fa8" + urlSuffix,
-			CreatedAt:   time.Now().Add(-30 * time.Minute),
-			Description: "Create a Dune dashboard for Seamless Protocol metrics.",
-			Tags:        []string{"farcaster", "dune", "data", statusTag},
-			PaymentType: "crypto",
-		},
-		{
-			ID:          "bc-2" + idSuffix,
-			Title:       "Restaurant recommendations in NYC",
-			Platform:    "BOUNTYCASTER",
-			Reward:      "50",
-			Currency:    "USDC",
-			URL:         "https://bountycaster.xyz/bounty/0x22df1gb9" + urlSuffix,
-			CreatedAt:   time.Now().Add(-2 * time.Hour),
-			Description: "Looking for the best pizza spots in Brooklyn.",
-			Tags:        []string{"farcaster", "nyc", "pizza", statusTag},
-			PaymentType: "crypto",
-		},
-	}
-
-	for _, b := range mockBounties {
-		ch <- b
-	}
-}
@@ -0,0 +1,110 @@
+package scanners
+
+import (
+	"context"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+// DemoScanner emits a fixed set of sample bounties. It only runs when
+// selected explicitly (ENABLED_SCANNERS: DEMO or the --demo flag); real
+// scanners never fall back to it.
+type DemoScanner struct {
+	baseURL string
+}
+
+func init() {
+	Register(Registration{
+		Name:        "DEMO",
+		Description: "Sample bounties for demos and UI development",
+		Schema: ConfigSchema{
+			{Key: "base_url", Type: FieldString, Default: "https://example.com/bountyos-demo", Description: "Prefix for sample bounty links"},
+		},
+		Factory: func(opts Options) (core.Scanner, error) {
+			return NewDemoScanner(opts.String("base_url")), nil
+		},
+	})
+}
+
+func NewDemoScanner(baseURL string) *DemoScanner {
+	if baseURL == "" {
+		baseURL = "https://example.com/bountyos-demo"
+	}
+	return &DemoScanner{baseURL: baseURL}
+}
+
+func (s *DemoScanner) Name() string {
+	return "Demo"
+}
+
+func (s *DemoScanner) Scan(ctx context.Context) (<-chan core.Bounty, error) {
+	ch := make(chan core.Bounty)
+
+	go func() {
+		defer close(ch)
+
+		for _, b := range s.bounties(time.Now()) {
+			select {
+			case ch <- b:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (s *DemoScanner) bounties(now time.Time) []core.Bounty {
+	return []core.Bounty{
+		{
+			ID:          "demo-st-1",
+			Title:       "ERA Wallet Comparison Bounty",
+			Platform:    "SUPERTEAM",
+			Reward:      "500",
+			Currency:    "USDC",
+			URL:         s.baseURL + "/superteam/era-wallet-comparison",
+			CreatedAt:   now.Add(-1 * time.Hour),
+			Description: "Compare ERA wallet with other Solana wallets.",
+			Tags:        []string{"demo", "solana", "wallet", "research"},
+			PaymentType: "crypto",
+		},
+		{
+			ID:          "demo-st-2",
+			Title:       "Marketing Growth Lead",
+			Platform:    "SUPERTEAM",
+			Reward:      "2000",
+			Currency:    "USDC",
+			URL:         s.baseURL + "/superteam/marketing-growth-lead",
+			CreatedAt:   now.Add(-5 * time.Hour),
+			Description: "Lead marketing growth for LaunchpadTrade.",
+			Tags:        []string{"demo", "solana", "marketing"},
+			PaymentType: "crypto",
+		},
+		{
+			ID:          "demo-bc-1",
+			Title:       "Dune Dashboard for Seamless Protocol",
+			Platform:    "BOUNTYCASTER",
+			Reward:      "15000",
+			Currency:    "USDC",
+			URL:         s.baseURL + "/bountycaster/dune-dashboard",
+			CreatedAt:   now.Add(-30 * time.Minute),
+			Description: "Create a Dune dashboard for Seamless Protocol metrics.",
+			Tags:        []string{"demo", "farcaster", "dune", "data"},
+			PaymentType: "crypto",
+		},
+		{
+			ID:          "demo-bc-2",
+			Title:       "Restaurant recommendations in NYC",
+			Platform:    "BOUNTYCASTER",
+			Reward:      "50",
+			Currency:    "USDC",
+			URL:         s.baseURL + "/bountycaster/nyc-restaurants",
+			CreatedAt:   now.Add(-2 * time.Hour),
+			Description: "Looking for the best pizza spots in Brooklyn.",
+			Tags:        []string{"demo", "farcaster", "nyc", "pizza"},
+			PaymentType: "crypto",
+		},
+	}
+}
@@ -0,0 +1,413 @@
+package scanners
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"bountyos-v8/internal/core"
+	"bountyos-v8/internal/security"
+)
+
+// DefaultRewardPattern matches "$500", "500 USDC" or "USDC 500" style amounts.
+// Custom patterns must expose "amount" and optionally "currency" named groups.
+const DefaultRewardPattern = `(?i)(?P<currency>\$|USDC|USDT|DAI|ETH|SOL|BTC|OP|ARB)\s?(?P<amount>\d[\d,]*(?:\.\d+)?[kK]?)|(?P<amount>\d[\d,]*(?:\.\d+)?[kK]?)\s?(?P<currency>USDC|USDT|DAI|ETH|SOL|BTC|OP|ARB|USD)\b`
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// FeedScanner polls RSS 2.0 and Atom feeds and turns entries into bounties.
+type FeedScanner struct {
+	client       *http.Client
+	name         string
+	feeds        []string
+	platform     string
+	currency     string
+	paymentType  string
+	tags         []string
+	rewardRe     *regexp.Regexp
+	closeMissing bool
+}
+
+type FeedScannerConfig struct {
+	Name          string
+	Feeds         []string
+	Platform      string
+	Currency      string
+	PaymentType   string
+	Tags          []string
+	RewardPattern string
+	// CloseMissing lets entries that leave the feed be closed as missing.
+	// Most feeds show only their latest entries, so it is off by default
+	// and every pass is partial.
+	CloseMissing bool
+}
+
+type rssDocument struct {
+	Channel struct {
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        string   `xml:"guid"`
+	Description string   `xml:"description"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+}
+
+type atomDocument struct {
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID        string `xml:"id"`
+	Title     string `xml:"title"`
+	Summary   string `xml:"summary"`
+	Content   string `xml:"content"`
+	Published string `xml:"published"`
+	Updated   string `xml:"updated"`
+	Links     []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	} `xml:"link"`
+	Categories []struct {
+		Term string `xml:"term,attr"`
+	} `xml:"category"`
+}
+
+// feedEntry is the format-neutral view shared by RSS items and Atom entries.
+type feedEntry struct {
+	id          string
+	title       string
+	link        string
+	description string
+	published   string
+	categories  []string
+}
+
+func init() {
+	Register(Registration{
+		Name:        "RSS",
+		Aliases:     []string{"ATOM", "FEED"},
+		Description: "RSS 2.0 and Atom feeds",
+		Schema: ConfigSchema{
+			{Key: "name", Type: FieldString, Default: "RSS Feeds", Description: "Display name of the scanner"},
+			{Key: "feeds", Type: FieldStringList, Required: true, Description: "Feed URLs to poll"},
+			{Key: "platform", Type: FieldString, Default: "RSS", Description: "Platform label for emitted bounties"},
+			{Key: "currency", Type: FieldString, Description: "Currency used when the reward pattern finds none"},
+			{Key: "payment_type", Type: FieldString, Description: "Payment type for emitted bounties"},
+			{Key: "tags", Type: FieldStringList, Description: "Static tags added to every bounty"},
+			{Key: "reward_regex", Type: FieldString, Default: DefaultRewardPattern, Description: "Pattern with amount/currency groups"},
+			{Key: "close_missing", Type: FieldBool, Default: false, Description: "Close bounties whose entries leave the feed (only for feeds listing every open bounty)"},
+		},
+		Factory: func(opts Options) (core.Scanner, error) {
+			scanner, err := NewFeedScanner(FeedScannerConfig{
+				Name:          opts.String("name"),
+				Feeds:         opts.StringList("feeds"),
+				Platform:      opts.String("platform"),
+				Currency:      opts.String("currency"),
+				PaymentType:   opts.String("payment_type"),
+				Tags:          opts.StringList("tags"),
+				RewardPattern: opts.String("reward_regex"),
+				CloseMissing:  opts.Bool("close_missing"),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return scanner, nil
+		},
+	})
+}
+
+func NewFeedScanner(cfg FeedScannerConfig) (*FeedScanner, error) {
+	if len(cfg.Feeds) == 0 {
+		return nil, fmt.Errorf("at least one feed URL is required")
+	}
+
+	pattern := cfg.RewardPattern
+	if strings.TrimSpace(pattern) == "" {
+		pattern = DefaultRewardPattern
+	}
+	rewardRe, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reward_regex: %w", err)
+	}
+	if rewardRe.SubexpIndex("amount") < 0 {
+		return nil, fmt.Errorf("reward_regex must define an \"amount\" group")
+	}
+
+	name := cfg.Name
+	if name == "" {
+		name = "RSS Feeds"
+	}
+	platform := strings.ToUpper(strings.TrimSpace(cfg.Platform))
+	if platform == "" {
+		platform = "RSS"
+	}
+	paymentType := cfg.PaymentType
+	if paymentType == "" {
+		paymentType = "unknown"
+	}
+
+	return &FeedScanner{
+		client:       security.SecureHTTPClient(),
+		name:         name,
+		feeds:        cfg.Feeds,
+		platform:     platform,
+		currency:     cfg.Currency,
+		paymentType:  paymentType,
+		tags:         cfg.Tags,
+		rewardRe:     rewardRe,
+		closeMissing: cfg.CloseMissing,
+	}, nil
+}
+
+func (s *FeedScanner) Name() string {
+	return s.name
+}
+
+// Platforms returns the platform of every bounty the scanner emits.
+func (s *FeedScanner) Platforms() []string {
+	return []string{s.platform}
+}
+
+// Scan fetches every feed. Unless close_missing is set the pass is
+// partial: an entry that ages out of a feed is not a closed bounty.
+func (s *FeedScanner) Scan(ctx context.Context) (<-chan core.Bounty, error) {
+	ch := make(chan core.Bounty)
+	if !s.closeMissing {
+		markPartial(ctx)
+	}
+
+	go func() {
+		defer close(ch)
+
+		for _, feedURL := range s.feeds {
+			if ctx.Err() != nil {
+				return
+			}
+			if err := s.scanFeed(ctx, feedURL, ch); err != nil {
+				security.GetLogger().Error("Error fetching feed %s: %v", feedURL, err)
+				reportScanError(ctx, err)
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (s *FeedScanner) scanFeed(ctx context.Context, feedURL string, ch chan<- core.Bounty) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
+	if err != nil {
+		return err
+	}
+
+	security.SecureRequest(req, "")
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := doRequestWithRetry(ctx, s.client, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newStatusError(feedURL, resp.StatusCode, body)
+	}
+
+	entries, err := parseFeed(body)
+	if err != nil {
+		return newDecodeError(feedURL, err, body)
+	}
+
+	for _, entry := range entries {
+		if entry.title == "" || entry.link == "" {
+			continue
+		}
+
+		// Entries without a usable date keep a zero creation time.
+		createdAt, _ := parseFeedTime(entry.published)
+
+		description := html.UnescapeString(htmlTagPattern.ReplaceAllString(entry.description, " "))
+		description = strings.Join(strings.Fields(description), " ")
+		reward, currency := s.extractReward(entry.title)
+		if reward == "" {
+			reward, currency = s.extractReward(description)
+		}
+		if currency == "" {
+			currency = s.currency
+		}
+
+		tags := append([]string{"feed"}, s.tags...)
+		for _, category := range entry.categories {
+			if trimmed := strings.TrimSpace(category); trimmed != "" {
+				tags = append(tags, trimmed)
+			}
+		}
+
+		id := entry.id
+		if id == "" {
+			id = entry.link
+		}
+
+		bounty := core.Bounty{
+			ID:          id,
+			Title:       entry.title,
+			Platform:    s.platform,
+			Reward:      reward,
+			Currency:    currency,
+			URL:         entry.link,
+			CreatedAt:   createdAt,
+			Description: description,
+			Tags:        tags,
+			PaymentType: s.paymentType,
+		}
+
+		select {
+		case ch <- bounty:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// extractReward applies the reward pattern and returns the first amount and
+// currency it captures. A "$" capture is reported as USD.
+func (s *FeedScanner) extractReward(text string) (string, string) {
+	match := s.rewardRe.FindStringSubmatch(text)
+	if match == nil {
+		return "", ""
+	}
+
+	amount := ""
+	currency := ""
+	for i, group := range s.rewardRe.SubexpNames() {
+		switch {
+		case group == "amount" && amount == "":
+			amount = strings.TrimSpace(match[i])
+		case group == "currency" && currency == "":
+			currency = strings.ToUpper(strings.TrimSpace(match[i]))
+		}
+	}
+	if currency == "$" {
+		currency = "USD"
+	}
+	return amount, currency
+}
+
+func parseFeed(body []byte) ([]feedEntry, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.Strict = false
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		// Most feeds declare UTF-8 or a Latin-1 superset; decode bytes as-is.
+		return input, nil
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch strings.ToLower(start.Name.Local) {
+		case "rss":
+			var doc rssDocument
+			if err := decoder.DecodeElement(&doc, &start); err != nil {
+				return nil, err
+			}
+			entries := make([]feedEntry, 0, len(doc.Channel.Items))
+			for _, item := range doc.Channel.Items {
+				entries = append(entries, feedEntry{
+					id:          strings.TrimSpace(item.GUID),
+					title:       strings.TrimSpace(item.Title),
+					link:        strings.TrimSpace(item.Link),
+					description: item.Description,
+					published:   item.PubDate,
+					categories:  item.Categories,
+				})
+			}
+			return entries, nil
+		case "feed":
+			var doc atomDocument
+			if err := decoder.DecodeElement(&doc, &start); err != nil {
+				return nil, err
+			}
+			entries := make([]feedEntry, 0, len(doc.Entries))
+			for _, entry := range doc.Entries {
+				link := ""
+				for _, l := range entry.Links {
+					if l.Rel == "" || l.Rel == "alternate" {
+						link = strings.TrimSpace(l.Href)
+						break
+					}
+				}
+				published := entry.Published
+				if published == "" {
+					published = entry.Updated
+				}
+				description := entry.Summary
+				if description == "" {
+					description = entry.Content
+				}
+				categories := make([]string, 0, len(entry.Categories))
+				for _, c := range entry.Categories {
+					categories = append(categories, c.Term)
+				}
+				entries = append(entries, feedEntry{
+					id:          strings.TrimSpace(entry.ID),
+					title:       strings.TrimSpace(entry.Title),
+					link:        link,
+					description: description,
+					published:   published,
+					categories:  categories,
+				})
+			}
+			return entries, nil
+		default:
+			return nil, fmt.Errorf("unsupported root element <%s>", start.Name.Local)
+		}
+	}
+}
+
+var feedTimeLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	time.RFC822Z,
+	time.RFC822,
+}
+
+func parseFeedTime(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range feedTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,157 @@
+package scanners
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+func TestFeedScanner_ScanRSSAndAtom(t *testing.T) {
+	rss := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"><channel><title>Hackathons</title>
+	<item>
+		<title>Build a Solana indexer - 2,500 USDC</title>
+		<link>https://hack.example/indexer</link>
+		<guid>hack-1</guid>
+		<description>&lt;p&gt;Index &lt;b&gt;everything&lt;/b&gt;&lt;/p&gt;</description>
+		<pubDate>Mon, 02 Jan 2006 15:04:05 -0700</pubDate>
+		<category>solana</category>
+	</item>
+	<item>
+		<title>Docs cleanup</title>
+		<link>https://hack.example/docs</link>
+		<description>Reward: $150 for merged PR</description>
+		<pubDate>Tue, 3 Jan 2006 10:00:00 GMT</pubDate>
+	</item>
+</channel></rss>`
+	atom := `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+	<entry>
+		<id>urn:atom:1</id>
+		<title>Audit bridge contracts</title>
+		<link rel="alternate" href="https://atom.example/audit"/>
+		<published>2024-05-01T12:00:00Z</published>
+		<summary>Pays ETH 3.5 on completion</summary>
+		<category term="audit"/>
+	</entry>
+</feed>`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rss.xml":
+			w.Header().Set("Content-Type", "application/rss+xml")
+			fmt.Fprint(w, rss)
+		case "/atom.xml":
+			w.Header().Set("Content-Type", "application/atom+xml")
+			fmt.Fprint(w, atom)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	scanner, err := NewFeedScanner(FeedScannerConfig{
+		Feeds:    []string{ts.URL + "/rss.xml", ts.URL + "/atom.xml"},
+		Platform: "hackboard",
+		Tags:     []string{"hackathon"},
+	})
+	if err != nil {
+		t.Fatalf("NewFeedScanner failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ch, err := scanner.Scan(ctx)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	bounties := make(map[string]core.Bounty)
+	for b := range ch {
+		bounties[b.URL] = b
+	}
+	if len(bounties) != 3 {
+		t.Fatalf("Expected 3 bounties, got %d", len(bounties))
+	}
+
+	indexer := bounties["https://hack.example/indexer"]
+	if indexer.Reward != "2,500" || indexer.Currency != "USDC" {
+		t.Errorf("Unexpected reward from title: %q %q", indexer.Reward, indexer.Currency)
+	}
+	if indexer.Description != "Index everything" {
+		t.Errorf("Expected HTML stripped from description, got %q", indexer.Description)
+	}
+	if indexer.CreatedAt.UTC().Format(time.RFC3339) != "2006-01-02T22:04:05Z" {
+		t.Errorf("Unexpected pubDate: %v", indexer.CreatedAt)
+	}
+	if indexer.ID != "hack-1" || indexer.Platform != "HACKBOARD" {
+		t.Errorf("Unexpected id/platform: %s %s", indexer.ID, indexer.Platform)
+	}
+	if !hasTag(indexer.Tags, "hackathon") || !hasTag(indexer.Tags, "solana") {
+		t.Errorf("Unexpected tags: %v", indexer.Tags)
+	}
+
+	docs := bounties["https://hack.example/docs"]
+	if docs.Reward != "150" || docs.Currency != "USD" {
+		t.Errorf("Unexpected reward from description: %q %q", docs.Reward, docs.Currency)
+	}
+
+	audit := bounties["https://atom.example/audit"]
+	if audit.Reward != "3.5" || audit.Currency != "ETH" {
+		t.Errorf("Unexpected Atom reward: %q %q", audit.Reward, audit.Currency)
+	}
+	if audit.CreatedAt.Format(time.RFC3339) != "2024-05-01T12:00:00Z" {
+		t.Errorf("Unexpected Atom published: %v", audit.CreatedAt)
+	}
+}
+
+func TestFeedScanner_CustomRewardPattern(t *testing.T) {
+	if _, err := NewFeedScanner(FeedScannerConfig{Feeds: []string{"https://x.example"}, RewardPattern: `(\d+)`}); err == nil {
+		t.Fatal("Expected error for pattern without amount group")
+	}
+
+	scanner, err := NewFeedScanner(FeedScannerConfig{
+		Feeds:         []string{"https://x.example"},
+		Currency:      "OP",
+		RewardPattern: `Prize pool: (?P<amount>\d+)`,
+	})
+	if err != nil {
+		t.Fatalf("NewFeedScanner failed: %v", err)
+	}
+	amount, currency := scanner.extractReward("Prize pool: 9000 tokens")
+	if amount != "9000" || currency != "" {
+		t.Errorf("Unexpected extraction: %q %q", amount, currency)
+	}
+}
+
+func TestFeedScanner_PartialUnlessCloseMissing(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<rss version="2.0"><channel><item><title>Task</title><link>https://hack.example/task</link></item></channel></rss>`)
+	}))
+	defer ts.Close()
+
+	for _, closeMissing := range []bool{false, true} {
+		scanner, err := NewFeedScanner(FeedScannerConfig{Feeds: []string{ts.URL}, CloseMissing: closeMissing})
+		if err != nil {
+			t.Fatalf("NewFeedScanner failed: %v", err)
+		}
+		var partial atomic.Bool
+		ch, _ := scanner.Scan(WithPartialFlag(context.Background(), &partial))
+		for b := range ch {
+			if !b.CreatedAt.IsZero() {
+				t.Errorf("undated entry CreatedAt = %v, want zero", b.CreatedAt)
+			}
+		}
+		// Entries that age out of a feed are closed only when opted in.
+		if partial.Load() == closeMissing {
+			t.Errorf("close_missing %v: partial = %v", closeMissing, partial.Load())
+		}
+	}
+}
@@ -3,7 +3,9 @@ package scanners
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -12,13 +14,15 @@ import (
 )
 
 type GitHubScanner struct {
-	client      *http.Client
-	token       string
-	endpoints   []string
-	baseURL     string
-	rateLimiter *security.GitHubRateLimiter
-	perPage     int
-	maxPages    int
+	client        *http.Client
+	token         string
+	endpoints     []string
+	baseURL       string
+	rateLimiter   *security.GitHubRateLimiter
+	perPage       int
+	maxPages      int
+	fullScanEvery time.Duration
+	state         StateStore
 }
 
 type GitHubScannerConfig struct {
@@ -26,6 +30,36 @@ type GitHubScannerConfig struct {
 	BaseURL  string
 	PerPage  int
 	MaxPages int
+	// FullScanEvery is how often a pass fetches every open issue per label.
+	// Passes in between fetch only issues created since the label's cursor,
+	// and are partial. Zero makes every pass full.
+	FullScanEvery time.Duration
+}
+
+func init() {
+	Register(Registration{
+		Name:        "GITHUB",
+		Aliases:     []string{"GITHUB_AGGREGATOR"},
+		Description: "GitHub issue search across bounty labels",
+		Schema: ConfigSchema{
+			{Key: "token", Type: FieldString, Secret: true, Description: "GitHub personal access token"},
+			{Key: "base_url", Type: FieldString, Default: "https://api.github.com", Description: "GitHub API base URL"},
+			{Key: "labels", Type: FieldStringList, Description: "Issue labels to search"},
+			{Key: "per_page", Type: FieldInt, Default: 100, Description: "Results per page (1-100)"},
+			{Key: "max_pages", Type: FieldInt, Default: 10, Description: "Pages fetched per label"},
+			{Key: "full_scan_hours", Type: FieldInt, Default: 6, Description: "Hours between full passes; passes in between fetch only new issues (0: always full)"},
+		},
+		Factory: func(opts Options) (core.Scanner, error) {
+			return NewGitHubScanner(opts.String("token"), GitHubScannerConfig{
+				Labels:   opts.StringList("labels"),
+				BaseURL:  opts.String("base_url"),
+				PerPage:  opts.Int("per_page"),
+				MaxPages: opts.Int("max_pages"),
+
+				FullScanEvery: time.Duration(opts.Int("full_scan_hours")) * time.Hour,
+			}), nil
+		},
+	})
 }
 
 func NewGitHubScanner(token string, cfg GitHubScannerConfig) *GitHubScanner {
@@ -55,13 +89,15 @@ func NewGitHubScanner(token string, cfg GitHubScannerConfig) *GitHubScanner {
 	}
 
 	return &GitHubScanner{
-		client:      security.SecureHTTPClient(),
-		token:       token,
-		endpoints:   labels,
-		baseURL:     baseURL,
-		rateLimiter: security.NewGitHubRateLimiter(token),
-		perPage:     perPage,
-		maxPages:    maxPages,
+		client:        security.SecureHTTPClient(),
+		token:         token,
+		endpoints:     labels,
+		baseURL:       baseURL,
+		rateLimiter:   security.NewGitHubRateLimiter(token),
+		perPage:       perPage,
+		maxPages:      maxPages,
+		fullScanEvery: cfg.FullScanEvery,
+		state:         newMemoryState(),
 	}
 }
 
@@ -69,142 +105,284 @@ func (s *GitHubScanner) Name() string {
 	return "GitHub Aggregator"
 }
 
+// Platforms returns the platform of every bounty the scanner emits, one per
+// label.
+func (s *GitHubScanner) Platforms() []string {
+	platforms := make([]string, 0, len(s.endpoints))
+	for _, label := range s.endpoints {
+		platforms = append(platforms, "GITHUB/"+strings.ToUpper(label))
+	}
+	return platforms
+}
+
+// SetStateStore keeps label cursors and ETags in store so restarts resume
+// incremental scanning.
+func (s *GitHubScanner) SetStateStore(store StateStore) {
+	s.state = store
+}
+
+// Scan fetches the open issues of every label. Between full passes it
+// fetches only issues created since each label's cursor, the newest
+// creation time seen, oldest first so a pass cut short by the page limit
+// resumes where it stopped. It revalidates the first page with its ETag so
+// an unchanged result costs no rate limit.
 func (s *GitHubScanner) Scan(ctx context.Context) (<-chan core.Bounty, error) {
 	ch := make(chan core.Bounty)
+	full := s.fullScanDue()
+	if !full {
+		markPartial(ctx)
+	}
 
 	go func() {
 		defer close(ch)
 
+		complete := true
 		for _, label := range s.endpoints {
-			for page := 1; page <= s.maxPages; page++ {
-				if ctx.Err() != nil {
-					return
-				}
-
-				query := fmt.Sprintf("is:issue is:open label:%s sort:created-desc", label)
-				url := fmt.Sprintf("%s/search/issues?q=%s&per_page=%d&page=%d", s.baseURL, strings.ReplaceAll(query, " ", "+"), s.perPage, page)
-
-				req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-				if err != nil {
-					security.GetLogger().Error("Error creating request for %s: %v", label, err)
-					break
-				}
-
-				security.SecureRequest(req, s.token)
-
-				// Check rate limits before making request
-				s.rateLimiter.CheckAndWait()
-
-				// Execute request with retries
-				resp, err := doRequestWithRetry(ctx, s.client, req)
-				if err != nil {
-					security.GetLogger().Error("Error fetching %s (page %d): %v", label, page, err)
-					break
-				}
-
-				// Update rate limiter with response headers
-				s.rateLimiter.UpdateFromHeaders(resp)
-
-				// Validate and parse the response
-				validatedResponse, err := security.ValidateGitHubResponseFromReader(resp.Body)
-				resp.Body.Close()
-				if err != nil {
-					security.GetLogger().Error("Error validating response for %s (page %d): %v", label, page, err)
-					break
-				}
-
-				if len(validatedResponse.Items) == 0 {
-					break
-				}
-
-				for _, item := range validatedResponse.Items {
-					createdAt, err := time.Parse(time.RFC3339, item.CreatedAt)
-					if err != nil {
-						continue
-					}
-
-					// Determine reward and currency from labels
-					reward := "Funded"
-					currency := "USD" // Default
-					paymentType := "fiat"
-					isFunded := false
-
-					for _, l := range item.Labels {
-						name := strings.ToLower(l.Name)
-						if strings.Contains(name, "funded") {
-							isFunded = true
-						}
-						if strings.Contains(name, "$") {
-							reward = l.Name
-							currency = "" // Already has $
-						}
-						if strings.Contains(name, "usdc") || strings.Contains(name, "eth") || strings.Contains(name, "sol") || strings.Contains(name, "usdt") {
-							reward = l.Name
-							currency = "" // Label likely has the currency name
-							paymentType = "crypto"
-						}
-					}
-
-					// Check body for payment keywords if not found in labels
-					if paymentType == "fiat" {
-						bodyLower := strings.ToLower(item.Body)
-						if strings.Contains(bodyLower, "usdc") || strings.Contains(bodyLower, "eth") || strings.Contains(bodyLower, "sol") || strings.Contains(bodyLower, "usdt") {
-							currency = "USDC/ETH/SOL"
-							paymentType = "crypto"
-						} else if strings.Contains(bodyLower, "paypal") {
-							currency = "PAYPAL"
-							paymentType = "fiat"
-						} else if strings.Contains(bodyLower, "cash app") || strings.Contains(bodyLower, "cashapp") {
-							currency = "CASHAPP"
-							paymentType = "p2p"
-						}
-					}
-
-					// Determine tags
-					tags := []string{"active"}
-					titleLower := strings.ToLower(item.Title)
-					if strings.Contains(titleLower, "urgent") {
-						tags = append(tags, "urgent")
-					}
-					if strings.Contains(titleLower, "fix") || strings.Contains(titleLower, "bug") {
-						tags = append(tags, "dev")
-					}
-					if strings.Contains(titleLower, "script") || strings.Contains(titleLower, "bot") {
-						tags = append(tags, "automation")
-					}
-					if isFunded {
-						tags = append(tags, "funded")
-					}
-
-					bounty := core.Bounty{
-						ID:          item.HTMLURL,
-						Title:       item.Title,
-						Platform:    "GITHUB/" + strings.ToUpper(label),
-						Reward:      reward,
-						Currency:    currency,
-						URL:         item.HTMLURL,
-						CreatedAt:   createdAt,
-						Description: item.Body,
-						Tags:        tags,
-						PaymentType: paymentType,
-					}
-
-					select {
-					case ch <- bounty:
-					case <-ctx.Done():
-						return
-					}
-				}
-
-				if len(validatedResponse.Items) < s.perPage {
-					break
-				}
-
-				// Rate limiting
-				time.Sleep(2 * time.Second)
+			var since time.Time
+			etag := ""
+			if !full {
+				since, etag = s.cursor(label)
+			}
+			newest, etag, ok := s.scanLabel(ctx, ch, label, since, etag)
+			if ctx.Err() != nil {
+				return
 			}
+			if !ok {
+				complete = false
+				continue
+			}
+			if full || !newest.Equal(since) {
+				etag = "" // belongs to a query the next pass will not repeat
+			}
+			s.saveCursor(label, newest, etag)
+		}
+		if full && complete {
+			s.setState("full_scan_at", time.Now().UTC().Format(time.RFC3339))
 		}
 	}()
 
 	return ch, nil
 }
+
+// scanLabel sends the open issues with label created at or after since, or
+// all of them when since is zero. etag, from the previous identical query,
+// lets an unchanged first page come back 304 Not Modified. It returns the
+// newest creation time seen, the first page's ETag and whether every page
+// was read. A full pass that hits the page limit is marked partial.
+func (s *GitHubScanner) scanLabel(ctx context.Context, ch chan<- core.Bounty, label string, since time.Time, etag string) (time.Time, string, bool) {
+	newest := since
+	query := fmt.Sprintf("is:issue is:open label:%s sort:created-desc", label)
+	if !since.IsZero() {
+		// Oldest first, so the newest issue seen is the cursor even when
+		// the page limit leaves later ones for the next pass.
+		query = fmt.Sprintf("is:issue is:open label:%s sort:created-asc created:>=%s", label, since.UTC().Format(time.RFC3339))
+	}
+
+	for page := 1; page <= s.maxPages; page++ {
+		if ctx.Err() != nil {
+			return newest, etag, false
+		}
+
+		reqURL := fmt.Sprintf("%s/search/issues?q=%s&per_page=%d&page=%d", s.baseURL, url.QueryEscape(query), s.perPage, page)
+
+		req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+		if err != nil {
+			security.GetLogger().Error("Error creating request for %s: %v", label, err)
+			reportScanError(ctx, err)
+			return newest, etag, false
+		}
+
+		security.SecureRequest(req, s.token)
+		if page == 1 && etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+
+		// Check rate limits before making request
+		s.rateLimiter.CheckAndWait()
+
+		// Execute request with retries
+		resp, err := doRequestWithRetry(ctx, s.client, req)
+		if err != nil {
+			security.GetLogger().Error("Error fetching %s (page %d): %v", label, page, err)
+			reportScanError(ctx, err)
+			return newest, etag, false
+		}
+
+		// Update rate limiter with response headers
+		s.rateLimiter.UpdateFromHeaders(resp)
+
+		// Nothing new since the previous pass
+		if resp.StatusCode == http.StatusNotModified {
+			resp.Body.Close()
+			return newest, etag, true
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+			resp.Body.Close()
+			err := newStatusError(reqURL, resp.StatusCode, body)
+			security.GetLogger().Error("Error fetching %s (page %d): %v", label, page, err)
+			reportScanError(ctx, err)
+			return newest, etag, false
+		}
+		if page == 1 {
+			etag = resp.Header.Get("ETag")
+		}
+
+		// Validate and parse the response
+		validatedResponse, err := security.ValidateGitHubResponseFromReader(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			security.GetLogger().Error("Error validating response for %s (page %d): %v", label, page, err)
+			reportScanError(ctx, &core.ScanError{Kind: core.ScanErrorDecode, URL: reqURL, Err: err})
+			return newest, etag, false
+		}
+
+		if len(validatedResponse.Items) == 0 {
+			break
+		}
+
+		for _, item := range validatedResponse.Items {
+			createdAt, err := time.Parse(time.RFC3339, item.CreatedAt)
+			if err != nil {
+				continue
+			}
+			if createdAt.After(newest) {
+				newest = createdAt
+			}
+
+			select {
+			case ch <- githubBounty(item, label, createdAt):
+			case <-ctx.Done():
+				return newest, etag, false
+			}
+		}
+
+		if len(validatedResponse.Items) < s.perPage {
+			break
+		}
+		if page == s.maxPages {
+			// Older issues past the limit were not fetched, so listings
+			// missing from this pass may still be open.
+			markPartial(ctx)
+			break
+		}
+
+		// Rate limiting
+		time.Sleep(2 * time.Second)
+	}
+	return newest, etag, true
+}
+
+// githubBounty maps a search result found under label to a bounty.
+func githubBounty(item security.GitHubIssue, label string, createdAt time.Time) core.Bounty {
+	// Determine reward and currency from labels
+	reward := "Funded"
+	currency := "USD" // Default
+	paymentType := "fiat"
+	isFunded := false
+
+	for _, l := range item.Labels {
+		name := strings.ToLower(l.Name)
+		if strings.Contains(name, "funded") {
+			isFunded = true
+		}
+		if strings.Contains(name, "$") {
+			reward = l.Name
+			currency = "" // Already has $
+		}
+		if strings.Contains(name, "usdc") || strings.Contains(name, "eth") || strings.Contains(name, "sol") || strings.Contains(name, "usdt") {
+			reward = l.Name
+			currency = "" // Label likely has the currency name
+			paymentType = "crypto"
+		}
+	}
+
+	// Check body for payment keywords if not found in labels
+	if paymentType == "fiat" {
+		bodyLower := strings.ToLower(item.Body)
+		if strings.Contains(bodyLower, "usdc") || strings.Contains(bodyLower, "eth") || strings.Contains(bodyLower, "sol") || strings.Contains(bodyLower, "usdt") {
+			currency = "USDC/ETH/SOL"
+			paymentType = "crypto"
+		} else if strings.Contains(bodyLower, "paypal") {
+			currency = "PAYPAL"
+			paymentType = "fiat"
+		} else if strings.Contains(bodyLower, "cash app") || strings.Contains(bodyLower, "cashapp") {
+			currency = "CASHAPP"
+			paymentType = "p2p"
+		}
+	}
+
+	// Determine tags
+	tags := []string{"active"}
+	titleLower := strings.ToLower(item.Title)
+	if strings.Contains(titleLower, "urgent") {
+		tags = append(tags, "urgent")
+	}
+	if strings.Contains(titleLower, "fix") || strings.Contains(titleLower, "bug") {
+		tags = append(tags, "dev")
+	}
+	if strings.Contains(titleLower, "script") || strings.Contains(titleLower, "bot") {
+		tags = append(tags, "automation")
+	}
+	if isFunded {
+		tags = append(tags, "funded")
+	}
+
+	return core.Bounty{
+		ID:          item.HTMLURL,
+		Title:       item.Title,
+		Platform:    "GITHUB/" + strings.ToUpper(label),
+		Reward:      reward,
+		Currency:    currency,
+		URL:         item.HTMLURL,
+		CreatedAt:   createdAt,
+		Description: item.Body,
+		Tags:        tags,
+		PaymentType: paymentType,
+	}
+}
+
+// stateName namespaces this scanner's state by API, so scanners against
+// different GitHub hosts keep separate cursors.
+func (s *GitHubScanner) stateName() string {
+	return "GITHUB " + s.baseURL
+}
+
+func (s *GitHubScanner) getState(key string) string {
+	value, err := s.state.GetScanState(s.stateName(), key)
+	if err != nil {
+		security.GetLogger().Warn("Failed to read GitHub scan state %s: %v", key, err)
+		return ""
+	}
+	return value
+}
+
+func (s *GitHubScanner) setState(key, value string) {
+	if err := s.state.SetScanState(s.stateName(), key, value); err != nil {
+		security.GetLogger().Warn("Failed to save GitHub scan state %s: %v", key, err)
+	}
+}
+
+// fullScanDue reports whether this pass should fetch every label in full.
+func (s *GitHubScanner) fullScanDue() bool {
+	if s.fullScanEvery <= 0 {
+		return true
+	}
+	last, err := time.Parse(time.RFC3339, s.getState("full_scan_at"))
+	return err != nil || time.Since(last) >= s.fullScanEvery
+}
+
+// cursor returns the newest creation time seen for label and the ETag of
+// the query it was last used in.
+func (s *GitHubScanner) cursor(label string) (time.Time, string) {
+	since, _ := time.Parse(time.RFC3339, s.getState("cursor:"+label))
+	return since, s.getState("etag:" + label)
+}
+
+func (s *GitHubScanner) saveCursor(label string, newest time.Time, etag string) {
+	if !newest.IsZero() {
+		s.setState("cursor:"+label, newest.UTC().Format(time.RFC3339))
+	}
+	s.setState("etag:"+label, etag)
+}
@@ -0,0 +1,312 @@
+package scanners
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"bountyos-v8/internal/core"
+	"bountyos-v8/internal/security"
+)
+
+// HackerOneScanner walks a HackerOne-style program directory (JSON:API
+// pages linked through links.next) and fetches each program's structured
+// scopes for its asset tags, at most once per scope TTL.
+type HackerOneScanner struct {
+	client     *http.Client
+	baseURL    string
+	webURL     string
+	username   string
+	token      string
+	maxPages   int
+	bountyOnly bool
+	scopeTTL   time.Duration
+
+	mu     sync.Mutex
+	scopes map[string]hackerOneScopeTags // by program handle
+}
+
+type HackerOneScannerConfig struct {
+	BaseURL    string
+	WebURL     string
+	Username   string
+	Token      string
+	MaxPages   int
+	BountyOnly bool
+	// ScopeTTL is how long a program's scope tags are reused before its
+	// structured scopes are fetched again. Zero fetches them every pass.
+	ScopeTTL time.Duration
+}
+
+// hackerOneScopeTags are the asset tags of a program's bounty-eligible
+// scopes and when they were fetched.
+type hackerOneScopeTags struct {
+	tags      []string
+	fetchedAt time.Time
+}
+
+type hackerOneProgramsResponse struct {
+	Data  []HackerOneProgram `json:"data"`
+	Links struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+type HackerOneProgram struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Handle              string   `json:"handle"`
+		Name                string   `json:"name"`
+		Currency            string   `json:"currency"`
+		SubmissionState     string   `json:"submission_state"`
+		State               string   `json:"state"`
+		OffersBounties      bool     `json:"offers_bounties"`
+		StartedAcceptingAt  string   `json:"started_accepting_at"`
+		MaximumBountyAmount *float64 `json:"maximum_bounty_amount"`
+	} `json:"attributes"`
+}
+
+type hackerOneScopesResponse struct {
+	Data []struct {
+		Attributes struct {
+			AssetType         string `json:"asset_type"`
+			AssetIdentifier   string `json:"asset_identifier"`
+			EligibleForBounty bool   `json:"eligible_for_bounty"`
+			MaxSeverity       string `json:"max_severity"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+func init() {
+	Register(Registration{
+		Name:        "HACKERONE",
+		Aliases:     []string{"H1"},
+		Description: "HackerOne-style bug bounty program directory",
+		Schema: ConfigSchema{
+			{Key: "base_url", Type: FieldString, Default: "https://api.hackerone.com/v1/hackers", Description: "Hacker API base URL"},
+			{Key: "web_url", Type: FieldString, Default: "https://hackerone.com", Description: "Prefix for program page links"},
+			{Key: "username", Type: FieldString, Required: true, Description: "API username"},
+			{Key: "token", Type: FieldString, Required: true, Secret: true, Description: "API token"},
+			{Key: "max_pages", Type: FieldInt, Default: 5, Description: "Directory pages fetched per scan"},
+			{Key: "bounty_only", Type: FieldBool, Default: true, Description: "Skip programs that do not pay bounties"},
+			{Key: "scope_ttl_hours", Type: FieldInt, Default: 24, Description: "Hours a program's scopes are reused before being fetched again (0: every pass)"},
+		},
+		Factory: func(opts Options) (core.Scanner, error) {
+			return NewHackerOneScanner(HackerOneScannerConfig{
+				BaseURL:    opts.String("base_url"),
+				WebURL:     opts.String("web_url"),
+				Username:   opts.String("username"),
+				Token:      opts.String("token"),
+				MaxPages:   opts.Int("max_pages"),
+				BountyOnly: opts.Bool("bounty_only"),
+				ScopeTTL:   time.Duration(opts.Int("scope_ttl_hours")) * time.Hour,
+			}), nil
+		},
+	})
+}
+
+func NewHackerOneScanner(cfg HackerOneScannerConfig) *HackerOneScanner {
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = "https://api.hackerone.com/v1/hackers"
+	}
+	webURL := strings.TrimRight(cfg.WebURL, "/")
+	if webURL == "" {
+		webURL = "https://hackerone.com"
+	}
+	maxPages := cfg.MaxPages
+	if maxPages <= 0 {
+		maxPages = 5
+	}
+	security.GetLogger().RegisterToken(cfg.Token)
+
+	return &HackerOneScanner{
+		client:     security.SecureHTTPClient(),
+		baseURL:    baseURL,
+		webURL:     webURL,
+		username:   cfg.Username,
+		token:      cfg.Token,
+		maxPages:   maxPages,
+		bountyOnly: cfg.BountyOnly,
+		scopeTTL:   cfg.ScopeTTL,
+		scopes:     make(map[string]hackerOneScopeTags),
+	}
+}
+
+func (s *HackerOneScanner) Name() string {
+	return "HackerOne"
+}
+
+// Platforms returns the platform of every bounty the scanner emits.
+func (s *HackerOneScanner) Platforms() []string {
+	return []string{"HACKERONE"}
+}
+
+func (s *HackerOneScanner) Scan(ctx context.Context) (<-chan core.Bounty, error) {
+	ch := make(chan core.Bounty)
+
+	go func() {
+		defer close(ch)
+
+		next := s.baseURL + "/programs"
+		for page := 1; page <= s.maxPages && next != ""; page++ {
+			if ctx.Err() != nil {
+				return
+			}
+
+			var programs hackerOneProgramsResponse
+			if err := s.getJSON(ctx, next, &programs); err != nil {
+				security.GetLogger().Error("Error fetching HackerOne programs (page %d): %v", page, err)
+				reportScanError(ctx, err)
+				return
+			}
+
+			for _, program := range programs.Data {
+				bounty, ok := s.programBounty(ctx, program)
+				if !ok {
+					continue
+				}
+				select {
+				case ch <- bounty:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			next = programs.Links.Next
+		}
+		if next != "" {
+			// Programs past the page cap were not fetched, so ones missing
+			// from this pass may still be open.
+			markPartial(ctx)
+		}
+	}()
+
+	return ch, nil
+}
+
+func (s *HackerOneScanner) programBounty(ctx context.Context, program HackerOneProgram) (core.Bounty, bool) {
+	attrs := program.Attributes
+	if attrs.Handle == "" {
+		return core.Bounty{}, false
+	}
+	if state := strings.ToLower(attrs.SubmissionState); state != "" && state != "open" {
+		return core.Bounty{}, false
+	}
+	if s.bountyOnly && !attrs.OffersBounties {
+		return core.Bounty{}, false
+	}
+
+	// Programs without a launch date keep a zero creation time.
+	createdAt, _ := parseProgramTime(attrs.StartedAcceptingAt)
+
+	reward := "Variable"
+	if attrs.MaximumBountyAmount != nil && *attrs.MaximumBountyAmount > 0 {
+		reward = formatAmount(*attrs.MaximumBountyAmount)
+	}
+	currency := strings.ToUpper(strings.TrimSpace(attrs.Currency))
+	if currency == "" {
+		currency = "USD"
+	}
+
+	tags := []string{"bug-bounty", "security"}
+	if attrs.State != "" {
+		tags = appendUniqueTag(tags, strings.ToLower(attrs.State))
+	}
+
+	for _, tag := range s.scopeTags(ctx, attrs.Handle) {
+		tags = appendUniqueTag(tags, tag)
+	}
+
+	name := strings.TrimSpace(attrs.Name)
+	if name == "" {
+		name = attrs.Handle
+	}
+
+	id := program.ID
+	if id == "" {
+		id = attrs.Handle
+	}
+
+	return core.Bounty{
+		ID:          id,
+		Title:       name + " Bug Bounty",
+		Platform:    "HACKERONE",
+		Reward:      reward,
+		Currency:    currency,
+		URL:         s.webURL + "/" + attrs.Handle,
+		CreatedAt:   createdAt,
+		Description: fmt.Sprintf("HackerOne program %s", attrs.Handle),
+		Tags:        tags,
+		PaymentType: "fiat",
+	}, true
+}
+
+// scopeTags returns the asset tags of the bounty-eligible scopes of the
+// program with handle, fetching them when the cached ones are older than
+// the scope TTL. Lookup failures only cost the asset tags; the program is
+// still listed, and the scopes are fetched again on the next pass.
+func (s *HackerOneScanner) scopeTags(ctx context.Context, handle string) []string {
+	s.mu.Lock()
+	cached, ok := s.scopes[handle]
+	s.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < s.scopeTTL {
+		return cached.tags
+	}
+
+	var scopes hackerOneScopesResponse
+	scopeURL := fmt.Sprintf("%s/programs/%s/structured_scopes", s.baseURL, handle)
+	if err := s.getJSON(ctx, scopeURL, &scopes); err != nil {
+		security.GetLogger().Warn("Error fetching HackerOne scopes for %s: %v", handle, err)
+		reportScanError(ctx, err)
+		return cached.tags
+	}
+	var tags []string
+	for _, scope := range scopes.Data {
+		if !scope.Attributes.EligibleForBounty {
+			continue
+		}
+		tags = appendUniqueTag(tags, scopeTag(scope.Attributes.AssetType))
+	}
+
+	s.mu.Lock()
+	s.scopes[handle] = hackerOneScopeTags{tags: tags, fetchedAt: time.Now()}
+	s.mu.Unlock()
+	return tags
+}
+
+func (s *HackerOneScanner) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	security.SecureRequest(req, "")
+	req.Header.Set("Accept", "application/json")
+	if s.username != "" || s.token != "" {
+		req.SetBasicAuth(s.username, s.token)
+	}
+
+	resp, err := doRequestWithRetry(ctx, s.client, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newStatusError(url, resp.StatusCode, body)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return newDecodeError(url, err, body)
+	}
+	return nil
+}
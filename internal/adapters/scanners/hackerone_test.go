@@ -0,0 +1,172 @@
+package scanners
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+func TestHackerOneScanner_ScanDirectory(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, token, ok := r.BasicAuth()
+		if !ok || user != "hunter" || token != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/hackers/programs":
+			if r.URL.Query().Get("page[number]") == "2" {
+				fmt.Fprint(w, `{"data":[
+					{"id":"3","attributes":{"handle":"paused","name":"Paused","submission_state":"paused","offers_bounties":true}}
+				],"links":{}}`)
+				return
+			}
+			fmt.Fprintf(w, `{"data":[
+				{"id":"1","attributes":{"handle":"acme","name":"Acme Corp","currency":"usd","submission_state":"open","state":"public_mode","offers_bounties":true,"started_accepting_at":"2023-06-01T00:00:00.000Z","maximum_bounty_amount":25000}},
+				{"id":"2","attributes":{"handle":"vdp","name":"VDP Only","submission_state":"open","offers_bounties":false}}
+			],"links":{"next":"%s/v1/hackers/programs?page%%5Bnumber%%5D=2"}}`, ts.URL)
+		case "/v1/hackers/programs/acme/structured_scopes":
+			fmt.Fprint(w, `{"data":[
+				{"attributes":{"asset_type":"URL","asset_identifier":"*.acme.com","eligible_for_bounty":true}},
+				{"attributes":{"asset_type":"GOOGLE_PLAY_APP_ID","asset_identifier":"com.acme","eligible_for_bounty":true}},
+				{"attributes":{"asset_type":"OTHER","asset_identifier":"legacy","eligible_for_bounty":false}}
+			]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	scanner := NewHackerOneScanner(HackerOneScannerConfig{
+		BaseURL:    ts.URL + "/v1/hackers",
+		Username:   "hunter",
+		Token:      "secret",
+		BountyOnly: true,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ch, err := scanner.Scan(ctx)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	var bounties []core.Bounty
+	for b := range ch {
+		bounties = append(bounties, b)
+	}
+	if len(bounties) != 1 {
+		t.Fatalf("Expected 1 bounty, got %d", len(bounties))
+	}
+
+	acme := bounties[0]
+	if acme.Title != "Acme Corp Bug Bounty" || acme.Platform != "HACKERONE" {
+		t.Errorf("Unexpected title/platform: %s %s", acme.Title, acme.Platform)
+	}
+	if acme.Reward != "25000" || acme.Currency != "USD" {
+		t.Errorf("Unexpected reward: %s %s", acme.Reward, acme.Currency)
+	}
+	if acme.URL != "https://hackerone.com/acme" {
+		t.Errorf("Unexpected URL: %s", acme.URL)
+	}
+	if acme.CreatedAt.Format("2006-01-02") != "2023-06-01" {
+		t.Errorf("Unexpected launch date: %v", acme.CreatedAt)
+	}
+	if !hasTag(acme.Tags, "scope:url") || !hasTag(acme.Tags, "scope:google-play-app-id") || hasTag(acme.Tags, "scope:other") {
+		t.Errorf("Unexpected scope tags: %v", acme.Tags)
+	}
+}
+
+func TestHackerOneScanner_CachesScopes(t *testing.T) {
+	var scopeRequests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/programs":
+			fmt.Fprint(w, `{"data":[
+				{"id":"1","attributes":{"handle":"acme","name":"Acme Corp","submission_state":"open","offers_bounties":true}}
+			],"links":{}}`)
+		case "/programs/acme/structured_scopes":
+			scopeRequests.Add(1)
+			fmt.Fprint(w, `{"data":[{"attributes":{"asset_type":"URL","eligible_for_bounty":true}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	scanner := NewHackerOneScanner(HackerOneScannerConfig{BaseURL: ts.URL, ScopeTTL: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for pass := 1; pass <= 2; pass++ {
+		ch, err := scanner.Scan(ctx)
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		var bounties []core.Bounty
+		for b := range ch {
+			bounties = append(bounties, b)
+		}
+		if len(bounties) != 1 {
+			t.Fatalf("pass %d: expected 1 bounty, got %d", pass, len(bounties))
+		}
+		if !hasTag(bounties[0].Tags, "scope:url") {
+			t.Errorf("pass %d: scope tags = %v", pass, bounties[0].Tags)
+		}
+		if !bounties[0].CreatedAt.IsZero() {
+			t.Errorf("pass %d: CreatedAt = %v, want zero without a launch date", pass, bounties[0].CreatedAt)
+		}
+	}
+	if n := scopeRequests.Load(); n != 1 {
+		t.Errorf("structured_scopes fetched %d times, want 1", n)
+	}
+}
+
+func TestHackerOneScanner_CappedPassIsPartial(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/programs" && r.URL.Query().Get("page") == "":
+			fmt.Fprintf(w, `{"data":[
+				{"id":"1","attributes":{"handle":"acme","name":"Acme Corp","submission_state":"open","offers_bounties":true}}
+			],"links":{"next":"%s/programs?page=2"}}`, ts.URL)
+		case r.URL.Path == "/programs":
+			fmt.Fprint(w, `{"data":[],"links":{}}`)
+		default:
+			fmt.Fprint(w, `{"data":[]}`)
+		}
+	}))
+	defer ts.Close()
+
+	for _, tt := range []struct {
+		maxPages int
+		want     bool
+	}{{1, true}, {2, false}} {
+		scanner := NewHackerOneScanner(HackerOneScannerConfig{BaseURL: ts.URL, MaxPages: tt.maxPages})
+
+		var partial atomic.Bool
+		ch, err := scanner.Scan(WithPartialFlag(context.Background(), &partial))
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		for range ch {
+		}
+		// A partial pass keeps its listings from being closed as missing.
+		if partial.Load() != tt.want {
+			t.Errorf("max_pages %d: partial = %v, want %v", tt.maxPages, partial.Load(), tt.want)
+		}
+	}
+}
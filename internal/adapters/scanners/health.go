@@ -0,0 +1,160 @@
+package scanners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+type errorSinkKey struct{}
+
+// WithErrorSink returns a context through which a scanner's background
+// goroutine reports source failures that cannot be returned from Scan.
+func WithErrorSink(ctx context.Context, sink func(error)) context.Context {
+	return context.WithValue(ctx, errorSinkKey{}, sink)
+}
+
+// reportScanError forwards err to the sink installed by WithErrorSink, if any.
+func reportScanError(ctx context.Context, err error) {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	if sink, ok := ctx.Value(errorSinkKey{}).(func(error)); ok && sink != nil {
+		sink(err)
+	}
+}
+
+// PlatformScanner is implemented by scanners that know every platform their
+// bounties carry, so a clean pass that returned nothing still counts for
+// those platforms.
+type PlatformScanner interface {
+	Platforms() []string
+}
+
+type partialKey struct{}
+
+// WithPartialFlag returns a context through which a scanner marks its pass
+// partial: it returned only what changed, so listings it left out are not
+// gone from the source.
+func WithPartialFlag(ctx context.Context, partial *atomic.Bool) context.Context {
+	return context.WithValue(ctx, partialKey{}, partial)
+}
+
+// markPartial sets the flag installed by WithPartialFlag, if any.
+func markPartial(ctx context.Context) {
+	if partial, ok := ctx.Value(partialKey{}).(*atomic.Bool); ok && partial != nil {
+		partial.Store(true)
+	}
+}
+
+func newStatusError(url string, status int, body []byte) error {
+	return &core.ScanError{
+		Kind:       core.ScanErrorStatus,
+		URL:        url,
+		StatusCode: status,
+		Err:        fmt.Errorf("unexpected status %d from %s: %s", status, url, responseSnippet(body)),
+	}
+}
+
+func newDecodeError(url string, err error, body []byte) error {
+	return &core.ScanError{
+		Kind: core.ScanErrorDecode,
+		URL:  url,
+		Err:  fmt.Errorf("invalid payload from %s: %w (snippet: %s)", url, err, responseSnippet(body)),
+	}
+}
+
+// HealthTracker records the outcome of each scan pass per scanner.
+type HealthTracker struct {
+	mu     sync.RWMutex
+	health map[string]*core.ScannerHealth
+}
+
+func NewHealthTracker() *HealthTracker {
+	return &HealthTracker{health: make(map[string]*core.ScannerHealth)}
+}
+
+// Track registers a scanner so it is listed before its first scan completes.
+func (t *HealthTracker) Track(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.health[name]; !ok {
+		t.health[name] = &core.ScannerHealth{Name: name, Status: core.HealthUnknown}
+	}
+}
+
+// Record stores the result of one scan pass.
+func (t *HealthTracker) Record(name string, started time.Time, count int, errs []error) {
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, ok := t.health[name]
+	if !ok {
+		h = &core.ScannerHealth{Name: name}
+		t.health[name] = h
+	}
+	h.LastScanAt = &now
+	h.LastDurationMS = now.Sub(started).Milliseconds()
+	h.LastCount = count
+
+	if len(errs) == 0 {
+		h.Status = core.HealthOK
+		h.LastSuccessAt = &now
+		h.LastError = ""
+		h.LastErrorKind = ""
+		h.ConsecutiveFailures = 0
+		return
+	}
+
+	last := errs[len(errs)-1]
+	h.LastError = last.Error()
+	h.LastErrorKind = ""
+	var scanErr *core.ScanError
+	if errors.As(last, &scanErr) {
+		h.LastErrorKind = scanErr.Kind
+	}
+	h.ConsecutiveFailures++
+	if count > 0 {
+		h.Status = core.HealthDegraded
+		h.LastSuccessAt = &now
+	} else {
+		h.Status = core.HealthFailing
+	}
+}
+
+// Schedule records when a scanner polls next and its current interval.
+func (t *HealthTracker) Schedule(name string, interval time.Duration, next time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, ok := t.health[name]
+	if !ok {
+		h = &core.ScannerHealth{Name: name, Status: core.HealthUnknown}
+		t.health[name] = h
+	}
+	h.IntervalSeconds = int(interval / time.Second)
+	h.NextScanAt = &next
+}
+
+// Snapshot returns a copy of every tracked scanner's health sorted by name.
+func (t *HealthTracker) Snapshot() []core.ScannerHealth {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	out := make([]core.ScannerHealth, 0, len(t.health))
+	for _, h := range t.health {
+		out = append(out, *h)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
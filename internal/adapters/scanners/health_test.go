@@ -0,0 +1,62 @@
+package scanners
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+func TestHealthTracker_Record(t *testing.T) {
+	tracker := NewHealthTracker()
+	tracker.Track("Superteam Earn")
+	tracker.Track("GitHub Aggregator")
+
+	snapshot := tracker.Snapshot()
+	if len(snapshot) != 2 || snapshot[0].Name != "GitHub Aggregator" || snapshot[0].Status != core.HealthUnknown {
+		t.Fatalf("Unexpected initial snapshot: %+v", snapshot)
+	}
+
+	statusErr := &core.ScanError{Kind: core.ScanErrorStatus, StatusCode: 503, Err: errors.New("unexpected status 503")}
+	tracker.Record("Superteam Earn", time.Now(), 0, []error{statusErr})
+	tracker.Record("Superteam Earn", time.Now(), 0, []error{statusErr})
+	tracker.Record("GitHub Aggregator", time.Now(), 4, []error{errors.New("page 3 failed")})
+
+	byName := make(map[string]core.ScannerHealth)
+	for _, h := range tracker.Snapshot() {
+		byName[h.Name] = h
+	}
+
+	superteam := byName["Superteam Earn"]
+	if superteam.Status != core.HealthFailing || superteam.LastErrorKind != core.ScanErrorStatus {
+		t.Errorf("Expected failing status error, got %+v", superteam)
+	}
+	if superteam.ConsecutiveFailures != 2 || superteam.LastSuccessAt != nil {
+		t.Errorf("Unexpected failure bookkeeping: %+v", superteam)
+	}
+
+	github := byName["GitHub Aggregator"]
+	if github.Status != core.HealthDegraded || github.LastCount != 4 || github.LastErrorKind != "" {
+		t.Errorf("Expected degraded status, got %+v", github)
+	}
+
+	tracker.Record("Superteam Earn", time.Now(), 3, nil)
+	for _, h := range tracker.Snapshot() {
+		if h.Name == "Superteam Earn" && (h.Status != core.HealthOK || h.ConsecutiveFailures != 0 || h.LastError != "") {
+			t.Errorf("Expected recovery, got %+v", h)
+		}
+	}
+}
+
+func TestHealthTracker_Schedule(t *testing.T) {
+	tracker := NewHealthTracker()
+	tracker.Track("Superteam Earn")
+	next := time.Now().Add(90 * time.Second)
+	tracker.Schedule("Superteam Earn", 90*time.Second, next)
+
+	h := tracker.Snapshot()[0]
+	if h.IntervalSeconds != 90 || h.NextScanAt == nil || !h.NextScanAt.Equal(next) || h.Status != core.HealthUnknown {
+		t.Errorf("Unexpected health after Schedule: %+v", h)
+	}
+}
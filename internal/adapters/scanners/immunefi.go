@@ -0,0 +1,204 @@
+package scanners
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"bountyos-v8/internal/core"
+	"bountyos-v8/internal/security"
+)
+
+// ImmunefiScanner lists bug bounty programs from an Immunefi-style program
+// JSON feed. Each program becomes one bounty carrying its maximum payout.
+type ImmunefiScanner struct {
+	client     *http.Client
+	baseURL    string
+	programURL string
+}
+
+type ImmunefiScannerConfig struct {
+	BaseURL    string
+	ProgramURL string
+}
+
+type ImmunefiProgram struct {
+	ID            string   `json:"id"`
+	Project       string   `json:"project"`
+	Slug          string   `json:"slug"`
+	Description   string   `json:"description"`
+	MaximumReward *float64 `json:"maximumReward"`
+	RewardToken   string   `json:"rewardToken"`
+	LaunchDate    string   `json:"launchDate"`
+	EndDate       string   `json:"endDate"`
+	Ecosystem     []string `json:"ecosystem"`
+	ProgramType   []string `json:"programType"`
+	KYC           bool     `json:"kyc"`
+	Assets        []struct {
+		Type   string `json:"type"`
+		Target string `json:"target"`
+	} `json:"assets"`
+}
+
+func init() {
+	Register(Registration{
+		Name:        "IMMUNEFI",
+		Description: "Immunefi-style bug bounty program listings",
+		Schema: ConfigSchema{
+			{Key: "base_url", Type: FieldString, Default: "https://immunefi.com/public-api/bounties.json", Description: "Program list endpoint"},
+			{Key: "program_url", Type: FieldString, Default: "https://immunefi.com/bug-bounty", Description: "Prefix for program page links"},
+		},
+		Factory: func(opts Options) (core.Scanner, error) {
+			return NewImmunefiScanner(ImmunefiScannerConfig{
+				BaseURL:    opts.String("base_url"),
+				ProgramURL: opts.String("program_url"),
+			}), nil
+		},
+	})
+}
+
+func NewImmunefiScanner(cfg ImmunefiScannerConfig) *ImmunefiScanner {
+	baseURL := strings.TrimSpace(cfg.BaseURL)
+	if baseURL == "" {
+		baseURL = "https://immunefi.com/public-api/bounties.json"
+	}
+	programURL := strings.TrimRight(cfg.ProgramURL, "/")
+	if programURL == "" {
+		programURL = "https://immunefi.com/bug-bounty"
+	}
+
+	return &ImmunefiScanner{
+		client:     security.SecureHTTPClient(),
+		baseURL:    baseURL,
+		programURL: programURL,
+	}
+}
+
+func (s *ImmunefiScanner) Name() string {
+	return "Immunefi"
+}
+
+// Platforms returns the platform of every bounty the scanner emits.
+func (s *ImmunefiScanner) Platforms() []string {
+	return []string{"IMMUNEFI"}
+}
+
+func (s *ImmunefiScanner) Scan(ctx context.Context) (<-chan core.Bounty, error) {
+	ch := make(chan core.Bounty)
+
+	go func() {
+		defer close(ch)
+
+		if err := s.scanPrograms(ctx, ch); err != nil {
+			security.GetLogger().Error("Error fetching Immunefi programs: %v", err)
+			reportScanError(ctx, err)
+		}
+	}()
+
+	return ch, nil
+}
+
+func (s *ImmunefiScanner) scanPrograms(ctx context.Context, ch chan<- core.Bounty) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", s.baseURL, nil)
+	if err != nil {
+		return err
+	}
+
+	security.SecureRequest(req, "")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := doRequestWithRetry(ctx, s.client, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 8<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newStatusError(s.baseURL, resp.StatusCode, body)
+	}
+
+	var programs []ImmunefiProgram
+	if err := json.Unmarshal(body, &programs); err != nil {
+		return newDecodeError(s.baseURL, err, body)
+	}
+
+	for _, program := range programs {
+		if program.Slug == "" || program.Project == "" {
+			continue
+		}
+
+		// Programs without a launch date keep a zero creation time.
+		createdAt, _ := parseProgramTime(program.LaunchDate)
+		var expiresAt *time.Time
+		if t, ok := parseProgramTime(program.EndDate); ok {
+			expiresAt = &t
+		}
+
+		reward := "Variable"
+		if program.MaximumReward != nil && *program.MaximumReward > 0 {
+			reward = formatAmount(*program.MaximumReward)
+		}
+		currency := strings.ToUpper(strings.TrimSpace(program.RewardToken))
+		if currency == "" {
+			currency = "USD"
+		}
+		paymentType := "crypto"
+		if currency == "USD" {
+			paymentType = "fiat"
+		}
+
+		tags := []string{"bug-bounty", "security"}
+		for _, asset := range program.Assets {
+			tags = appendUniqueTag(tags, scopeTag(asset.Type))
+		}
+		for _, ecosystem := range program.Ecosystem {
+			tags = appendUniqueTag(tags, strings.ToLower(strings.TrimSpace(ecosystem)))
+		}
+		for _, kind := range program.ProgramType {
+			tags = appendUniqueTag(tags, strings.ToLower(strings.TrimSpace(kind)))
+		}
+		if program.KYC {
+			tags = appendUniqueTag(tags, "kyc")
+		}
+
+		description := strings.TrimSpace(program.Description)
+		if description == "" {
+			description = fmt.Sprintf("Bug bounty program for %s", program.Project)
+		}
+
+		id := program.ID
+		if id == "" {
+			id = program.Slug
+		}
+
+		bounty := core.Bounty{
+			ID:          id,
+			Title:       program.Project + " Bug Bounty",
+			Platform:    "IMMUNEFI",
+			Reward:      reward,
+			Currency:    currency,
+			URL:         s.programURL + "/" + program.Slug + "/",
+			CreatedAt:   createdAt,
+			ExpiresAt:   expiresAt,
+			Description: description,
+			Tags:        tags,
+			PaymentType: paymentType,
+		}
+
+		select {
+		case ch <- bounty:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package scanners
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+func TestImmunefiScanner_ScanPrograms(t *testing.T) {
+	programsResponse := `[{
+		"id":"prog-1",
+		"project":"Bridge Protocol",
+		"slug":"bridgeprotocol",
+		"maximumReward":1000000,
+		"launchDate":"2024-03-01T00:00:00.000Z",
+		"ecosystem":["Ethereum","Arbitrum"],
+		"programType":["DeFi"],
+		"kyc":true,
+		"assets":[
+			{"type":"smart_contract","target":"https://etherscan.io/address/0x1"},
+			{"type":"websites_and_applications","target":"https://app.bridge.example"},
+			{"type":"smart_contract","target":"https://etherscan.io/address/0x2"}
+		]
+	},{
+		"id":"prog-2",
+		"project":"Quiet Lender",
+		"slug":"quietlender",
+		"rewardToken":"usdc",
+		"launchDate":"2024-04-15"
+	},{
+		"id":"broken",
+		"project":"",
+		"slug":""
+	}]`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/public-api/bounties.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, programsResponse)
+	}))
+	defer ts.Close()
+
+	scanner := NewImmunefiScanner(ImmunefiScannerConfig{BaseURL: ts.URL + "/public-api/bounties.json"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ch, err := scanner.Scan(ctx)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	var bounties []core.Bounty
+	for b := range ch {
+		bounties = append(bounties, b)
+	}
+	if len(bounties) != 2 {
+		t.Fatalf("Expected 2 bounties, got %d", len(bounties))
+	}
+
+	bridge := bounties[0]
+	if bridge.Platform != "IMMUNEFI" {
+		t.Errorf("Unexpected platform: %s", bridge.Platform)
+	}
+	if bridge.Reward != "1000000" || bridge.Currency != "USD" {
+		t.Errorf("Unexpected reward: %s %s", bridge.Reward, bridge.Currency)
+	}
+	if bridge.URL != "https://immunefi.com/bug-bounty/bridgeprotocol/" {
+		t.Errorf("Unexpected URL: %s", bridge.URL)
+	}
+	if bridge.CreatedAt.Format("2006-01-02") != "2024-03-01" {
+		t.Errorf("Unexpected launch date: %v", bridge.CreatedAt)
+	}
+	for _, tag := range []string{"bug-bounty", "scope:smart-contract", "scope:websites-and-applications", "ethereum", "defi", "kyc"} {
+		if !hasTag(bridge.Tags, tag) {
+			t.Errorf("Expected tag %s in %v", tag, bridge.Tags)
+		}
+	}
+	if len(bridge.Tags) != 8 {
+		t.Errorf("Expected deduplicated scope tags, got %v", bridge.Tags)
+	}
+
+	lender := bounties[1]
+	if lender.Reward != "Variable" || lender.Currency != "USDC" || lender.PaymentType != "crypto" {
+		t.Errorf("Unexpected lender reward: %s %s %s", lender.Reward, lender.Currency, lender.PaymentType)
+	}
+	if lender.CreatedAt.Format("2006-01-02") != "2024-04-15" {
+		t.Errorf("Unexpected launch date: %v", lender.CreatedAt)
+	}
+}
+
+func TestImmunefiScanner_UrgencyBonus(t *testing.T) {
+	base := core.Bounty{Title: "Program", Currency: "USD", CreatedAt: time.Now().Add(-48 * time.Hour)}
+	immunefi := base
+	immunefi.Platform = "IMMUNEFI"
+	if core.CalculateUrgency(&immunefi)-core.CalculateUrgency(&base) != 30 {
+		t.Errorf("Expected IMMUNEFI platform bonus to apply")
+	}
+}
@@ -0,0 +1,496 @@
+package scanners
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"bountyos-v8/internal/core"
+	"bountyos-v8/internal/security"
+)
+
+// Pagination styles supported by JSONAPIScanner.
+const (
+	PaginationNone   = "none"
+	PaginationPage   = "page"
+	PaginationOffset = "offset"
+	PaginationCursor = "cursor"
+)
+
+// JSONAPIScanner polls a JSON listing endpoint and maps each item onto a
+// core.Bounty using declarative field selectors, so new boards can be added
+// from config alone.
+type JSONAPIScanner struct {
+	client *http.Client
+	cfg    JSONAPIScannerConfig
+}
+
+// JSONAPIScannerConfig describes where the listing lives and how to read it.
+// Selectors are dot paths ("data.items", "reward.amount", "tags.#.name");
+// a leading "$." is accepted for JSONPath-style configs.
+type JSONAPIScannerConfig struct {
+	Name        string
+	URL         string
+	Platform    string
+	ItemsPath   string
+	Fields      map[string]string
+	URLPrefix   string
+	Currency    string
+	PaymentType string
+	Tags        []string
+	TimeFormat  string
+	Headers     map[string]string
+
+	Pagination    string
+	PageParam     string
+	PageSizeParam string
+	PageSize      int
+	StartPage     int
+	MaxPages      int
+	CursorPath    string
+}
+
+// jsonAPIFields lists the selector keys understood in the "fields" block.
+var jsonAPIFields = []string{"id", "title", "description", "reward", "currency", "url", "created_at", "expires_at", "tags"}
+
+func init() {
+	Register(Registration{
+		Name:        "JSON_API",
+		Aliases:     []string{"JSON", "REST"},
+		Description: "Generic JSON listing mapped through configurable field selectors",
+		Schema: ConfigSchema{
+			{Key: "name", Type: FieldString, Description: "Display name of the scanner"},
+			{Key: "url", Type: FieldString, Required: true, Description: "Listing endpoint"},
+			{Key: "platform", Type: FieldString, Description: "Platform label (defaults to the URL host)"},
+			{Key: "items_path", Type: FieldString, Description: "Selector for the item array; empty when the body is the array"},
+			{Key: "fields", Type: FieldMap, Required: true, Description: "Bounty field to selector mapping"},
+			{Key: "url_prefix", Type: FieldString, Description: "Prefix for relative item URLs"},
+			{Key: "currency", Type: FieldString, Description: "Currency used when no currency selector matches"},
+			{Key: "payment_type", Type: FieldString, Description: "Payment type for emitted bounties"},
+			{Key: "tags", Type: FieldStringList, Description: "Static tags added to every bounty"},
+			{Key: "time_format", Type: FieldString, Default: time.RFC3339, Description: "Go layout for date fields"},
+			{Key: "headers", Type: FieldMap, Secret: true, Description: "Extra request headers, such as Authorization"},
+			{Key: "pagination", Type: FieldString, Default: PaginationNone, Description: "none, page, offset or cursor"},
+			{Key: "page_param", Type: FieldString, Description: "Query parameter carrying the page, offset or cursor"},
+			{Key: "page_size_param", Type: FieldString, Description: "Query parameter carrying the page size"},
+			{Key: "page_size", Type: FieldInt, Description: "Items requested per page"},
+			{Key: "start_page", Type: FieldInt, Default: 1, Description: "First page number for page pagination"},
+			{Key: "max_pages", Type: FieldInt, Default: 5, Description: "Maximum pages fetched per scan"},
+			{Key: "cursor_path", Type: FieldString, Description: "Selector for the next cursor in the response"},
+		},
+		Factory: func(opts Options) (core.Scanner, error) {
+			headers := make(map[string]string)
+			for key, value := range opts.Map("headers") {
+				headers[key] = fmt.Sprint(value)
+			}
+			scanner, err := NewJSONAPIScanner(JSONAPIScannerConfig{
+				Name:          opts.String("name"),
+				URL:           opts.String("url"),
+				Platform:      opts.String("platform"),
+				ItemsPath:     opts.String("items_path"),
+				Fields:        stringMap(opts.Map("fields")),
+				URLPrefix:     opts.String("url_prefix"),
+				Currency:      opts.String("currency"),
+				PaymentType:   opts.String("payment_type"),
+				Tags:          opts.StringList("tags"),
+				TimeFormat:    opts.String("time_format"),
+				Headers:       headers,
+				Pagination:    opts.String("pagination"),
+				PageParam:     opts.String("page_param"),
+				PageSizeParam: opts.String("page_size_param"),
+				PageSize:      opts.Int("page_size"),
+				StartPage:     opts.Int("start_page"),
+				MaxPages:      opts.Int("max_pages"),
+				CursorPath:    opts.String("cursor_path"),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return scanner, nil
+		},
+	})
+}
+
+func NewJSONAPIScanner(cfg JSONAPIScannerConfig) (*JSONAPIScanner, error) {
+	parsed, err := url.Parse(strings.TrimSpace(cfg.URL))
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid url %q", cfg.URL)
+	}
+	cfg.URL = parsed.String()
+
+	fields := make(map[string]string, len(cfg.Fields))
+	for key, selector := range cfg.Fields {
+		fields[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(selector)
+	}
+	for key := range fields {
+		if !containsString(jsonAPIFields, key) {
+			return nil, fmt.Errorf("unknown field %q (expected one of %s)", key, strings.Join(jsonAPIFields, ", "))
+		}
+	}
+	if fields["title"] == "" || fields["url"] == "" {
+		return nil, fmt.Errorf("fields.title and fields.url are required")
+	}
+	cfg.Fields = fields
+
+	cfg.Pagination = strings.ToLower(strings.TrimSpace(cfg.Pagination))
+	switch cfg.Pagination {
+	case "", PaginationNone:
+		cfg.Pagination = PaginationNone
+	case PaginationPage, PaginationOffset:
+		if cfg.PageParam == "" {
+			cfg.PageParam = cfg.Pagination
+		}
+	case PaginationCursor:
+		if cfg.CursorPath == "" {
+			return nil, fmt.Errorf("cursor pagination requires cursor_path")
+		}
+		if cfg.PageParam == "" {
+			cfg.PageParam = "cursor"
+		}
+	default:
+		return nil, fmt.Errorf("unknown pagination %q", cfg.Pagination)
+	}
+	if cfg.Pagination == PaginationOffset && cfg.PageSize <= 0 {
+		return nil, fmt.Errorf("offset pagination requires page_size")
+	}
+	if cfg.MaxPages <= 0 {
+		cfg.MaxPages = 5
+	}
+	if cfg.StartPage < 0 {
+		cfg.StartPage = 1
+	}
+
+	if cfg.Platform == "" {
+		cfg.Platform = strings.ToUpper(strings.TrimPrefix(parsed.Hostname(), "www."))
+	}
+	if cfg.Name == "" {
+		cfg.Name = cfg.Platform
+	}
+	if cfg.PaymentType == "" {
+		cfg.PaymentType = "unknown"
+	}
+	if cfg.TimeFormat == "" {
+		cfg.TimeFormat = time.RFC3339
+	}
+
+	return &JSONAPIScanner{
+		client: security.SecureHTTPClient(),
+		cfg:    cfg,
+	}, nil
+}
+
+func (s *JSONAPIScanner) Name() string {
+	return s.cfg.Name
+}
+
+// Platforms returns the platform of every bounty the scanner emits.
+func (s *JSONAPIScanner) Platforms() []string {
+	return []string{s.cfg.Platform}
+}
+
+func (s *JSONAPIScanner) Scan(ctx context.Context) (<-chan core.Bounty, error) {
+	ch := make(chan core.Bounty)
+
+	go func() {
+		defer close(ch)
+
+		page := s.cfg.StartPage
+		offset := 0
+		cursor := ""
+		for i := 0; i < s.cfg.MaxPages; i++ {
+			if ctx.Err() != nil {
+				return
+			}
+
+			pageURL := s.pageURL(page, offset, cursor)
+			doc, err := s.fetch(ctx, pageURL)
+			if err != nil {
+				security.GetLogger().Error("Error fetching %s: %v", s.cfg.Name, err)
+				reportScanError(ctx, err)
+				return
+			}
+
+			items, ok := selectPath(doc, s.cfg.ItemsPath).([]interface{})
+			if !ok {
+				security.GetLogger().Error("Error parsing %s: no item array at %q", s.cfg.Name, s.cfg.ItemsPath)
+				reportScanError(ctx, &core.ScanError{
+					Kind: core.ScanErrorDecode,
+					URL:  pageURL,
+					Err:  fmt.Errorf("no item array at %q", s.cfg.ItemsPath),
+				})
+				return
+			}
+
+			for _, item := range items {
+				bounty, ok := s.mapItem(item)
+				if !ok {
+					continue
+				}
+				select {
+				case ch <- bounty:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			switch s.cfg.Pagination {
+			case PaginationNone:
+				return
+			case PaginationCursor:
+				cursor = valueString(selectPath(doc, s.cfg.CursorPath))
+				if cursor == "" {
+					return
+				}
+			default:
+				if len(items) == 0 || (s.cfg.PageSize > 0 && len(items) < s.cfg.PageSize) {
+					return
+				}
+				page++
+				offset += len(items)
+			}
+		}
+		// The page cap cut the listing short, so listings missing from
+		// this pass may still be open.
+		markPartial(ctx)
+	}()
+
+	return ch, nil
+}
+
+func (s *JSONAPIScanner) pageURL(page, offset int, cursor string) string {
+	if s.cfg.Pagination == PaginationNone {
+		return s.cfg.URL
+	}
+
+	parsed, _ := url.Parse(s.cfg.URL)
+	query := parsed.Query()
+	switch s.cfg.Pagination {
+	case PaginationPage:
+		query.Set(s.cfg.PageParam, strconv.Itoa(page))
+	case PaginationOffset:
+		query.Set(s.cfg.PageParam, strconv.Itoa(offset))
+	case PaginationCursor:
+		if cursor != "" {
+			query.Set(s.cfg.PageParam, cursor)
+		}
+	}
+	if s.cfg.PageSizeParam != "" && s.cfg.PageSize > 0 {
+		query.Set(s.cfg.PageSizeParam, strconv.Itoa(s.cfg.PageSize))
+	}
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+func (s *JSONAPIScanner) fetch(ctx context.Context, pageURL string) (interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	security.SecureRequest(req, "")
+	req.Header.Set("Accept", "application/json")
+	for key, value := range s.cfg.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := doRequestWithRetry(ctx, s.client, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newStatusError(pageURL, resp.StatusCode, body)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, newDecodeError(pageURL, err, body)
+	}
+	return doc, nil
+}
+
+func (s *JSONAPIScanner) mapItem(item interface{}) (core.Bounty, bool) {
+	field := func(key string) interface{} {
+		selector := s.cfg.Fields[key]
+		if selector == "" {
+			return nil
+		}
+		return selectPath(item, selector)
+	}
+
+	title := valueString(field("title"))
+	link := valueString(field("url"))
+	if title == "" || link == "" {
+		return core.Bounty{}, false
+	}
+	if s.cfg.URLPrefix != "" && !strings.Contains(link, "://") {
+		link = strings.TrimRight(s.cfg.URLPrefix, "/") + "/" + strings.TrimLeft(link, "/")
+	}
+
+	id := valueString(field("id"))
+	if id == "" {
+		id = link
+	}
+	currency := valueString(field("currency"))
+	if currency == "" {
+		currency = s.cfg.Currency
+	}
+	description := valueString(field("description"))
+	if description == "" {
+		description = title
+	}
+
+	// Items without a usable date keep a zero creation time.
+	createdAt, _ := s.valueTime(field("created_at"))
+	var expiresAt *time.Time
+	if t, ok := s.valueTime(field("expires_at")); ok {
+		expiresAt = &t
+	}
+
+	tags := append([]string(nil), s.cfg.Tags...)
+	switch v := field("tags").(type) {
+	case []interface{}:
+		for _, tag := range v {
+			if str := valueString(tag); str != "" {
+				tags = append(tags, str)
+			}
+		}
+	default:
+		if str := valueString(v); str != "" {
+			tags = append(tags, trimList(strings.Split(str, ","))...)
+		}
+	}
+
+	return core.Bounty{
+		ID:          id,
+		Title:       title,
+		Platform:    s.cfg.Platform,
+		Reward:      valueString(field("reward")),
+		Currency:    currency,
+		URL:         link,
+		CreatedAt:   createdAt,
+		ExpiresAt:   expiresAt,
+		Description: description,
+		Tags:        tags,
+		PaymentType: s.cfg.PaymentType,
+	}, true
+}
+
+func (s *JSONAPIScanner) valueTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return time.Time{}, false
+		}
+		// Treat 13-digit values as epoch milliseconds.
+		if n > 1e12 {
+			return time.UnixMilli(n), true
+		}
+		return time.Unix(n, 0), true
+	case string:
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return time.Time{}, false
+		}
+		if t, err := time.Parse(s.cfg.TimeFormat, v); err == nil {
+			return t, true
+		}
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// selectPath walks a decoded JSON document along a dot-separated selector.
+// Numeric segments index arrays and "#" maps the rest of the path over every
+// element, mirroring gjson's "items.#.name" form.
+func selectPath(doc interface{}, path string) interface{} {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+	path = strings.TrimPrefix(path, ".")
+	if path == "" {
+		return doc
+	}
+
+	segment, rest, _ := strings.Cut(path, ".")
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		return selectPath(v[segment], rest)
+	case []interface{}:
+		if segment == "#" {
+			if rest == "" {
+				return json.Number(strconv.Itoa(len(v)))
+			}
+			out := make([]interface{}, 0, len(v))
+			for _, item := range v {
+				if value := selectPath(item, rest); value != nil {
+					out = append(out, value)
+				}
+			}
+			return out
+		}
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index >= len(v) {
+			return nil
+		}
+		return selectPath(v[index], rest)
+	}
+	return nil
+}
+
+func valueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case json.Number:
+		if f, err := v.Float64(); err == nil && strings.ContainsAny(v.String(), ".eE") {
+			return formatAmount(f)
+		}
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if str := valueString(item); str != "" {
+				parts = append(parts, str)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+	return ""
+}
+
+func stringMap(raw map[string]interface{}) map[string]string {
+	out := make(map[string]string, len(raw))
+	for key, value := range raw {
+		out[key] = fmt.Sprint(value)
+	}
+	return out
+}
+
+func containsString(list []string, target string) bool {
+	for _, item := range list {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package scanners
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+func TestJSONAPIScanner_MapsFieldsAcrossPages(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	pages := map[string]string{
+		"1": fmt.Sprintf(`{"data":{"results":[
+			{"slug":"audit-vault","name":"Audit vault","prize":{"amount":1250.5,"token":"USDC"},
+			 "posted":"%s","deadline":%d,"labels":[{"name":"solidity"},{"name":"audit"}]},
+			{"slug":"","name":"Missing link"}
+		]}}`, now.Format(time.RFC3339), now.Add(48*time.Hour).UnixMilli()),
+		"2": `{"data":{"results":[
+			{"slug":"bot","name":"Write a bot","prize":{"amount":300},"labels":[]}
+		]}}`,
+		"3": `{"data":{"results":[]}}`,
+	}
+
+	var requested []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/bounties" {
+			http.NotFound(w, r)
+			return
+		}
+		requested = append(requested, r.URL.RawQuery)
+		if r.URL.Query().Get("limit") != "2" {
+			t.Errorf("Expected limit=2, got %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, pages[r.URL.Query().Get("p")])
+	}))
+	defer ts.Close()
+
+	scanner, err := Build("ACME_BOARD", map[string]interface{}{
+		"type":            "json_api",
+		"url":             ts.URL + "/v1/bounties?status=open",
+		"platform":        "ACME",
+		"items_path":      "$.data.results",
+		"url_prefix":      "https://acme.example/bounty",
+		"currency":        "USD",
+		"payment_type":    "crypto",
+		"tags":            []interface{}{"acme"},
+		"pagination":      "page",
+		"page_param":      "p",
+		"page_size_param": "limit",
+		"page_size":       2,
+		"fields": map[string]interface{}{
+			"title":      "name",
+			"url":        "slug",
+			"reward":     "prize.amount",
+			"currency":   "prize.token",
+			"created_at": "posted",
+			"expires_at": "deadline",
+			"tags":       "labels.#.name",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ch, err := scanner.Scan(ctx)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	var bounties []core.Bounty
+	for b := range ch {
+		bounties = append(bounties, b)
+	}
+
+	if len(requested) != 2 {
+		t.Errorf("Expected 2 page requests (short page stops paging), got %v", requested)
+	}
+	if len(bounties) != 2 {
+		t.Fatalf("Expected 2 bounties, got %d", len(bounties))
+	}
+
+	audit := bounties[0]
+	if audit.URL != "https://acme.example/bounty/audit-vault" {
+		t.Errorf("Unexpected URL: %s", audit.URL)
+	}
+	if audit.Reward != "1250.5" || audit.Currency != "USDC" {
+		t.Errorf("Unexpected reward: %s %s", audit.Reward, audit.Currency)
+	}
+	if !audit.CreatedAt.Equal(now) {
+		t.Errorf("Unexpected created_at: %v", audit.CreatedAt)
+	}
+	if audit.ExpiresAt == nil || audit.ExpiresAt.Sub(now) != 48*time.Hour {
+		t.Errorf("Unexpected expires_at: %v", audit.ExpiresAt)
+	}
+	if !hasTag(audit.Tags, "acme") || !hasTag(audit.Tags, "solidity") || !hasTag(audit.Tags, "audit") {
+		t.Errorf("Unexpected tags: %v", audit.Tags)
+	}
+	if audit.Platform != "ACME" || audit.PaymentType != "crypto" {
+		t.Errorf("Unexpected platform/payment: %s %s", audit.Platform, audit.PaymentType)
+	}
+
+	if bounties[1].Currency != "USD" {
+		t.Errorf("Expected static currency fallback, got %q", bounties[1].Currency)
+	}
+	if !bounties[1].CreatedAt.IsZero() {
+		t.Errorf("Expected zero created_at for an undated item, got %v", bounties[1].CreatedAt)
+	}
+}
+
+func TestJSONAPIScanner_CursorPagination(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			fmt.Fprint(w, `{"items":[{"t":"One","u":"https://x.example/1"}],"next":"abc"}`)
+		case "abc":
+			fmt.Fprint(w, `{"items":[{"t":"Two","u":"https://x.example/2"}],"next":null}`)
+		default:
+			t.Errorf("Unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	}))
+	defer ts.Close()
+
+	scanner, err := NewJSONAPIScanner(JSONAPIScannerConfig{
+		URL:        ts.URL,
+		ItemsPath:  "items",
+		Fields:     map[string]string{"title": "t", "url": "u"},
+		Pagination: PaginationCursor,
+		CursorPath: "next",
+	})
+	if err != nil {
+		t.Fatalf("NewJSONAPIScanner failed: %v", err)
+	}
+
+	ch, _ := scanner.Scan(context.Background())
+	count := 0
+	for range ch {
+		count++
+	}
+	if count != 2 {
+		t.Fatalf("Expected 2 bounties, got %d", count)
+	}
+}
+
+func TestJSONAPIScanner_CappedPassIsPartial(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(w, `{"items":[{"t":"One","u":"https://x.example/1"}],"next":"abc"}`)
+			return
+		}
+		fmt.Fprint(w, `{"items":[{"t":"Two","u":"https://x.example/2"}],"next":null}`)
+	}))
+	defer ts.Close()
+
+	for _, tt := range []struct {
+		maxPages int
+		want     bool
+	}{{1, true}, {2, false}} {
+		scanner, err := NewJSONAPIScanner(JSONAPIScannerConfig{
+			URL:        ts.URL,
+			ItemsPath:  "items",
+			Fields:     map[string]string{"title": "t", "url": "u"},
+			Pagination: PaginationCursor,
+			PageParam:  "cursor",
+			CursorPath: "next",
+			MaxPages:   tt.maxPages,
+		})
+		if err != nil {
+			t.Fatalf("NewJSONAPIScanner failed: %v", err)
+		}
+
+		var partial atomic.Bool
+		ch, _ := scanner.Scan(WithPartialFlag(context.Background(), &partial))
+		for range ch {
+		}
+		// A partial pass keeps its listings from being closed as missing.
+		if partial.Load() != tt.want {
+			t.Errorf("max_pages %d: partial = %v, want %v", tt.maxPages, partial.Load(), tt.want)
+		}
+	}
+}
+
+func TestJSONAPIScanner_RejectsBadConfig(t *testing.T) {
+	cases := []JSONAPIScannerConfig{
+		{URL: "not a url", Fields: map[string]string{"title": "t", "url": "u"}},
+		{URL: "https://x.example", Fields: map[string]string{"title": "t"}},
+		{URL: "https://x.example", Fields: map[string]string{"title": "t", "url": "u", "bogus": "b"}},
+		{URL: "https://x.example", Fields: map[string]string{"title": "t", "url": "u"}, Pagination: "cursor"},
+	}
+	for i, cfg := range cases {
+		if _, err := NewJSONAPIScanner(cfg); err == nil {
+			t.Errorf("case %d: expected error", i)
+		}
+	}
+}
@@ -0,0 +1,372 @@
+package scanners
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"bountyos-v8/internal/core"
+	"bountyos-v8/internal/security"
+)
+
+// ErrUnknownScanner is returned when a scanner name has no registration.
+var ErrUnknownScanner = errors.New("unknown scanner")
+
+// FieldType describes the value type accepted by a scanner config field.
+type FieldType string
+
+const (
+	FieldString     FieldType = "string"
+	FieldInt        FieldType = "int"
+	FieldBool       FieldType = "bool"
+	FieldStringList FieldType = "[]string"
+	FieldMap        FieldType = "map"
+)
+
+// TypeKey is the reserved option that selects which registration builds a
+// scanner instance. It lets one registration back several configured sources.
+const TypeKey = "type"
+
+// ConfigField declares a single option accepted by a scanner factory.
+type ConfigField struct {
+	Key         string
+	Type        FieldType
+	Default     interface{}
+	Required    bool
+	Secret      bool // masked in logs once configured
+	Description string
+}
+
+// ConfigSchema is the ordered list of options a scanner understands.
+type ConfigSchema []ConfigField
+
+// Options holds the validated configuration passed to a scanner factory.
+type Options map[string]interface{}
+
+// Factory builds a scanner instance from validated options.
+type Factory func(opts Options) (core.Scanner, error)
+
+// Registration describes a scanner plugin.
+type Registration struct {
+	Name        string
+	Aliases     []string
+	Description string
+	Schema      ConfigSchema
+	Factory     Factory
+}
+
+var registry = struct {
+	sync.RWMutex
+	byName  map[string]Registration
+	aliases map[string]string
+}{
+	byName:  make(map[string]Registration),
+	aliases: make(map[string]string),
+}
+
+// Register adds a scanner plugin to the registry. It panics on an empty name,
+// a nil factory or a duplicate name, mirroring database/sql.Register.
+func Register(reg Registration) {
+	name := normalizeScannerName(reg.Name)
+	if name == "" {
+		panic("scanners: Register called with empty name")
+	}
+	if reg.Factory == nil {
+		panic("scanners: Register factory is nil for " + name)
+	}
+	reg.Name = name
+	reg.Aliases = append([]string(nil), reg.Aliases...)
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, dup := registry.byName[name]; dup {
+		panic("scanners: Register called twice for " + name)
+	}
+	if _, dup := registry.aliases[name]; dup {
+		panic("scanners: Register name collides with alias " + name)
+	}
+	for i, alias := range reg.Aliases {
+		alias = normalizeScannerName(alias)
+		if alias == "" {
+			continue
+		}
+		if _, dup := registry.byName[alias]; dup {
+			panic("scanners: alias collides with registered name " + alias)
+		}
+		if _, dup := registry.aliases[alias]; dup {
+			panic("scanners: alias registered twice " + alias)
+		}
+		reg.Aliases[i] = alias
+		registry.aliases[alias] = name
+	}
+	registry.byName[name] = reg
+}
+
+// Lookup returns the registration for a name or alias.
+func Lookup(name string) (Registration, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	name = normalizeScannerName(name)
+	if canonical, ok := registry.aliases[name]; ok {
+		name = canonical
+	}
+	reg, ok := registry.byName[name]
+	return reg, ok
+}
+
+// CanonicalName resolves aliases to the registered name. Unknown names are
+// returned normalized so they can still be used as instance names.
+func CanonicalName(name string) string {
+	name = normalizeScannerName(name)
+	registry.RLock()
+	defer registry.RUnlock()
+	if canonical, ok := registry.aliases[name]; ok {
+		return canonical
+	}
+	return name
+}
+
+// Registered returns every registration sorted by name.
+func Registered() []Registration {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	out := make([]Registration, 0, len(registry.byName))
+	for _, reg := range registry.byName {
+		out = append(out, reg)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// Build creates a scanner instance. The registration is chosen by the "type"
+// option when present and by the instance name otherwise. ScheduleSchema
+// options are left to ResolveSchedule.
+func Build(name string, raw map[string]interface{}) (core.Scanner, error) {
+	kind := name
+	if value, ok := lookupKey(raw, TypeKey); ok {
+		if s, ok := value.(string); ok && strings.TrimSpace(s) != "" {
+			kind = s
+		}
+	}
+
+	reg, ok := Lookup(kind)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownScanner, normalizeScannerName(kind))
+	}
+
+	// Mask secrets before anything can log them, even when validation fails
+	for _, field := range reg.Schema {
+		if value, ok := lookupKey(raw, field.Key); ok && field.Secret {
+			registerSecret(value)
+		}
+	}
+
+	opts, err := reg.Schema.Resolve(withoutScheduleKeys(raw))
+	if err != nil {
+		return nil, fmt.Errorf("scanner %s: %w", normalizeScannerName(name), err)
+	}
+
+	scanner, err := reg.Factory(opts)
+	if err != nil {
+		return nil, fmt.Errorf("scanner %s: %w", normalizeScannerName(name), err)
+	}
+	return scanner, nil
+}
+
+// Resolve validates raw options against the schema, applying defaults and
+// coercing YAML or environment values into the declared types.
+func (s ConfigSchema) Resolve(raw map[string]interface{}) (Options, error) {
+	known := make(map[string]ConfigField, len(s))
+	for _, field := range s {
+		known[field.Key] = field
+	}
+
+	opts := make(Options, len(s))
+	var problems []string
+
+	for key, value := range raw {
+		normalized := strings.ToLower(strings.TrimSpace(key))
+		if normalized == TypeKey {
+			continue
+		}
+		field, ok := known[normalized]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown option %q", key))
+			continue
+		}
+		coerced, err := coerceOption(field.Type, value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("option %q: %v", field.Key, err))
+			continue
+		}
+		opts[field.Key] = coerced
+	}
+
+	for _, field := range s {
+		if _, ok := opts[field.Key]; ok {
+			continue
+		}
+		if field.Required {
+			problems = append(problems, fmt.Sprintf("missing required option %q", field.Key))
+			continue
+		}
+		if field.Default != nil {
+			opts[field.Key] = field.Default
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, errors.New(strings.Join(problems, "; "))
+	}
+	return opts, nil
+}
+
+// String returns a string option or "" when unset.
+func (o Options) String(key string) string {
+	value, _ := o[key].(string)
+	return value
+}
+
+// Int returns an int option or 0 when unset.
+func (o Options) Int(key string) int {
+	value, _ := o[key].(int)
+	return value
+}
+
+// Bool returns a bool option or false when unset.
+func (o Options) Bool(key string) bool {
+	value, _ := o[key].(bool)
+	return value
+}
+
+// StringList returns a copy of a list option or nil when unset.
+func (o Options) StringList(key string) []string {
+	value, _ := o[key].([]string)
+	return append([]string(nil), value...)
+}
+
+// Map returns a nested option block or nil when unset.
+func (o Options) Map(key string) map[string]interface{} {
+	value, _ := o[key].(map[string]interface{})
+	return value
+}
+
+func coerceOption(kind FieldType, value interface{}) (interface{}, error) {
+	switch kind {
+	case FieldString:
+		switch v := value.(type) {
+		case string:
+			return strings.TrimSpace(v), nil
+		case int, int64, float64, bool:
+			return fmt.Sprint(v), nil
+		}
+	case FieldInt:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case int64:
+			return int(v), nil
+		case float64:
+			if v == float64(int(v)) {
+				return int(v), nil
+			}
+		case string:
+			parsed, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("expected integer, got %q", v)
+			}
+			return parsed, nil
+		}
+	case FieldBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("expected boolean, got %q", v)
+			}
+			return parsed, nil
+		}
+	case FieldStringList:
+		switch v := value.(type) {
+		case []string:
+			return trimList(v), nil
+		case []interface{}:
+			out := make([]string, 0, len(v))
+			for _, item := range v {
+				out = append(out, fmt.Sprint(item))
+			}
+			return trimList(out), nil
+		case string:
+			return trimList(strings.Split(v, ",")), nil
+		}
+	case FieldMap:
+		switch v := value.(type) {
+		case map[string]interface{}:
+			return v, nil
+		case map[interface{}]interface{}:
+			out := make(map[string]interface{}, len(v))
+			for key, item := range v {
+				out[fmt.Sprint(key)] = item
+			}
+			return out, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported field type %q", kind)
+	}
+	return nil, fmt.Errorf("expected %s, got %T", kind, value)
+}
+
+func trimList(list []string) []string {
+	out := make([]string, 0, len(list))
+	for _, item := range list {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			out = append(out, trimmed)
+		}
+	}
+	return out
+}
+
+// registerSecret masks a secret option in logs. Each value of a map, such
+// as request headers, is a secret of its own; so is the credential after an
+// auth scheme such as "Bearer".
+func registerSecret(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, item := range v {
+			registerSecret(item)
+		}
+	case string:
+		v = strings.TrimSpace(v)
+		security.GetLogger().RegisterToken(v)
+		if fields := strings.Fields(v); len(fields) > 1 {
+			security.GetLogger().RegisterToken(fields[len(fields)-1])
+		}
+	case nil:
+	default:
+		registerSecret(fmt.Sprint(v))
+	}
+}
+
+func lookupKey(raw map[string]interface{}, key string) (interface{}, bool) {
+	for k, v := range raw {
+		if strings.EqualFold(strings.TrimSpace(k), key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func normalizeScannerName(name string) string {
+	return strings.ToUpper(strings.TrimSpace(name))
+}
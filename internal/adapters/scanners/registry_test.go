@@ -0,0 +1,113 @@
+package scanners
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"bountyos-v8/internal/core"
+	"bountyos-v8/internal/security"
+)
+
+type stubScanner struct {
+	name string
+}
+
+func (s *stubScanner) Name() string { return s.name }
+
+func (s *stubScanner) Scan(ctx context.Context) (<-chan core.Bounty, error) {
+	ch := make(chan core.Bounty)
+	close(ch)
+	return ch, nil
+}
+
+func TestRegistry_BuiltinsRegistered(t *testing.T) {
+	for _, name := range []string{"GITHUB", "github_aggregator", "SUPERTEAM", "BOUNTYCASTER"} {
+		if _, ok := Lookup(name); !ok {
+			t.Errorf("Expected %s to be registered", name)
+		}
+	}
+	if got := CanonicalName("github_aggregator"); got != "GITHUB" {
+		t.Errorf("CanonicalName() = %s, want GITHUB", got)
+	}
+}
+
+func TestRegistry_BuildByType(t *testing.T) {
+	Register(Registration{
+		Name: "TEST_STUB",
+		Schema: ConfigSchema{
+			{Key: "label", Type: FieldString, Required: true},
+			{Key: "pages", Type: FieldInt, Default: 3},
+			{Key: "tags", Type: FieldStringList},
+		},
+		Factory: func(opts Options) (core.Scanner, error) {
+			if opts.Int("pages") != 3 {
+				t.Errorf("Expected default pages 3, got %d", opts.Int("pages"))
+			}
+			if tags := opts.StringList("tags"); len(tags) != 2 || tags[1] != "b" {
+				t.Errorf("Unexpected tags: %v", tags)
+			}
+			return &stubScanner{name: opts.String("label")}, nil
+		},
+	})
+
+	scanner, err := Build("in_house", map[string]interface{}{
+		"type":  "test_stub",
+		"label": " In-House Board ",
+		"tags":  "a, b",
+
+		"interval_seconds": 300, // read by ResolveSchedule, not the factory
+	})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if scanner.Name() != "In-House Board" {
+		t.Errorf("Unexpected scanner name: %s", scanner.Name())
+	}
+
+	_, err = Build("TEST_STUB", map[string]interface{}{"pages": "many"})
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+	if !strings.Contains(err.Error(), `missing required option "label"`) || !strings.Contains(err.Error(), `option "pages"`) {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if _, err := Build("nope", nil); !errors.Is(err, ErrUnknownScanner) {
+		t.Errorf("Expected ErrUnknownScanner, got %v", err)
+	}
+}
+
+func TestRegistry_BuildMasksSecrets(t *testing.T) {
+	var logs bytes.Buffer
+	security.GetLogger().SetOutput(&logs)
+	defer security.GetLogger().SetOutput(os.Stdout)
+
+	// Masked even though the scanner fails validation
+	const token = "h1-token-from-scanners-block"
+	if _, err := Build("HACKERONE", map[string]interface{}{"token": token}); err == nil {
+		t.Fatal("Expected a missing username error")
+	}
+	security.GetLogger().Info("request failed with token %s", token)
+	if strings.Contains(logs.String(), token) {
+		t.Errorf("token logged unmasked: %s", logs.String())
+	}
+
+	// Every header value of a JSON API scanner, and the credential after
+	// an auth scheme
+	const apiKey, bearer = "json-api-key-from-headers", "json-api-bearer-from-headers"
+	if _, err := Build("JSON_API", map[string]interface{}{
+		"url":     "https://board.example/api",
+		"fields":  map[string]interface{}{"title": "t", "url": "u"},
+		"headers": map[string]interface{}{"X-Api-Key": apiKey, "Authorization": "Bearer " + bearer},
+	}); err != nil {
+		t.Fatalf("Build(JSON_API) error = %v", err)
+	}
+	security.GetLogger().Info("headers %s and %s", apiKey, bearer)
+	if strings.Contains(logs.String(), apiKey) || strings.Contains(logs.String(), bearer) {
+		t.Errorf("header logged unmasked: %s", logs.String())
+	}
+}
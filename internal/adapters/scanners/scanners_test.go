@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -94,6 +97,9 @@ func TestGitHubScanner_Scan(t *testing.T) {
 	if target.PaymentType != "crypto" {
 		t.Errorf("Wrong payment type: %s", target.PaymentType)
 	}
+	if platforms := scanner.Platforms(); len(platforms) != 1 || platforms[0] != target.Platform {
+		t.Errorf("Platforms() = %v, want [%s]", platforms, target.Platform)
+	}
 }
 
 func TestGitHubScanner_Paginates(t *testing.T) {
@@ -182,3 +188,209 @@ func TestGitHubScanner_Paginates(t *testing.T) {
 		t.Fatalf("Expected to find a page 2 bounty")
 	}
 }
+
+func TestGitHubScanner_Incremental(t *testing.T) {
+	t.Setenv("BOUNTYOS_DISABLE_RATE_LIMIT_SLEEP", "1")
+
+	type item struct {
+		Title     string `json:"title"`
+		HTMLURL   string `json:"html_url"`
+		CreatedAt string `json:"created_at"`
+	}
+	base := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	issue := func(n int) item {
+		return item{
+			Title:     fmt.Sprintf("Issue %d", n),
+			HTMLURL:   fmt.Sprintf("https://github.com/test/repo/issues/%d", n),
+			CreatedAt: base.Add(time.Duration(n) * time.Minute).Format(time.RFC3339),
+		}
+	}
+
+	var (
+		mu          sync.Mutex
+		issues      = []item{issue(1), issue(2)}
+		lastQuery   string
+		lastIfMatch string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		query := r.URL.Query().Get("q")
+		lastQuery, lastIfMatch = query, r.Header.Get("If-None-Match")
+
+		var since time.Time
+		if i := strings.Index(query, "created:>="); i >= 0 {
+			since, _ = time.Parse(time.RFC3339, query[i+len("created:>="):])
+		}
+		var items []item
+		for _, it := range issues {
+			created, _ := time.Parse(time.RFC3339, it.CreatedAt)
+			if !created.Before(since) {
+				items = append(items, it)
+			}
+		}
+
+		etag := fmt.Sprintf(`"%s/%d"`, query, len(items))
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+	}))
+	defer ts.Close()
+
+	state := newMemoryState()
+	newScanner := func() *GitHubScanner {
+		scanner := NewGitHubScanner("dummy-token", GitHubScannerConfig{FullScanEvery: time.Hour})
+		scanner.baseURL = ts.URL
+		scanner.endpoints = []string{"bounty"}
+		scanner.SetStateStore(state)
+		return scanner
+	}
+	scan := func(scanner *GitHubScanner) (titles []string, partial bool) {
+		t.Helper()
+		var flag atomic.Bool
+		ctx := WithPartialFlag(context.Background(), &flag)
+		ctx = WithErrorSink(ctx, func(err error) { t.Errorf("scan error: %v", err) })
+		ch, err := scanner.Scan(ctx)
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		for b := range ch {
+			titles = append(titles, b.Title)
+		}
+		return titles, flag.Load()
+	}
+	request := func() (string, string) {
+		mu.Lock()
+		defer mu.Unlock()
+		return lastQuery, lastIfMatch
+	}
+
+	scanner := newScanner()
+
+	// First pass: no cursor yet, so everything is fetched
+	titles, partial := scan(scanner)
+	if query, _ := request(); strings.Contains(query, "created:") || partial || len(titles) != 2 {
+		t.Fatalf("first pass: query %q, partial %v, titles %v; want full pass of 2", query, partial, titles)
+	}
+
+	// Second pass: only issues since the newest one seen
+	titles, partial = scan(scanner)
+	cursor := "created:>=" + base.Add(2*time.Minute).Format(time.RFC3339)
+	if query, _ := request(); !strings.Contains(query, cursor) || !partial {
+		t.Fatalf("second pass: query %q, partial %v; want %q and partial", query, partial, cursor)
+	}
+	if len(titles) != 1 || titles[0] != "Issue 2" {
+		t.Fatalf("second pass: titles %v; want [Issue 2]", titles)
+	}
+
+	// Third pass: the same query revalidates with its ETag and gets a 304
+	titles, _ = scan(scanner)
+	if _, ifMatch := request(); ifMatch == "" || len(titles) != 0 {
+		t.Fatalf("third pass: If-None-Match %q, titles %v; want conditional request and no bounties", ifMatch, titles)
+	}
+
+	// A restarted scanner resumes from the stored cursor
+	mu.Lock()
+	issues = append(issues, issue(3))
+	mu.Unlock()
+	titles, partial = scan(newScanner())
+	if query, _ := request(); !strings.Contains(query, cursor) || !partial {
+		t.Fatalf("after restart: query %q, partial %v; want %q and partial", query, partial, cursor)
+	}
+	if len(titles) != 2 || titles[1] != "Issue 3" {
+		t.Fatalf("after restart: titles %v; want [Issue 2 Issue 3]", titles)
+	}
+	if got, _ := state.GetScanState("GITHUB "+ts.URL, "cursor:bounty"); got != base.Add(3*time.Minute).Format(time.RFC3339) {
+		t.Fatalf("cursor after restart = %q; want Issue 3's creation time", got)
+	}
+}
+
+func TestGitHubScanner_PageLimit(t *testing.T) {
+	t.Setenv("BOUNTYOS_DISABLE_RATE_LIMIT_SLEEP", "1")
+
+	type item struct {
+		Title     string `json:"title"`
+		HTMLURL   string `json:"html_url"`
+		CreatedAt string `json:"created_at"`
+	}
+	base := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	issue := func(n int) item {
+		return item{
+			Title:     fmt.Sprintf("Issue %d", n),
+			HTMLURL:   fmt.Sprintf("https://github.com/test/repo/issues/%d", n),
+			CreatedAt: base.Add(time.Duration(n) * time.Minute).Format(time.RFC3339),
+		}
+	}
+
+	var mu sync.Mutex
+	issues := []item{issue(1), issue(2), issue(3)}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		query := r.URL.Query().Get("q")
+		var since time.Time
+		if i := strings.Index(query, "created:>="); i >= 0 {
+			since, _ = time.Parse(time.RFC3339, query[i+len("created:>="):])
+		}
+		var items []item
+		for _, it := range issues {
+			created, _ := time.Parse(time.RFC3339, it.CreatedAt)
+			if !created.Before(since) {
+				items = append(items, it)
+			}
+		}
+		if strings.Contains(query, "sort:created-desc") {
+			slices.Reverse(items)
+		}
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		items = items[min((page-1)*perPage, len(items)):min(page*perPage, len(items))]
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+	}))
+	defer ts.Close()
+
+	scanner := NewGitHubScanner("dummy-token", GitHubScannerConfig{PerPage: 2, MaxPages: 1, FullScanEvery: time.Hour})
+	scanner.baseURL = ts.URL
+	scanner.endpoints = []string{"bounty"}
+	scan := func() (titles []string, partial bool) {
+		t.Helper()
+		var flag atomic.Bool
+		ctx := WithPartialFlag(context.Background(), &flag)
+		ch, err := scanner.Scan(ctx)
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		for b := range ch {
+			titles = append(titles, b.Title)
+		}
+		return titles, flag.Load()
+	}
+
+	// A full pass cut short by the page limit is partial
+	if titles, partial := scan(); !partial || !slices.Equal(titles, []string{"Issue 3", "Issue 2"}) {
+		t.Fatalf("full pass: titles %v, partial %v; want [Issue 3 Issue 2] and partial", titles, partial)
+	}
+
+	// More new issues than one pass fetches are picked up over later passes
+	mu.Lock()
+	issues = append(issues, issue(4), issue(5), issue(6))
+	mu.Unlock()
+	seen := map[string]bool{}
+	for pass := 0; pass < 4; pass++ {
+		titles, _ := scan()
+		for _, title := range titles {
+			seen[title] = true
+		}
+	}
+	for _, want := range []string{"Issue 4", "Issue 5", "Issue 6"} {
+		if !seen[want] {
+			t.Errorf("incremental passes never returned %s; saw %v", want, seen)
+		}
+	}
+}
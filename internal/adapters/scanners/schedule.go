@@ -0,0 +1,140 @@
+package scanners
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// ScheduleSchema declares the options every scanner accepts, next to its
+// own, to poll on its own schedule. Unset options fall back to the global
+// POLL_* settings.
+var ScheduleSchema = ConfigSchema{
+	{Key: "interval_seconds", Type: FieldInt, Description: "Polling interval"},
+	{Key: "min_interval_seconds", Type: FieldInt, Description: "Fastest interval adaptive polling speeds up to"},
+	{Key: "max_interval_seconds", Type: FieldInt, Description: "Slowest interval adaptive polling and backoff slow down to"},
+	{Key: "jitter_percent", Type: FieldInt, Description: "Random spread added to each wait, as a percentage"},
+}
+
+// Schedule is when a scanner polls its source.
+type Schedule struct {
+	Interval      time.Duration
+	MinInterval   time.Duration
+	MaxInterval   time.Duration
+	JitterPercent int
+}
+
+// ResolveSchedule reads the ScheduleSchema options in raw over defaults and
+// returns the normalized schedule.
+func ResolveSchedule(raw map[string]interface{}, defaults Schedule) (Schedule, error) {
+	picked := make(map[string]interface{})
+	for key, value := range raw {
+		if key = strings.ToLower(strings.TrimSpace(key)); isScheduleKey(key) {
+			picked[key] = value
+		}
+	}
+	opts, err := ScheduleSchema.Resolve(picked)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	s := defaults
+	for key, dst := range map[string]*time.Duration{
+		"interval_seconds":     &s.Interval,
+		"min_interval_seconds": &s.MinInterval,
+		"max_interval_seconds": &s.MaxInterval,
+	} {
+		if n := opts.Int(key); n > 0 {
+			*dst = time.Duration(n) * time.Second
+		}
+	}
+	if _, ok := opts["jitter_percent"]; ok {
+		s.JitterPercent = opts.Int("jitter_percent")
+	}
+	return s.normalized(), nil
+}
+
+// normalized keeps MinInterval <= Interval <= MaxInterval and the jitter
+// between 0 and 50%.
+func (s Schedule) normalized() Schedule {
+	if s.Interval <= 0 {
+		s.Interval = time.Minute
+	}
+	if s.MinInterval <= 0 || s.MinInterval > s.Interval {
+		s.MinInterval = s.Interval
+	}
+	if s.MaxInterval < s.Interval {
+		s.MaxInterval = s.Interval
+	}
+	s.JitterPercent = max(0, min(s.JitterPercent, 50))
+	return s
+}
+
+func isScheduleKey(key string) bool {
+	for _, field := range ScheduleSchema {
+		if field.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutScheduleKeys returns raw without the ScheduleSchema options, which
+// scanner factories do not see.
+func withoutScheduleKeys(raw map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(raw))
+	for key, value := range raw {
+		if !isScheduleKey(strings.ToLower(strings.TrimSpace(key))) {
+			out[key] = value
+		}
+	}
+	return out
+}
+
+// Poller adapts a scanner's polling interval to what its passes find. A
+// pass without new bounties stretches the interval by half, up to
+// MaxInterval; a pass with new ones halves it, down to MinInterval. Failed
+// passes back off exponentially from the current interval, also capped at
+// MaxInterval.
+type Poller struct {
+	schedule Schedule
+	interval time.Duration
+	failures int
+	rand     func() float64 // in [0, 1)
+}
+
+// NewPoller returns a Poller starting at schedule's Interval.
+func NewPoller(schedule Schedule) *Poller {
+	schedule = schedule.normalized()
+	return &Poller{schedule: schedule, interval: schedule.Interval, rand: rand.Float64}
+}
+
+// Next records a pass that found fresh new bounties, or failed, and returns
+// how long to wait before the next one.
+func (p *Poller) Next(fresh int, failed bool) time.Duration {
+	wait := p.interval
+	switch {
+	case failed:
+		p.failures++
+		wait = p.interval << min(p.failures, 16)
+		if wait <= 0 || wait > p.schedule.MaxInterval {
+			wait = p.schedule.MaxInterval
+		}
+	case fresh > 0:
+		p.failures = 0
+		p.interval = max(p.interval/2, p.schedule.MinInterval)
+		wait = p.interval
+	default:
+		p.failures = 0
+		p.interval = min(p.interval*3/2, p.schedule.MaxInterval)
+		wait = p.interval
+	}
+
+	spread := float64(wait) * float64(p.schedule.JitterPercent) / 100
+	return wait + time.Duration(spread*(2*p.rand()-1))
+}
+
+// Interval is the current adaptive interval, before backoff and jitter.
+func (p *Poller) Interval() time.Duration {
+	return p.interval
+}
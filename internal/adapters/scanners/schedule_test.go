@@ -0,0 +1,64 @@
+package scanners
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveSchedule(t *testing.T) {
+	defaults := Schedule{Interval: time.Minute, MinInterval: 15 * time.Second, MaxInterval: 15 * time.Minute, JitterPercent: 10}
+
+	got, err := ResolveSchedule(map[string]interface{}{"labels": "bounty", "Interval_Seconds": "600", "jitter_percent": 0}, defaults)
+	if err != nil {
+		t.Fatalf("ResolveSchedule() error = %v", err)
+	}
+	want := Schedule{Interval: 10 * time.Minute, MinInterval: 15 * time.Second, MaxInterval: 15 * time.Minute}
+	if got != want {
+		t.Errorf("ResolveSchedule() = %+v, want %+v", got, want)
+	}
+
+	// An interval beyond the maximum raises it, and jitter is capped
+	got, err = ResolveSchedule(map[string]interface{}{"interval_seconds": 3600, "jitter_percent": 90}, defaults)
+	if err != nil || got.MaxInterval != time.Hour || got.JitterPercent != 50 {
+		t.Errorf("ResolveSchedule() = %+v, %v; want max 1h and 50%% jitter", got, err)
+	}
+
+	if _, err := ResolveSchedule(map[string]interface{}{"interval_seconds": "often"}, defaults); err == nil {
+		t.Error("ResolveSchedule() accepted a non-numeric interval")
+	}
+}
+
+func TestPoller_Adapts(t *testing.T) {
+	p := NewPoller(Schedule{Interval: time.Minute, MinInterval: 20 * time.Second, MaxInterval: 2 * time.Minute})
+
+	for _, step := range []struct {
+		fresh  int
+		failed bool
+		want   time.Duration
+	}{
+		{0, false, 90 * time.Second}, // quiet: slow down by half
+		{0, false, 2 * time.Minute},  // capped at the maximum
+		{5, false, time.Minute},      // activity: speed up
+		{3, false, 30 * time.Second},
+		{1, false, 20 * time.Second}, // floored at the minimum
+		{0, true, 40 * time.Second},  // failures back off exponentially
+		{0, true, 80 * time.Second},
+		{0, true, 2 * time.Minute},   // up to the maximum
+		{0, false, 30 * time.Second}, // recovery resumes from the interval
+	} {
+		if got := p.Next(step.fresh, step.failed); got != step.want {
+			t.Fatalf("Next(%d, %v) = %s, want %s", step.fresh, step.failed, got, step.want)
+		}
+	}
+}
+
+func TestPoller_Jitter(t *testing.T) {
+	p := NewPoller(Schedule{Interval: 100 * time.Second, MaxInterval: 100 * time.Second, JitterPercent: 10})
+	for _, r := range []float64{0, 0.5, 0.999} {
+		p.rand = func() float64 { return r }
+		got := p.Next(0, false)
+		if got < 90*time.Second || got > 110*time.Second {
+			t.Errorf("Next() with rand %v = %s, want within 10%% of 100s", r, got)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package scanners
+
+import "sync"
+
+// StateStore persists scanner progress, such as cursors, across restarts.
+// storage.Store satisfies it.
+type StateStore interface {
+	GetScanState(scanner, key string) (string, error) // "" when unset
+	SetScanState(scanner, key, value string) error
+}
+
+// StatefulScanner is implemented by scanners that resume from stored state.
+// Until SetStateStore is called they keep their state in memory.
+type StatefulScanner interface {
+	SetStateStore(store StateStore)
+}
+
+// memoryState is the StateStore scanners use before SetStateStore.
+type memoryState struct {
+	mu     sync.Mutex
+	values map[[2]string]string
+}
+
+func newMemoryState() *memoryState {
+	return &memoryState{values: make(map[[2]string]string)}
+}
+
+func (m *memoryState) GetScanState(scanner, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.values[[2]string{scanner, key}], nil
+}
+
+func (m *memoryState) SetScanState(scanner, key, value string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[[2]string{scanner, key}] = value
+	return nil
+}
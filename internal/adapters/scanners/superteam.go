@@ -38,6 +38,23 @@ type SuperteamListing struct {
 	Status           string   `json:"status"`
 }
 
+func init() {
+	Register(Registration{
+		Name:        "SUPERTEAM",
+		Description: "Superteam Earn bounty listings",
+		Schema: ConfigSchema{
+			{Key: "base_url", Type: FieldString, Default: "https://earn.superteam.fun/api/listings", Description: "Listings API endpoint"},
+			{Key: "statuses", Type: FieldStringList, Default: []string{"open"}, Description: "Listing statuses to fetch"},
+		},
+		Factory: func(opts Options) (core.Scanner, error) {
+			return NewSuperteamScanner(SuperteamScannerConfig{
+				BaseURL:  opts.String("base_url"),
+				Statuses: opts.StringList("statuses"),
+			}), nil
+		},
+	})
+}
+
 func NewSuperteamScanner(cfg SuperteamScannerConfig) *SuperteamScanner {
 	baseURL := strings.TrimRight(cfg.BaseURL, "/")
 	if baseURL == "" {
@@ -59,6 +76,11 @@ func (s *SuperteamScanner) Name() string {
 	return "Superteam Earn"
 }
 
+// Platforms returns the platform of every bounty the scanner emits.
+func (s *SuperteamScanner) Platforms() []string {
+	return []string{"SUPERTEAM"}
+}
+
 func (s *SuperteamScanner) Scan(ctx context.Context) (<-chan core.Bounty, error) {
 	ch := make(chan core.Bounty)
 
@@ -68,9 +90,7 @@ func (s *SuperteamScanner) Scan(ctx context.Context) (<-chan core.Bounty, error)
 		for _, status := range s.normalizedStatuses() {
 			if err := s.scanStatus(ctx, status, ch); err != nil {
 				security.GetLogger().Error("Error fetching Superteam (%s): %v", status, err)
-				// For demonstration/fallback, we'll emit "mock" bounties if the real API fails
-				// so the user sees it working.
-				s.emitMockBounties(ch, status)
+				reportScanError(ctx, err)
 			}
 		}
 	}()
@@ -103,12 +123,12 @@ func (s *SuperteamScanner) scanStatus(ctx context.Context, status string, ch cha
 		return err
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("unexpected status %d from %s: %s", resp.StatusCode, url, responseSnippet(body))
+		return newStatusError(url, resp.StatusCode, body)
 	}
 
 	var results []SuperteamListing
 	if err := json.Unmarshal(body, &results); err != nil {
-		return fmt.Errorf("invalid JSON from %s: %w (snippet: %s)", url, err, responseSnippet(body))
+		return newDecodeError(url, err, body)
 	}
 
 	for _, item := range results {
@@ -132,16 +152,22 @@ func (s *SuperteamScanner) scanStatus(ctx context.Context, status string, ch cha
 		}
 
 		reward := ""
+		var rewardMin, rewardMax float64
+		rewardExact := false
 		if item.RewardAmount != nil {
+			rewardMin, rewardMax = *item.RewardAmount, *item.RewardAmount
+			rewardExact = true
 			reward = formatAmount(*item.RewardAmount)
 		} else if item.MinRewardAsk != nil || item.MaxRewardAsk != nil {
 			min := ""
 			max := ""
 			if item.MinRewardAsk != nil {
 				min = formatAmount(*item.MinRewardAsk)
+				rewardMin, rewardMax = *item.MinRewardAsk, *item.MinRewardAsk
 			}
 			if item.MaxRewardAsk != nil {
 				max = formatAmount(*item.MaxRewardAsk)
+				rewardMax = *item.MaxRewardAsk
 			}
 			switch {
 			case min != "" && max != "":
@@ -173,7 +199,11 @@ func (s *SuperteamScanner) scanStatus(ctx context.Context, status string, ch cha
 			Description: item.Title,
 			Tags:        tags,
 			PaymentType: "crypto",
+			RewardMin:   rewardMin,
+			RewardMax:   rewardMax,
+			RewardExact: rewardExact,
 		}
+		bounty.ApplyReward()
 
 		select {
 		case ch <- bounty:
@@ -228,43 +258,3 @@ func formatAmount(value float64) string {
 	formatted = strings.TrimRight(formatted, ".")
 	return formatted
 }
-
-func (s *SuperteamScanner) emitMockBounties(ch chan<- core.Bounty, status string) {
-	statusTag := status
-	if statusTag == "" {
-		statusTag = "active"
-	}
-	urlSuffix := "?status=" + statusTag
-	idSuffix := "-" + statusTag
-
-	mockBounties := []core.Bounty{
-		{
-			ID:          "st-1" + idSuffix,
-			Title:       "ERA Wallet Comparison Bounty",
-			Platform:    "SUPERTEAM",
-			Reward:      "500",
-			Currency:    "USDC",
-			URL:         "https://earn.superteam.fun/listings/bounty/era-wallet-comparison-bounty" + urlSuffix,
-			CreatedAt:   time.Now().Add(-1 * time.Hour),
-			Description: "Compare ERA wallet with other Solana wallets.",
-			Tags:        []string{"solana", "wallet", "research", statusTag},
-			PaymentType: "crypto",
-		},
-		{
-			ID:          "st-2" + idSuffix,
-			Title:       "Marketing Growth Lead",
-			Platform:    "SUPERTEAM",
-			Reward:      "2000",
-			Currency:    "USDC",
-			URL:         "https://earn.superteam.fun/listings/bounty/marketing-growth-lead-launchpadtrade" + urlSuffix,
-			CreatedAt:   time.Now().Add(-5 * time.Hour),
-			Description: "Lead marketing growth for LaunchpadTrade.",
-			Tags:        []string{"solana", "marketing", statusTag},
-			PaymentType: "crypto",
-		},
-	}
-
-	for _, b := range mockBounties {
-		ch <- b
-	}
-}
@@ -2,6 +2,7 @@ package scanners
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -13,25 +14,28 @@ import (
 
 func TestSuperteamScanner_ScanStatuses(t *testing.T) {
 	now := time.Now().UTC().Format(time.RFC3339)
-	activeResponse := fmt.Sprintf(`[{
-		"id":"st-active",
-		"title":"Active Listing",
-		"description":"active",
-		"reward":500,
+	openResponse := fmt.Sprintf(`[{
+		"id":"st-open",
+		"title":"Open Listing",
+		"type":"bounty",
+		"description":"open",
+		"rewardAmount":500,
 		"token":"USDC",
 		"deadline":"",
 		"createdAt":"%s",
-		"slug":"active-listing"
+		"slug":"open-listing"
 	}]`, now)
-	fundedResponse := fmt.Sprintf(`[{
-		"id":"st-funded",
-		"title":"Funded Listing",
-		"description":"funded",
-		"reward":750,
+	reviewResponse := fmt.Sprintf(`[{
+		"id":"st-review",
+		"title":"Review Listing",
+		"type":"bounty",
+		"description":"review",
+		"minRewardAsk":750,
+		"maxRewardAsk":1500,
 		"token":"USDC",
 		"deadline":"",
 		"createdAt":"%s",
-		"slug":"funded-listing"
+		"slug":"review-listing"
 	}]`, now)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -43,10 +47,10 @@ func TestSuperteamScanner_ScanStatuses(t *testing.T) {
 		status := r.URL.Query().Get("status")
 		w.Header().Set("Content-Type", "application/json")
 		switch status {
-		case "active":
-			fmt.Fprint(w, activeResponse)
-		case "funded":
-			fmt.Fprint(w, fundedResponse)
+		case "open":
+			fmt.Fprint(w, openResponse)
+		case "review":
+			fmt.Fprint(w, reviewResponse)
 		default:
 			fmt.Fprint(w, `[]`)
 		}
@@ -55,7 +59,7 @@ func TestSuperteamScanner_ScanStatuses(t *testing.T) {
 
 	scanner := NewSuperteamScanner(SuperteamScannerConfig{})
 	scanner.baseURL = ts.URL + "/api/bounties"
-	scanner.statuses = []string{"active", "funded"}
+	scanner.statuses = []string{"open", "in-progress"}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -76,13 +80,19 @@ func TestSuperteamScanner_ScanStatuses(t *testing.T) {
 
 	for _, b := range bounties {
 		switch b.Title {
-		case "Active Listing":
-			if !hasTag(b.Tags, "active") {
-				t.Errorf("Expected active tag in %v", b.Tags)
+		case "Open Listing":
+			if !hasTag(b.Tags, "open") {
+				t.Errorf("Expected open tag in %v", b.Tags)
 			}
-		case "Funded Listing":
-			if !hasTag(b.Tags, "funded") {
-				t.Errorf("Expected funded tag in %v", b.Tags)
+			if b.RewardMin != 500 || b.RewardMax != 500 || !b.RewardExact || b.RewardToken != "USDC" {
+				t.Errorf("Unexpected fixed reward: %v-%v %s exact=%v", b.RewardMin, b.RewardMax, b.RewardToken, b.RewardExact)
+			}
+		case "Review Listing":
+			if !hasTag(b.Tags, "review") {
+				t.Errorf("Expected review tag in %v", b.Tags)
+			}
+			if b.Reward != "750-1500" || b.RewardMin != 750 || b.RewardMax != 1500 || b.RewardExact {
+				t.Errorf("Unexpected ask range: %s (%v-%v exact=%v)", b.Reward, b.RewardMin, b.RewardMax, b.RewardExact)
 			}
 		default:
 			t.Fatalf("Unexpected bounty title: %s", b.Title)
@@ -104,3 +114,39 @@ func hasTag(tags []string, target string) bool {
 	}
 	return false
 }
+
+func TestSuperteamScanner_ReportsFailureWithoutMocks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusGone)
+	}))
+	defer ts.Close()
+
+	scanner := NewSuperteamScanner(SuperteamScannerConfig{BaseURL: ts.URL + "/api/bounties"})
+
+	var reported []error
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ctx = WithErrorSink(ctx, func(err error) {
+		reported = append(reported, err)
+	})
+
+	ch, err := scanner.Scan(ctx)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	count := 0
+	for range ch {
+		count++
+	}
+
+	if count != 0 {
+		t.Fatalf("Expected no bounties from a failing source, got %d", count)
+	}
+	if len(reported) != 1 {
+		t.Fatalf("Expected 1 reported error, got %v", reported)
+	}
+	var scanErr *core.ScanError
+	if !errors.As(reported[0], &scanErr) || scanErr.Kind != core.ScanErrorStatus || scanErr.StatusCode != http.StatusGone {
+		t.Errorf("Expected typed status error, got %v", reported[0])
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"strings"
 	"time"
 
+	"bountyos-v8/internal/core"
 	"bountyos-v8/internal/security"
 )
 
@@ -47,5 +49,49 @@ func doRequestWithRetry(ctx context.Context, client *http.Client, req *http.Requ
 		lastErr = err
 	}
 
-	return nil, fmt.Errorf("after %d retries, last error: %w", maxRetries, lastErr)
+	return nil, &core.ScanError{
+		Kind: core.ScanErrorRequest,
+		URL:  req.URL.String(),
+		Err:  fmt.Errorf("after %d retries, last error: %w", maxRetries, lastErr),
+	}
+}
+
+// parseProgramTime accepts the RFC3339 timestamps and bare dates used by bug
+// bounty program listings.
+func parseProgramTime(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05.000Z", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// scopeTag turns an asset type such as "SMART_CONTRACT" or "websites and
+// applications" into a "scope:smart-contract" style tag.
+func scopeTag(assetType string) string {
+	normalized := strings.ToLower(strings.TrimSpace(assetType))
+	if normalized == "" {
+		return ""
+	}
+	normalized = strings.Join(strings.FieldsFunc(normalized, func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-' || r == '/'
+	}), "-")
+	return "scope:" + normalized
+}
+
+func appendUniqueTag(tags []string, tag string) []string {
+	if tag == "" {
+		return tags
+	}
+	for _, existing := range tags {
+		if existing == tag {
+			return tags
+		}
+	}
+	return append(tags, tag)
 }
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+)
+
+// dialect is the SQL flavour of a backend. Queries are written for SQLite
+// with ? placeholders; the few expressions that differ go through the
+// methods below.
+type dialect int
+
+const (
+	sqliteDialect dialect = iota
+	postgresDialect
+)
+
+// rebind rewrites ? placeholders outside string literals as $1, $2, ... for
+// Postgres.
+func (d dialect) rebind(query string) string {
+	if d != postgresDialect || !strings.Contains(query, "?") {
+		return query
+	}
+	var b strings.Builder
+	b.Grow(len(query) + 16)
+	n, quoted := 0, false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'':
+			quoted = !quoted
+		case c == '?' && !quoted:
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// epoch returns a numeric expression for the RFC 3339 timestamp in expr, so
+// timestamps with different zone offsets compare correctly. Empty and NULL
+// timestamps give NULL.
+func (d dialect) epoch(expr string) string {
+	if d == postgresDialect {
+		return `EXTRACT(EPOCH FROM CAST(NULLIF(` + expr + `, '') AS TIMESTAMPTZ))`
+	}
+	return `julianday(` + expr + `)`
+}
+
+// contains returns a condition true when column contains the next argument.
+func (d dialect) contains(column string) string {
+	if d == postgresDialect {
+		return `strpos(` + column + `, ?) > 0`
+	}
+	return `instr(` + column + `, ?) > 0`
+}
+
+// hasTag returns a condition true when the JSON tags array holds the next
+// argument, compared in lower case.
+func (d dialect) hasTag() string {
+	if d == postgresDialect {
+		return `EXISTS (SELECT 1 FROM jsonb_array_elements_text(CASE WHEN tags LIKE '[%' THEN CAST(tags AS JSONB) ELSE '[]' END) AS tag(value)
+			WHERE LOWER(tag.value) = ?)`
+	}
+	return `json_valid(tags) AND EXISTS (SELECT 1 FROM json_each(tags) WHERE LOWER(json_each.value) = ?)`
+}
+
+// database is a connection pool that rebinds placeholders for its dialect.
+type database struct {
+	*sql.DB
+	dialect dialect
+}
+
+func (db *database) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return db.DB.Exec(db.dialect.rebind(query), args...)
+}
+
+func (db *database) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return db.DB.Query(db.dialect.rebind(query), args...)
+}
+
+func (db *database) QueryRow(query string, args ...interface{}) *sql.Row {
+	return db.DB.QueryRow(db.dialect.rebind(query), args...)
+}
+
+func (db *database) Begin() (*txn, error) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &txn{Tx: tx, dialect: db.dialect}, nil
+}
+
+// txn is a transaction that rebinds placeholders for its dialect.
+type txn struct {
+	*sql.Tx
+	dialect dialect
+}
+
+func (tx *txn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return tx.Tx.Exec(tx.dialect.rebind(query), args...)
+}
+
+func (tx *txn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.Tx.Query(tx.dialect.rebind(query), args...)
+}
+
+func (tx *txn) QueryRow(query string, args ...interface{}) *sql.Row {
+	return tx.Tx.QueryRow(tx.dialect.rebind(query), args...)
+}
+
+func (tx *txn) Prepare(query string) (*sql.Stmt, error) {
+	return tx.Tx.Prepare(tx.dialect.rebind(query))
+}
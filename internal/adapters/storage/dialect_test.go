@@ -0,0 +1,25 @@
+package storage
+
+import "testing"
+
+func TestDialectRebind(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`SELECT url FROM bounties WHERE url = ?`, `SELECT url FROM bounties WHERE url = $1`},
+		{`WHERE CAST(? AS TEXT) = '' OR action = ? LIMIT ?`, `WHERE CAST($1 AS TEXT) = '' OR action = $2 LIMIT $3`},
+		{`SELECT '?' || title FROM bounties WHERE id = ?`, `SELECT '?' || title FROM bounties WHERE id = $1`},
+		{`SELECT 1 FROM bounties WHERE ` + pgSearchDocument + ` @@ websearch_to_tsquery('english', ?)`,
+			`SELECT 1 FROM bounties WHERE ` + pgSearchDocument + ` @@ websearch_to_tsquery('english', $1)`},
+		{`SELECT 1`, `SELECT 1`},
+	}
+	for _, tt := range tests {
+		if got := postgresDialect.rebind(tt.query); got != tt.want {
+			t.Errorf("rebind(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+		if got := sqliteDialect.rebind(tt.query); got != tt.query {
+			t.Errorf("SQLite rebind(%q) = %q, want it unchanged", tt.query, got)
+		}
+	}
+}
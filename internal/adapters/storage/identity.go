@@ -0,0 +1,280 @@
+package storage
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+// FindDuplicate returns the stored bounty that b is another sighting of and
+// how it matched: the same canonical URL, a link between the two listings
+// in either direction, or, for listings from another platform created
+// within opts.Window, a title at least opts.TitleSimilarity alike. The match
+// kind is empty when b is new.
+func (s *sqlStore) FindDuplicate(b core.Bounty, opts core.DedupOptions) (core.Bounty, string, error) {
+	found, match, err := s.findDuplicate(b, opts)
+	if err == ErrNotFound {
+		return core.Bounty{}, "", nil
+	}
+	return found, match, err
+}
+
+func (s *sqlStore) findDuplicate(b core.Bounty, opts core.DedupOptions) (core.Bounty, string, error) {
+	canonical := core.CanonicalURL(b.URL)
+	if found, err := s.findOne(`canonical_url = ? OR url = ?`, canonical, b.URL); err != ErrNotFound {
+		return found, core.MatchURL, err
+	}
+
+	// Links only tie listings from different platforms: an issue that links
+	// to another issue on the same tracker is usually a separate task.
+	// b links to a stored listing, e.g. a Bountycaster post for a GitHub issue.
+	for _, link := range core.ExtractLinks(b.Description) {
+		if link == canonical {
+			continue
+		}
+		found, err := s.findOne(`canonical_url = ?`, link)
+		if err == ErrNotFound || (err == nil && sameSource(found, b)) {
+			continue
+		}
+		return found, core.MatchLink, err
+	}
+	// A stored listing links to b.
+	if found, err := s.findLinkingTo(b, canonical); err != ErrNotFound {
+		return found, core.MatchLink, err
+	}
+
+	if opts.TitleSimilarity <= 0 {
+		return core.Bounty{}, "", ErrNotFound
+	}
+	return s.findSimilarTitle(b, opts)
+}
+
+func (s *sqlStore) findOne(where string, args ...interface{}) (core.Bounty, error) {
+	rows, err := s.db.Query(`SELECT `+bountyColumns+` FROM bounties WHERE `+where+` ORDER BY first_seen LIMIT 1`, args...)
+	if err != nil {
+		return core.Bounty{}, err
+	}
+	defer rows.Close()
+
+	bounties := scanBounties(rows)
+	if len(bounties) == 0 {
+		return core.Bounty{}, ErrNotFound
+	}
+	return bounties[0], nil
+}
+
+// findLinkingTo returns the earliest stored bounty from another source
+// whose description links to canonical, looked up in bounty_links.
+func (s *sqlStore) findLinkingTo(b core.Bounty, canonical string) (core.Bounty, error) {
+	rows, err := s.db.Query(`SELECT `+bountyColumns+` FROM bounties
+		WHERE url IN (SELECT bounty_url FROM bounty_links WHERE link = ?)
+		ORDER BY first_seen`, canonical)
+	if err != nil {
+		return core.Bounty{}, err
+	}
+	defer rows.Close()
+
+	for _, candidate := range scanBounties(rows) {
+		if !sameSource(candidate, b) {
+			return candidate, nil
+		}
+	}
+	return core.Bounty{}, ErrNotFound
+}
+
+// findSimilarTitle returns the open bounty from another source whose title
+// is most like b's. With a window, only bounties created within it of b are
+// read; the bounds compare as instants since created_at keeps its offset.
+func (s *sqlStore) findSimilarTitle(b core.Bounty, opts core.DedupOptions) (core.Bounty, string, error) {
+	created := b.CreatedAt
+	if created.IsZero() {
+		created = time.Now()
+	}
+
+	query := `SELECT id, title, platform FROM bounties WHERE status = 'open'`
+	var args []interface{}
+	if opts.Window > 0 {
+		epoch := s.db.dialect.epoch
+		query += ` AND ` + epoch("created_at") + ` BETWEEN ` + epoch("?") + ` AND ` + epoch("?")
+		args = append(args, created.Add(-opts.Window).UTC().Format(time.RFC3339), created.Add(opts.Window).UTC().Format(time.RFC3339))
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return core.Bounty{}, "", err
+	}
+	bestID, bestScore := "", 0.0
+	for rows.Next() {
+		var id, title, otherPlatform sql.NullString
+		if err := rows.Scan(&id, &title, &otherPlatform); err != nil {
+			rows.Close()
+			return core.Bounty{}, "", err
+		}
+		if sameSource(core.Bounty{Platform: otherPlatform.String}, b) {
+			continue // the same source lists distinct issues with similar titles
+		}
+		if score := core.TitleSimilarity(b.Title, title.String); score >= opts.TitleSimilarity && score > bestScore {
+			bestID, bestScore = id.String, score
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return core.Bounty{}, "", err
+	}
+	if bestID == "" {
+		return core.Bounty{}, "", ErrNotFound
+	}
+	found, err := s.GetByID(bestID)
+	return found, core.MatchTitle, err
+}
+
+// sameSource reports whether a and b come from the same platform, ignoring
+// labels.
+func sameSource(a, b core.Bounty) bool {
+	return strings.EqualFold(core.SourceOf(a).Platform, core.SourceOf(b).Platform)
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// RecordSource notes that the stored bounty at bountyURL was seen as src,
+// keeping the first sighting time and match kind of a known source.
+func (s *sqlStore) RecordSource(bountyURL string, src core.BountySource) error {
+	now := time.Now()
+	if src.Match == "" {
+		src.Match = core.MatchURL
+	}
+	_, err := s.db.Exec(`INSERT INTO bounty_sources
+		(bounty_url, platform, label, source_id, url, match, first_seen, last_seen)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(bounty_url, platform, label, url) DO UPDATE SET
+		 source_id = CASE WHEN excluded.source_id != '' THEN excluded.source_id ELSE bounty_sources.source_id END,
+		 last_seen = excluded.last_seen`,
+		bountyURL,
+		src.Platform,
+		src.Label,
+		src.SourceID,
+		src.URL,
+		src.Match,
+		formatSeen(now),
+		formatSeen(now),
+	)
+	return err
+}
+
+// GetSources returns every source recorded for the bounty with id, first
+// seen first.
+func (s *sqlStore) GetSources(id string) ([]core.BountySource, error) {
+	rows, err := s.db.Query(`SELECT src.platform, src.label, COALESCE(src.source_id, ''), src.url, COALESCE(src.match, ''),
+		src.first_seen, src.last_seen
+		FROM bounty_sources src JOIN bounties b ON b.url = src.bounty_url
+		WHERE b.id = ?
+		ORDER BY src.first_seen, src.platform, src.label`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sources := []core.BountySource{}
+	for rows.Next() {
+		var src core.BountySource
+		var firstSeen, lastSeen sql.NullString
+		if err := rows.Scan(&src.Platform, &src.Label, &src.SourceID, &src.URL, &src.Match, &firstSeen, &lastSeen); err != nil {
+			return nil, err
+		}
+		src.FirstSeen, _ = parseTime(firstSeen.String)
+		src.LastSeen, _ = parseTime(lastSeen.String)
+		sources = append(sources, src)
+	}
+	return sources, rows.Err()
+}
+
+// backfillIdentity sets canonical URLs for rows saved before canonical
+// identity and records each row's platform as its first source.
+func backfillIdentity(db dbtx) error {
+	rows, err := db.Query(`SELECT url, COALESCE(platform, ''), first_seen, last_seen FROM bounties
+		WHERE canonical_url IS NULL OR canonical_url = ''`)
+	if err != nil {
+		return err
+	}
+	type legacyRow struct {
+		url, platform       string
+		firstSeen, lastSeen sql.NullString
+	}
+	var legacy []legacyRow
+	for rows.Next() {
+		var row legacyRow
+		if err := rows.Scan(&row.url, &row.platform, &row.firstSeen, &row.lastSeen); err != nil {
+			rows.Close()
+			return err
+		}
+		legacy = append(legacy, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, row := range legacy {
+		if _, err := db.Exec(`UPDATE bounties SET canonical_url = ? WHERE url = ?`, core.CanonicalURL(row.url), row.url); err != nil {
+			return err
+		}
+		src := core.SourceOf(core.Bounty{URL: row.url, Platform: row.platform})
+		if _, err := db.Exec(`INSERT INTO bounty_sources
+			(bounty_url, platform, label, source_id, url, match, first_seen, last_seen)
+			VALUES (?, ?, ?, '', ?, ?, ?, ?)
+			ON CONFLICT(bounty_url, platform, label, url) DO NOTHING`,
+			row.url, src.Platform, src.Label, row.url, core.MatchURL, row.firstSeen, row.lastSeen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveLinks replaces the links recorded for the bounty at url with those in
+// description.
+func saveLinks(db dbtx, url, description string) error {
+	if _, err := db.Exec(`DELETE FROM bounty_links WHERE bounty_url = ?`, url); err != nil {
+		return err
+	}
+	for _, link := range core.ExtractLinks(description) {
+		if _, err := db.Exec(`INSERT INTO bounty_links (bounty_url, link) VALUES (?, ?)
+			ON CONFLICT(bounty_url, link) DO NOTHING`, url, link); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillLinks records the links of rows saved before bounty_links existed.
+func backfillLinks(db dbtx) error {
+	rows, err := db.Query(`SELECT url, COALESCE(description, '') FROM bounties`)
+	if err != nil {
+		return err
+	}
+	descriptions := make(map[string]string)
+	for rows.Next() {
+		var url, description string
+		if err := rows.Scan(&url, &description); err != nil {
+			rows.Close()
+			return err
+		}
+		descriptions[url] = description
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for url, description := range descriptions {
+		if err := saveLinks(db, url, description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+func TestStorage_FindDuplicate(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+
+		now := time.Now()
+		issue := core.Bounty{
+			URL:       "https://github.com/org/repo/issues/7",
+			Title:     "Add Solana wallet adapter support",
+			Platform:  "GITHUB/BOUNTY",
+			CreatedAt: now,
+		}
+		if err := store.Save(issue); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := store.RecordSource(issue.URL, core.SourceOf(issue)); err != nil {
+			t.Fatalf("RecordSource() error = %v", err)
+		}
+		opts := core.DedupOptions{TitleSimilarity: 0.8, Window: 7 * 24 * time.Hour}
+
+		tests := []struct {
+			name   string
+			bounty core.Bounty
+			want   string
+		}{
+			{"same issue under another label", core.Bounty{URL: "https://www.github.com/org/repo/issues/7/", Platform: "GITHUB/FUNDED", Title: "x"}, core.MatchURL},
+			{"post linking to the issue", core.Bounty{URL: "https://bountycaster.xyz/bounty/1", Platform: "BOUNTYCASTER", Title: "Help wanted",
+				Description: "500 USDC for https://github.com/org/repo/issues/7#top"}, core.MatchLink},
+			{"similar title elsewhere", core.Bounty{URL: "https://superteam.fun/listing/wallet", Platform: "SUPERTEAM", CreatedAt: now,
+				Title: "[Bounty] Add Solana Wallet Adapter support"}, core.MatchTitle},
+			{"similar title on the same platform", core.Bounty{URL: "https://github.com/org/repo/issues/8", Platform: "GITHUB/BOUNTY", CreatedAt: now,
+				Title: "Add Solana wallet adapter support"}, ""},
+			{"similar title outside the window", core.Bounty{URL: "https://superteam.fun/listing/old", Platform: "SUPERTEAM", CreatedAt: now.Add(-30 * 24 * time.Hour),
+				Title: "Add Solana wallet adapter support"}, ""},
+			{"issue linking to a neighbouring issue", core.Bounty{URL: "https://github.com/org/repo/issues/70", Platform: "GITHUB/BOUNTY", Title: "Other work",
+				Description: "Follow-up to https://github.com/org/repo/issues/7"}, ""},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				found, match, err := store.FindDuplicate(tt.bounty, opts)
+				if err != nil {
+					t.Fatalf("FindDuplicate() error = %v", err)
+				}
+				if match != tt.want {
+					t.Fatalf("FindDuplicate() match = %q, want %q", match, tt.want)
+				}
+				if match != "" && found.URL != issue.URL {
+					t.Errorf("FindDuplicate() found %s, want %s", found.URL, issue.URL)
+				}
+			})
+		}
+
+		// A stored post that links to an issue seen later.
+		post := core.Bounty{URL: "https://bountycaster.xyz/bounty/2", Platform: "BOUNTYCASTER", Title: "Port indexer",
+			Description: "Details: https://github.com/org/repo/issues/9", CreatedAt: now}
+		if err := store.Save(post); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		found, match, err := store.FindDuplicate(core.Bounty{URL: "https://github.com/org/repo/issues/9", Platform: "GITHUB/BOUNTY", Title: "Port the indexer"}, opts)
+		if err != nil || match != core.MatchLink || found.URL != post.URL {
+			t.Errorf("FindDuplicate(linked from stored post) = %s, %q, %v", found.URL, match, err)
+		}
+		if _, match, _ := store.FindDuplicate(core.Bounty{URL: "https://github.com/org/repo/issues/90", Platform: "GITHUB/BOUNTY", Title: "z"}, opts); match != "" {
+			t.Errorf("issues/90 matched %q via a link to issues/9", match)
+		}
+		post.Description = "Details moved to Discord"
+		if err := store.Save(post); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if _, match, _ := store.FindDuplicate(core.Bounty{URL: "https://github.com/org/repo/issues/9", Platform: "GITHUB/BOUNTY", Title: "z"}, opts); match != "" {
+			t.Errorf("issues/9 matched %q after the post dropped its link", match)
+		}
+
+		funded := core.SourceOf(core.Bounty{URL: issue.URL, Platform: "GITHUB/FUNDED"})
+		funded.Match = core.MatchURL
+		if err := store.RecordSource(issue.URL, funded); err != nil {
+			t.Fatalf("RecordSource() error = %v", err)
+		}
+		sources, err := store.GetSources(core.BountyID(issue.URL))
+		if err != nil || len(sources) != 2 {
+			t.Fatalf("GetSources() = %+v, %v", sources, err)
+		}
+		labels := map[string]bool{}
+		for _, src := range sources {
+			labels[src.Label] = src.Platform == "GITHUB"
+		}
+		if !labels["BOUNTY"] || !labels["FUNDED"] {
+			t.Errorf("GetSources() labels = %v", labels)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+// RecordPayment adds a received payment for the bounty with id to the
+// ledger and moves its pipeline record to paid, creating it if needed.
+// The stored entry is returned with its ledger ID.
+func (s *sqlStore) RecordPayment(id string, entry core.LedgerEntry) (core.LedgerEntry, error) {
+	url, err := s.urlForID(id)
+	if err != nil {
+		return core.LedgerEntry{}, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return core.LedgerEntry{}, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	if entry.ReceivedAt.IsZero() {
+		entry.ReceivedAt = now
+	}
+	var ledgerID int64
+	err = tx.QueryRow(`INSERT INTO ledger
+		(url, amount, token, amount_usd, reference, tier, received_at, recorded_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id`,
+		url,
+		entry.Amount,
+		entry.Token,
+		entry.AmountUSD,
+		entry.Reference,
+		entry.Tier,
+		formatSeen(entry.ReceivedAt),
+		formatSeen(now),
+	).Scan(&ledgerID)
+	if err != nil {
+		return core.LedgerEntry{}, err
+	}
+
+	tracking, err := getTracking(tx, url)
+	if err != nil && !errors.Is(err, ErrNotTracked) {
+		return core.LedgerEntry{}, err
+	}
+	if tracking.State != core.StatePaid {
+		paid := core.StatePaid
+		tracking.Apply(core.TrackingUpdate{State: &paid}, now)
+		received := entry.ReceivedAt
+		tracking.PaidAt = &received
+		if err := saveTracking(tx, url, tracking); err != nil {
+			return core.LedgerEntry{}, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return core.LedgerEntry{}, err
+	}
+
+	entries, err := s.queryLedger(`WHERE l.id = ?`, ledgerID)
+	if err != nil {
+		return core.LedgerEntry{}, err
+	}
+	if len(entries) == 0 {
+		return core.LedgerEntry{}, ErrNotFound
+	}
+	return entries[0], nil
+}
+
+// GetLedger returns every recorded payment, most recently received first.
+func (s *sqlStore) GetLedger() ([]core.LedgerEntry, error) {
+	return s.queryLedger(`ORDER BY l.received_at DESC, l.id DESC`)
+}
+
+// UnreconciledPayments returns bounties marked paid that have no ledger entry.
+func (s *sqlStore) UnreconciledPayments() ([]core.Tracking, error) {
+	rows, err := s.db.Query(`SELECT ` + trackingColumns + `
+		FROM tracking t JOIN bounties b ON b.url = t.url
+		WHERE t.state = 'paid' AND NOT EXISTS (SELECT 1 FROM ledger l WHERE l.url = t.url)
+		ORDER BY t.updated_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []core.Tracking{}
+	for rows.Next() {
+		tracking, err := scanTracking(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, tracking)
+	}
+	return list, rows.Err()
+}
+
+func (s *sqlStore) queryLedger(clause string, args ...interface{}) ([]core.LedgerEntry, error) {
+	rows, err := s.db.Query(`SELECT l.id, COALESCE(b.id, ''), l.url, COALESCE(b.title, ''), COALESCE(b.platform, ''),
+		l.amount, l.token, l.amount_usd, l.reference, l.tier, l.received_at, l.recorded_at,
+		COALESCE(t.expected_payout, 0), COALESCE(t.payout_currency, ''), t.claimed_at
+		FROM ledger l
+		LEFT JOIN bounties b ON b.url = l.url
+		LEFT JOIN tracking t ON t.url = l.url
+		`+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []core.LedgerEntry{}
+	for rows.Next() {
+		var e core.LedgerEntry
+		var receivedAt, recordedAt string
+		var claimedAt sql.NullString
+		if err := rows.Scan(&e.ID, &e.BountyID, &e.URL, &e.Title, &e.Platform,
+			&e.Amount, &e.Token, &e.AmountUSD, &e.Reference, &e.Tier, &receivedAt, &recordedAt,
+			&e.ExpectedPayout, &e.PayoutCurrency, &claimedAt); err != nil {
+			return nil, err
+		}
+		e.ReceivedAt, _ = parseTime(receivedAt)
+		e.RecordedAt, _ = parseTime(recordedAt)
+		if claimedAt.Valid {
+			if t, err := parseTime(claimedAt.String); err == nil {
+				e.ClaimedAt = &t
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+func TestStorage_Ledger(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+
+		for _, url := range []string{"https://example.com/paid", "https://example.com/unrecorded"} {
+			if err := store.Save(core.Bounty{URL: url, Title: url, Platform: "TEST", CreatedAt: time.Now()}); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+		paidID := core.BountyID("https://example.com/paid")
+		unrecordedID := core.BountyID("https://example.com/unrecorded")
+
+		claimed := core.StateClaimed
+		payout := 500.0
+		if _, err := store.UpdateTracking(paidID, core.TrackingUpdate{State: &claimed, ExpectedPayout: &payout}); err != nil {
+			t.Fatalf("UpdateTracking() error = %v", err)
+		}
+		paid := core.StatePaid
+		if _, err := store.UpdateTracking(unrecordedID, core.TrackingUpdate{State: &paid}); err != nil {
+			t.Fatalf("UpdateTracking() error = %v", err)
+		}
+
+		received := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+		entry, err := store.RecordPayment(paidID, core.LedgerEntry{
+			Amount:     480,
+			Token:      "USDC",
+			AmountUSD:  480,
+			Reference:  "0xabc",
+			Tier:       "CryptoKing",
+			ReceivedAt: received,
+		})
+		if err != nil {
+			t.Fatalf("RecordPayment() error = %v", err)
+		}
+		if entry.ID == 0 || entry.BountyID != paidID || entry.ExpectedPayout != 500 || entry.ClaimedAt == nil || !entry.ReceivedAt.Equal(received) {
+			t.Errorf("RecordPayment() = %+v", entry)
+		}
+
+		tracking, err := store.GetTracking(paidID)
+		if err != nil || tracking.State != core.StatePaid || tracking.PaidAt == nil || !tracking.PaidAt.Equal(received) {
+			t.Errorf("tracking after payment = %+v, %v", tracking, err)
+		}
+
+		ledger, err := store.GetLedger()
+		if err != nil || len(ledger) != 1 || ledger[0].Reference != "0xabc" {
+			t.Errorf("GetLedger() = %+v, %v", ledger, err)
+		}
+
+		unreconciled, err := store.UnreconciledPayments()
+		if err != nil || len(unreconciled) != 1 || unreconciled[0].BountyID != unrecordedID {
+			t.Errorf("UnreconciledPayments() = %+v, %v", unreconciled, err)
+		}
+
+		if _, err := store.RecordPayment("missing", core.LedgerEntry{Amount: 1}); err != ErrNotFound {
+			t.Errorf("RecordPayment(missing) error = %v, want ErrNotFound", err)
+		}
+	})
+}
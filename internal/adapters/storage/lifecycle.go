@@ -0,0 +1,239 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+// formatSeen stores sighting times in UTC so they compare lexically in SQL.
+func formatSeen(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// tagsValue is the JSON form tags are stored and compared in; no tags is
+// "[]".
+func tagsValue(tags []string) string {
+	if len(tags) == 0 {
+		return "[]"
+	}
+	value, _ := json.Marshal(tags)
+	return string(value)
+}
+
+// Refresh records a repeat sighting of a stored bounty. It bumps last_seen,
+// applies changed source fields and status, and logs each difference to
+// bounty_history. A changed description also replaces its recorded links.
+// It returns the recorded changes.
+func (s *sqlStore) Refresh(bounty core.Bounty) ([]core.BountyChange, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var title, reward, currency, status, description, tags string
+	var expiresAt sql.NullString
+	err = tx.QueryRow(`SELECT COALESCE(title, ''), COALESCE(reward, ''), COALESCE(currency, ''), expires_at, COALESCE(status, 'open'),
+		COALESCE(description, ''), COALESCE(tags, '')
+		FROM bounties WHERE url = ?`, bounty.URL).Scan(&title, &reward, &currency, &expiresAt, &status, &description, &tags)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	newStatus := bounty.Status
+	if newStatus == "" {
+		newStatus = core.StatusOpen
+	}
+	if newStatus == core.StatusOpen && bounty.ExpiresAt != nil && bounty.ExpiresAt.Before(now) {
+		newStatus = core.StatusExpired
+	}
+	newExpires := ""
+	if bounty.ExpiresAt != nil {
+		newExpires = bounty.ExpiresAt.Format(time.RFC3339)
+	}
+
+	var changes []core.BountyChange
+	diff := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, core.BountyChange{
+				URL:       bounty.URL,
+				Field:     field,
+				OldValue:  oldValue,
+				NewValue:  newValue,
+				ChangedAt: now,
+			})
+		}
+	}
+	diff("title", title, bounty.Title)
+	diff("reward", reward, bounty.Reward)
+	diff("currency", currency, bounty.Currency)
+	diff("expires_at", expiresAt.String, newExpires)
+	diff("status", status, string(newStatus))
+	diff("description", description, bounty.Description)
+	if tags == "" || tags == "null" {
+		tags = "[]"
+	}
+	diff("tags", tags, tagsValue(bounty.Tags))
+
+	if len(changes) > 0 {
+		var expires interface{}
+		if newExpires != "" {
+			expires = newExpires
+		}
+		if _, err := tx.Exec(`UPDATE bounties SET title = ?, reward = ?, currency = ?, expires_at = ?, status = ?,
+			reward_min = ?, reward_max = ?, reward_token = ?, reward_exact = ?, reward_usd = ?,
+			description = ?, tags = ?
+			WHERE url = ?`,
+			bounty.Title, bounty.Reward, bounty.Currency, expires, string(newStatus),
+			bounty.RewardMin, bounty.RewardMax, bounty.RewardToken, bounty.RewardExact, bounty.RewardUSD,
+			bounty.Description, tagsValue(bounty.Tags),
+			bounty.URL); err != nil {
+			return nil, err
+		}
+		if err := insertHistory(tx, changes); err != nil {
+			return nil, err
+		}
+	}
+	if description != bounty.Description {
+		if err := saveLinks(tx, bounty.URL, bounty.Description); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE bounties SET last_seen = ? WHERE url = ?`, formatSeen(now), bounty.URL); err != nil {
+		return nil, err
+	}
+	return changes, tx.Commit()
+}
+
+// CloseMissing marks open bounties from platform that have not been seen
+// since cutoff as closed, returning how many were closed.
+func (s *sqlStore) CloseMissing(platform string, cutoff time.Time) (int, error) {
+	rows, err := s.db.Query(`SELECT url FROM bounties
+		WHERE status = 'open' AND platform = ? AND last_seen < ?`, platform, formatSeen(cutoff))
+	if err != nil {
+		return 0, err
+	}
+	urls, err := collectStrings(rows)
+	if err != nil {
+		return 0, err
+	}
+	return s.transition(urls, core.StatusOpen, core.StatusClosed)
+}
+
+// ExpireDue marks open bounties whose ExpiresAt is before now as expired,
+// returning how many changed.
+func (s *sqlStore) ExpireDue(now time.Time) (int, error) {
+	rows, err := s.db.Query(`SELECT url, expires_at FROM bounties
+		WHERE status = 'open' AND expires_at IS NOT NULL AND expires_at != ''`)
+	if err != nil {
+		return 0, err
+	}
+	var due []string
+	for rows.Next() {
+		var url, expires string
+		if err := rows.Scan(&url, &expires); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if t, err := parseTime(expires); err == nil && t.Before(now) {
+			due = append(due, url)
+		}
+	}
+	rows.Close()
+	return s.transition(due, core.StatusOpen, core.StatusExpired)
+}
+
+// GetHistory returns the recorded changes for the bounty with id, oldest first.
+func (s *sqlStore) GetHistory(id string) ([]core.BountyChange, error) {
+	rows, err := s.db.Query(`SELECT h.url, h.field, h.old_value, h.new_value, h.changed_at
+		FROM bounty_history h JOIN bounties b ON b.url = h.url
+		WHERE b.id = ?
+		ORDER BY h.id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []core.BountyChange{}
+	for rows.Next() {
+		var c core.BountyChange
+		var changedAt string
+		if err := rows.Scan(&c.URL, &c.Field, &c.OldValue, &c.NewValue, &changedAt); err != nil {
+			return nil, err
+		}
+		c.ChangedAt, _ = parseTime(changedAt)
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
+
+func (s *sqlStore) transition(urls []string, from, to core.BountyStatus) (int, error) {
+	if len(urls) == 0 {
+		return 0, nil
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	changes := make([]core.BountyChange, 0, len(urls))
+	for _, url := range urls {
+		if _, err := tx.Exec(`UPDATE bounties SET status = ? WHERE url = ?`, string(to), url); err != nil {
+			return 0, err
+		}
+		changes = append(changes, core.BountyChange{
+			URL:       url,
+			Field:     "status",
+			OldValue:  string(from),
+			NewValue:  string(to),
+			ChangedAt: now,
+		})
+	}
+	if err := insertHistory(tx, changes); err != nil {
+		return 0, err
+	}
+	return len(urls), tx.Commit()
+}
+
+func insertHistory(tx dbtx, changes []core.BountyChange) error {
+	for _, c := range changes {
+		if _, err := tx.Exec(`INSERT INTO bounty_history (url, field, old_value, new_value, changed_at)
+			VALUES (?, ?, ?, ?, ?)`, c.URL, c.Field, c.OldValue, c.NewValue, c.ChangedAt.Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func collectStrings(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+	var out []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		out = append(out, value)
+	}
+	return out, rows.Err()
+}
+
+// backfillLifecycle seeds sighting times for rows saved before lifecycle
+// tracking; last_seen starts now so existing rows get a full grace period.
+func backfillLifecycle(db dbtx) error {
+	if _, err := db.Exec(`UPDATE bounties SET first_seen = created_at WHERE first_seen IS NULL`); err != nil {
+		return err
+	}
+	_, err := db.Exec(`UPDATE bounties SET last_seen = ? WHERE last_seen IS NULL`, formatSeen(time.Now()))
+	return err
+}
@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+func TestStorage_RefreshRecordsChanges(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+
+		bounty := core.Bounty{
+			URL:       "https://example.com/bounty/life",
+			Title:     "Original",
+			Platform:  "TEST",
+			Reward:    "100",
+			Currency:  "USDC",
+			CreatedAt: time.Now(),
+		}
+		if err := store.Save(bounty); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		saved, err := store.GetByID(core.BountyID(bounty.URL))
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if saved.Status != core.StatusOpen || saved.FirstSeen.IsZero() || saved.LastSeen.IsZero() {
+			t.Fatalf("new bounty lifecycle = %s %v %v", saved.Status, saved.FirstSeen, saved.LastSeen)
+		}
+
+		// Unchanged sighting records nothing.
+		changes, err := store.Refresh(bounty)
+		if err != nil || len(changes) != 0 {
+			t.Fatalf("Refresh(unchanged) = %v, %v", changes, err)
+		}
+
+		bounty.Title = "Renamed"
+		bounty.Reward = "250"
+		bounty.Status = core.StatusClaimed
+		changes, err = store.Refresh(bounty)
+		if err != nil {
+			t.Fatalf("Refresh() error = %v", err)
+		}
+		fields := map[string]core.BountyChange{}
+		for _, c := range changes {
+			fields[c.Field] = c
+		}
+		if len(changes) != 3 || fields["title"].OldValue != "Original" || fields["reward"].NewValue != "250" || fields["status"].NewValue != "claimed" {
+			t.Fatalf("Refresh() changes = %+v", changes)
+		}
+
+		history, err := store.GetHistory(core.BountyID(bounty.URL))
+		if err != nil || len(history) != 3 {
+			t.Fatalf("GetHistory() = %+v, %v", history, err)
+		}
+
+		// Claimed listings drop out of the recent feed.
+		recent, err := store.GetRecent(10)
+		if err != nil || len(recent) != 0 {
+			t.Errorf("GetRecent() = %d bounties, %v; want none", len(recent), err)
+		}
+
+		if _, err := store.Refresh(core.Bounty{URL: "https://example.com/unknown"}); err != ErrNotFound {
+			t.Errorf("Refresh(unknown) error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestStorage_RefreshUpdatesDescriptionAndLinks(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+		post := core.Bounty{URL: "https://bountycaster.xyz/bounty/3", Platform: "BOUNTYCASTER", Title: "Indexer",
+			Description: "Details soon", CreatedAt: time.Now()}
+		if err := store.Save(post); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		post.Description = "Details: https://github.com/org/repo/issues/3"
+		post.Tags = []string{"rust"}
+		changes, err := store.Refresh(post)
+		if err != nil || len(changes) != 2 {
+			t.Fatalf("Refresh() = %+v, %v; want description and tags changes", changes, err)
+		}
+		saved, err := store.GetByID(core.BountyID(post.URL))
+		if err != nil || saved.Description != post.Description || len(saved.Tags) != 1 {
+			t.Fatalf("GetByID() = %+v, %v", saved, err)
+		}
+
+		// Link dedup sees the refreshed description.
+		issue := core.Bounty{URL: "https://github.com/org/repo/issues/3", Platform: "GITHUB/BOUNTY", Title: "z"}
+		if found, match, err := store.FindDuplicate(issue, core.DedupOptions{}); err != nil || match != core.MatchLink || found.URL != post.URL {
+			t.Errorf("FindDuplicate() = %s, %q, %v; want link match", found.URL, match, err)
+		}
+		if changes, err := store.Refresh(post); err != nil || len(changes) != 0 {
+			t.Errorf("Refresh(unchanged) = %+v, %v", changes, err)
+		}
+	})
+}
+
+func TestStorage_CloseMissingAndExpire(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+		now := time.Now()
+		past := now.Add(-time.Hour)
+
+		for _, b := range []core.Bounty{
+			{URL: "https://example.com/stale", Platform: "TEST", LastSeen: now.Add(-48 * time.Hour)},
+			{URL: "https://example.com/fresh", Platform: "TEST", LastSeen: now},
+			{URL: "https://example.com/other", Platform: "OTHER", LastSeen: now.Add(-48 * time.Hour)},
+			{URL: "https://example.com/due", Platform: "OTHER", LastSeen: now, ExpiresAt: &past},
+		} {
+			b.Title = b.URL
+			b.CreatedAt = now
+			if err := store.Save(b); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		closed, err := store.CloseMissing("TEST", now.Add(-24*time.Hour))
+		if err != nil || closed != 1 {
+			t.Fatalf("CloseMissing() = %d, %v; want 1", closed, err)
+		}
+		expired, err := store.ExpireDue(now)
+		if err != nil || expired != 1 {
+			t.Fatalf("ExpireDue() = %d, %v; want 1", expired, err)
+		}
+
+		recent, err := store.GetRecent(10)
+		if err != nil {
+			t.Fatalf("GetRecent() error = %v", err)
+		}
+		open := map[string]bool{}
+		for _, b := range recent {
+			open[b.URL] = true
+		}
+		if len(open) != 2 || !open["https://example.com/fresh"] || !open["https://example.com/other"] {
+			t.Errorf("open bounties = %v, want fresh and other", open)
+		}
+
+		stale, err := store.GetByID(core.BountyID("https://example.com/stale"))
+		if err != nil || stale.Status != core.StatusClosed {
+			t.Errorf("stale status = %s, %v; want closed", stale.Status, err)
+		}
+		history, _ := store.GetHistory(core.BountyID("https://example.com/due"))
+		if len(history) != 1 || history[0].NewValue != "expired" {
+			t.Errorf("due history = %+v", history)
+		}
+
+		// A closed listing seen again is reopened.
+		stale.Status = core.StatusOpen
+		changes, err := store.Refresh(stale)
+		if err != nil || len(changes) != 1 || changes[0].NewValue != "open" {
+			t.Errorf("Refresh(reappeared) = %+v, %v", changes, err)
+		}
+	})
+}
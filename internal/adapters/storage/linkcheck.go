@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// LinkCheck returns the latest recorded reachability of url. found is false
+// when url was never checked or was last checked before since.
+func (s *sqlStore) LinkCheck(url string, since time.Time) (reachable, found bool, err error) {
+	var checkedAt string
+	err = s.db.QueryRow(`SELECT reachable, checked_at FROM link_checks WHERE url = ?`, url).Scan(&reachable, &checkedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	at, err := parseTime(checkedAt)
+	if err != nil || at.Before(since) {
+		return false, false, nil
+	}
+	return reachable, true, nil
+}
+
+// SaveLinkCheck records the reachability of url as of checkedAt.
+func (s *sqlStore) SaveLinkCheck(url string, reachable bool, checkedAt time.Time) error {
+	_, err := s.db.Exec(`INSERT INTO link_checks (url, reachable, checked_at) VALUES (?, ?, ?)
+		ON CONFLICT(url) DO UPDATE SET reachable = excluded.reachable, checked_at = excluded.checked_at`,
+		url, reachable, formatSeen(checkedAt))
+	return err
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStorage_LinkCheck(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+		url := "https://example.com/a"
+		if _, found, err := store.LinkCheck(url, time.Time{}); err != nil || found {
+			t.Fatalf("LinkCheck() before saving = %v, %v; want not found", found, err)
+		}
+
+		checkedAt := time.Now().Add(-2 * time.Hour)
+		if err := store.SaveLinkCheck(url, true, checkedAt); err != nil {
+			t.Fatalf("SaveLinkCheck() error = %v", err)
+		}
+		if reachable, found, err := store.LinkCheck(url, checkedAt.Add(-time.Hour)); err != nil || !found || !reachable {
+			t.Errorf("LinkCheck() = %v, %v, %v; want a reachable result", reachable, found, err)
+		}
+		if _, found, err := store.LinkCheck(url, time.Now().Add(-time.Hour)); err != nil || found {
+			t.Errorf("LinkCheck() of a stale result = %v, %v; want not found", found, err)
+		}
+
+		if err := store.SaveLinkCheck(url, false, time.Now()); err != nil {
+			t.Fatalf("SaveLinkCheck() error = %v", err)
+		}
+		if reachable, found, err := store.LinkCheck(url, time.Now().Add(-time.Hour)); err != nil || !found || reachable {
+			t.Errorf("LinkCheck() after update = %v, %v, %v; want unreachable", reachable, found, err)
+		}
+	})
+}
@@ -0,0 +1,1159 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"bountyos-v8/internal/core"
+	"bountyos-v8/internal/security"
+)
+
+// MemoryStorage keeps everything in process memory and loses it on exit.
+// It behaves like SQLiteStorage, down to storing timestamps at second
+// precision, and is safe for concurrent use. Search matches substrings,
+// like SQLite built without FTS5.
+type MemoryStorage struct {
+	mu         sync.RWMutex
+	bounties   map[string]core.Bounty // by URL
+	history    []core.BountyChange
+	sources    map[string][]core.BountySource // by bounty URL
+	rejections map[string]core.Rejection      // by URL
+	tracking   map[string]core.Tracking       // by URL, without bounty fields
+	ledger     []core.LedgerEntry             // without bounty or tracking fields
+	ledgerID   int64
+	audit      []core.AuditEntry
+	linkChecks map[string]linkCheck // by URL
+	scanState  map[[2]string]string // by scanner and key
+}
+
+type linkCheck struct {
+	reachable bool
+	checkedAt time.Time
+}
+
+// NewMemoryStorage returns an empty MemoryStorage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		bounties:   make(map[string]core.Bounty),
+		sources:    make(map[string][]core.BountySource),
+		rejections: make(map[string]core.Rejection),
+		tracking:   make(map[string]core.Tracking),
+		linkChecks: make(map[string]linkCheck),
+		scanState:  make(map[[2]string]string),
+	}
+}
+
+// storedTime drops what an RFC 3339 column would: sub-second precision.
+func storedTime(t time.Time) time.Time {
+	parsed, _ := parseTime(t.Format(time.RFC3339))
+	return parsed
+}
+
+// storedSeen is storedTime for columns written with formatSeen, in UTC.
+func storedSeen(t time.Time) time.Time {
+	parsed, _ := parseTime(formatSeen(t))
+	return parsed
+}
+
+func storedSeenPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	stored := storedSeen(*t)
+	return &stored
+}
+
+func copyBounty(b core.Bounty) core.Bounty {
+	b.Tags = slices.Clone(b.Tags)
+	b.ScoreBreakdown = slices.Clone(b.ScoreBreakdown)
+	if b.ExpiresAt != nil {
+		expires := *b.ExpiresAt
+		b.ExpiresAt = &expires
+	}
+	return b
+}
+
+func copyTracking(t core.Tracking) core.Tracking {
+	for _, field := range []**time.Time{&t.WatchedAt, &t.ClaimedAt, &t.StartedAt, &t.SubmittedAt, &t.PaidAt} {
+		if *field != nil {
+			stamp := **field
+			*field = &stamp
+		}
+	}
+	return t
+}
+
+func limitBounties(bounties []core.Bounty, limit int) []core.Bounty {
+	if limit >= 0 && len(bounties) > limit {
+		return bounties[:limit]
+	}
+	return bounties
+}
+
+// filtered returns copies of the stored bounties keep accepts, by URL.
+// Callers hold m.mu.
+func (m *MemoryStorage) filtered(keep func(b core.Bounty) bool) []core.Bounty {
+	var out []core.Bounty
+	for _, b := range m.bounties {
+		if keep(b) {
+			out = append(out, copyBounty(b))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].URL < out[j].URL })
+	return out
+}
+
+func isOpen(b core.Bounty) bool {
+	return b.Status == core.StatusOpen
+}
+
+func (m *MemoryStorage) Save(bounty core.Bounty) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored := copyBounty(bounty)
+	stored.ID = core.BountyID(bounty.URL)
+	stored.SourceID = ""
+	if stored.Status == "" {
+		stored.Status = core.StatusOpen
+	}
+	now := time.Now()
+	if stored.FirstSeen.IsZero() {
+		stored.FirstSeen = now
+	}
+	if stored.LastSeen.IsZero() {
+		stored.LastSeen = now
+	}
+	// A re-saved bounty keeps its first_seen.
+	if existing, ok := m.bounties[bounty.URL]; ok {
+		stored.FirstSeen = existing.FirstSeen
+	}
+	stored.CreatedAt = storedTime(stored.CreatedAt)
+	if stored.ExpiresAt != nil {
+		expires := storedTime(*stored.ExpiresAt)
+		stored.ExpiresAt = &expires
+	}
+	stored.FirstSeen = storedSeen(stored.FirstSeen)
+	stored.LastSeen = storedSeen(stored.LastSeen)
+	m.bounties[bounty.URL] = stored
+	return nil
+}
+
+func (m *MemoryStorage) IsNew(url string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.bounties[url]
+	return !ok, nil
+}
+
+func (m *MemoryStorage) GetRecent(limit int) ([]core.Bounty, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	recent := m.filtered(isOpen)
+	sort.SliceStable(recent, func(i, j int) bool { return recent[i].CreatedAt.After(recent[j].CreatedAt) })
+	return limitBounties(recent, limit), nil
+}
+
+func (m *MemoryStorage) Close() error {
+	return nil
+}
+
+// GetByID returns the bounty stored under id, or ErrNotFound.
+func (m *MemoryStorage) GetByID(id string) (core.Bounty, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	url, err := m.urlForID(id)
+	if err != nil {
+		return core.Bounty{}, err
+	}
+	return copyBounty(m.bounties[url]), nil
+}
+
+// urlForID returns the URL of the bounty with id. Callers hold m.mu.
+func (m *MemoryStorage) urlForID(id string) (string, error) {
+	for url, b := range m.bounties {
+		if b.ID == id {
+			return url, nil
+		}
+	}
+	return "", ErrNotFound
+}
+
+// GetByReward returns open bounties whose parsed maximum reward lies within
+// [minAmount, maxAmount], highest first. A maxAmount of 0 means no upper
+// bound; a non-empty token restricts results to that symbol.
+func (m *MemoryStorage) GetByReward(minAmount, maxAmount float64, token string, limit int) ([]core.Bounty, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	token = strings.ToUpper(strings.TrimSpace(token))
+	matches := m.filtered(func(b core.Bounty) bool {
+		return isOpen(b) && b.RewardMax >= minAmount &&
+			(maxAmount == 0 || b.RewardMax <= maxAmount) &&
+			(token == "" || b.RewardToken == token)
+	})
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].RewardMax != matches[j].RewardMax {
+			return matches[i].RewardMax > matches[j].RewardMax
+		}
+		return matches[i].CreatedAt.After(matches[j].CreatedAt)
+	})
+	return limitBounties(matches, limit), nil
+}
+
+// Rescore recomputes every open bounty's score with score and writes back
+// those that changed. It returns the number of updated bounties.
+func (m *MemoryStorage) Rescore(score func(*core.Bounty) core.ScoreResult) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	updated := 0
+	for _, bounty := range m.filtered(isOpen) {
+		result := score(&bounty)
+		stored := m.bounties[bounty.URL]
+		if result.Total == stored.Score && sameBreakdown(result.Breakdown, stored.ScoreBreakdown) {
+			continue
+		}
+		stored.Score = result.Total
+		stored.ScoreBreakdown = slices.Clone(result.Breakdown)
+		m.bounties[bounty.URL] = stored
+		updated++
+	}
+	return updated, nil
+}
+
+// PurgeInvalidURLs normalizes stored URLs and deletes bounties whose URL is
+// invalid or, when links is non-nil, that links reports unreachable.
+func (m *MemoryStorage) PurgeInvalidURLs(ctx context.Context, links *security.LinkValidator) (int, error) {
+	m.mu.RLock()
+	urls := make([]string, 0, len(m.bounties))
+	for url := range m.bounties {
+		urls = append(urls, url)
+	}
+	m.mu.RUnlock()
+	sort.Strings(urls)
+
+	removed := 0
+	remove := func(url string) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.deleteLocked(url) {
+			removed++
+		}
+	}
+	valid := make([]string, 0, len(urls))
+	for _, url := range urls {
+		normalized := security.NormalizeURL(url)
+		if normalized == "" {
+			remove(url)
+			continue
+		}
+		if normalized != url && m.renameURL(url, normalized) {
+			url = normalized
+		}
+		if !security.ValidateURL(url) {
+			remove(url)
+			continue
+		}
+		valid = append(valid, url)
+	}
+	if links != nil {
+		for _, url := range links.Unreachable(ctx, valid) {
+			remove(url)
+		}
+	}
+	return removed, nil
+}
+
+// Delete removes the bounty stored under url with its history and sources.
+// It reports false when there is none.
+func (m *MemoryStorage) Delete(url string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.deleteLocked(url), nil
+}
+
+// deleteLocked is Delete for callers holding m.mu.
+func (m *MemoryStorage) deleteLocked(url string) bool {
+	if _, ok := m.bounties[url]; !ok {
+		return false
+	}
+	delete(m.bounties, url)
+	delete(m.sources, url)
+	m.history = slices.DeleteFunc(m.history, func(c core.BountyChange) bool { return c.URL == url })
+	return true
+}
+
+// renameURL moves the bounty at from, and everything recorded against it,
+// to the URL to. It fails when to is already stored.
+func (m *MemoryStorage) renameURL(from, to string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.bounties[from]
+	if _, taken := m.bounties[to]; !ok || taken {
+		return false
+	}
+	delete(m.bounties, from)
+	b.URL, b.ID = to, core.BountyID(to)
+	m.bounties[to] = b
+
+	if sources, ok := m.sources[from]; ok {
+		delete(m.sources, from)
+		m.sources[to] = append(m.sources[to], sources...)
+	}
+	if tracking, ok := m.tracking[from]; ok {
+		delete(m.tracking, from)
+		tracking.URL = to
+		m.tracking[to] = tracking
+	}
+	for i := range m.ledger {
+		if m.ledger[i].URL == from {
+			m.ledger[i].URL = to
+		}
+	}
+	for i := range m.history {
+		if m.history[i].URL == from {
+			m.history[i].URL = to
+		}
+	}
+	return true
+}
+
+// Query returns one page of bounties matching q, using keyset pagination so
+// pages stay stable while new bounties arrive.
+func (m *MemoryStorage) Query(q core.BountyQuery) (core.BountyPage, error) {
+	page := core.BountyPage{Bounties: []core.Bounty{}}
+	if q.Sort == "" {
+		q.Sort = core.SortScore
+	}
+	if q.Limit <= 0 {
+		q.Limit = 50
+	}
+	var after *pageCursor
+	if q.Cursor != "" {
+		c, err := decodeCursor(q.Cursor, q.Sort, q.Ascending)
+		if err != nil {
+			return page, err
+		}
+		after = &c
+	}
+
+	m.mu.RLock()
+	matches := m.filtered(func(b core.Bounty) bool { return matchesQuery(b, q) })
+	m.mu.RUnlock()
+
+	// compare orders b against the position (value, url) in page order.
+	compare := func(b core.Bounty, value float64, url string) int {
+		c := cmp.Compare(sortValue(b, q.Sort, q.Ascending), value)
+		if c == 0 {
+			c = strings.Compare(b.URL, url)
+		}
+		if !q.Ascending {
+			c = -c
+		}
+		return c
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return compare(matches[i], sortValue(matches[j], q.Sort, q.Ascending), matches[j].URL) < 0
+	})
+	if after != nil {
+		start := sort.Search(len(matches), func(i int) bool {
+			return compare(matches[i], after.Value, after.URL) > 0
+		})
+		matches = matches[start:]
+	}
+
+	if len(matches) <= q.Limit {
+		if matches != nil {
+			page.Bounties = matches
+		}
+		return page, nil
+	}
+	page.Bounties = matches[:q.Limit]
+	last := page.Bounties[q.Limit-1]
+	page.NextCursor = encodeCursor(pageCursor{
+		Sort:      q.Sort,
+		Ascending: q.Ascending,
+		Value:     sortValue(last, q.Sort, q.Ascending),
+		URL:       last.URL,
+	})
+	return page, nil
+}
+
+// sortValue is the in-memory counterpart of sortExpr, with timestamps as
+// Unix seconds.
+func sortValue(b core.Bounty, sort core.BountySort, ascending bool) float64 {
+	switch sort {
+	case core.SortCreatedAt:
+		return float64(b.CreatedAt.Unix())
+	case core.SortRewardUSD:
+		return b.RewardUSD
+	case core.SortExpiresAt:
+		switch {
+		case b.ExpiresAt != nil:
+			return float64(b.ExpiresAt.Unix())
+		case ascending:
+			return noDeadline
+		default:
+			return -noDeadline
+		}
+	default:
+		return float64(b.Score)
+	}
+}
+
+// Stats aggregates every bounty matching q's filters; sorting and paging
+// are ignored.
+func (m *MemoryStorage) Stats(q core.BountyQuery) (core.BountyStats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stats := core.BountyStats{ByPlatform: make(map[string]int)}
+	total := 0
+	for _, b := range m.bounties {
+		if !matchesQuery(b, q) {
+			continue
+		}
+		stats.TotalCount++
+		total += b.Score
+		stats.ByPlatform[b.Platform]++
+		if b.PaymentType == "crypto" {
+			stats.CryptoCount++
+		}
+	}
+	if stats.TotalCount > 0 {
+		stats.AvgScore = float64(total) / float64(stats.TotalCount)
+	}
+	return stats, nil
+}
+
+// matchesQuery is the in-memory counterpart of bountyQueryClause.
+func matchesQuery(b core.Bounty, q core.BountyQuery) bool {
+	statuses := q.Statuses
+	if len(statuses) == 0 {
+		statuses = []core.BountyStatus{core.StatusOpen}
+	}
+	if !slices.Contains(statuses, b.Status) {
+		return false
+	}
+	if q.Platform != "" && !platformMatches(b.Platform, q.Platform) {
+		return false
+	}
+	if q.Currency != "" {
+		currency := strings.ToUpper(strings.TrimSpace(q.Currency))
+		if strings.ToUpper(b.Currency) != currency && b.RewardToken != currency {
+			return false
+		}
+	}
+	if q.PaymentType != "" && b.PaymentType != strings.ToLower(strings.TrimSpace(q.PaymentType)) {
+		return false
+	}
+	if q.MinScore != nil && b.Score < *q.MinScore {
+		return false
+	}
+	if q.Tag != "" {
+		tag := strings.ToLower(strings.TrimSpace(q.Tag))
+		if !slices.ContainsFunc(b.Tags, func(t string) bool { return strings.ToLower(t) == tag }) {
+			return false
+		}
+	}
+	if !q.CreatedAfter.IsZero() && b.CreatedAt.Before(storedSeen(q.CreatedAfter)) {
+		return false
+	}
+	if !q.ExpiresBefore.IsZero() && (b.ExpiresAt == nil || !b.ExpiresAt.Before(storedSeen(q.ExpiresBefore))) {
+		return false
+	}
+	return true
+}
+
+// platformMatches is the in-memory counterpart of platformClause.
+func platformMatches(stored, platform string) bool {
+	platform = strings.ToUpper(strings.TrimSpace(platform))
+	return stored == platform || strings.HasPrefix(stored, platform+"/")
+}
+
+// Search matches query as substrings of title, description and tags, like
+// SQLite without FTS5: terms are ANDed unless joined by OR, NOT or "-term"
+// excludes, and "phrases" match as a whole. Matches are ordered by score;
+// an empty query lists every bounty passing the filters, newest first.
+func (m *MemoryStorage) Search(query string, filters core.SearchFilters) (core.SearchResult, error) {
+	if filters.Limit <= 0 {
+		filters.Limit = 20
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+	result := core.SearchResult{Limit: filters.Limit, Offset: filters.Offset, Bounties: []core.Bounty{}}
+
+	var groups [][]searchCondition
+	if query = strings.TrimSpace(query); query != "" {
+		var err error
+		if groups, err = parseSearchGroups(query); err != nil {
+			return result, err
+		}
+	}
+
+	m.mu.RLock()
+	matches := m.filtered(func(b core.Bounty) bool {
+		return matchesSearchFilters(b, filters) && (groups == nil || matchesSearch(b, groups))
+	})
+	m.mu.RUnlock()
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if groups != nil && matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].CreatedAt.After(matches[j].CreatedAt)
+	})
+	result.Total = len(matches)
+	if filters.Offset < len(matches) {
+		result.Bounties = limitBounties(matches[filters.Offset:], filters.Limit)
+	}
+	return result, nil
+}
+
+// matchesSearch reports whether b satisfies the OR of AND groups, matching
+// case-insensitively like SQLite's LIKE.
+func matchesSearch(b core.Bounty, groups [][]searchCondition) bool {
+	tags, _ := json.Marshal(b.Tags)
+	haystack := strings.ToLower(b.Title + " " + b.Description + " " + string(tags))
+	for _, group := range groups {
+		all := true
+		for _, cond := range group {
+			if strings.Contains(haystack, strings.ToLower(cond.text)) == cond.negate {
+				all = false
+				break
+			}
+		}
+		if all {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesSearchFilters is the in-memory counterpart of searchFilterClause.
+func matchesSearchFilters(b core.Bounty, f core.SearchFilters) bool {
+	switch {
+	case f.Platform != "" && !platformMatches(b.Platform, f.Platform),
+		f.PaymentType != "" && b.PaymentType != strings.ToLower(strings.TrimSpace(f.PaymentType)),
+		f.Status != "" && b.Status != f.Status,
+		f.MinScore != nil && b.Score < *f.MinScore,
+		f.MaxScore != nil && b.Score > *f.MaxScore,
+		f.MinUSD > 0 && b.RewardUSD < f.MinUSD,
+		f.MaxUSD > 0 && b.RewardUSD > f.MaxUSD,
+		!f.CreatedAfter.IsZero() && b.CreatedAt.Before(storedSeen(f.CreatedAfter)),
+		!f.CreatedBefore.IsZero() && !b.CreatedAt.Before(storedSeen(f.CreatedBefore)):
+		return false
+	}
+	return true
+}
+
+// Refresh records a repeat sighting of a stored bounty. It bumps LastSeen,
+// applies changed source fields and status, and logs each difference to the
+// bounty's history. It returns the recorded changes.
+func (m *MemoryStorage) Refresh(bounty core.Bounty) ([]core.BountyChange, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.bounties[bounty.URL]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	now := time.Now()
+	newStatus := bounty.Status
+	if newStatus == "" {
+		newStatus = core.StatusOpen
+	}
+	if newStatus == core.StatusOpen && bounty.ExpiresAt != nil && bounty.ExpiresAt.Before(now) {
+		newStatus = core.StatusExpired
+	}
+	oldExpires, newExpires := "", ""
+	if stored.ExpiresAt != nil {
+		oldExpires = stored.ExpiresAt.Format(time.RFC3339)
+	}
+	if bounty.ExpiresAt != nil {
+		newExpires = bounty.ExpiresAt.Format(time.RFC3339)
+	}
+
+	var changes []core.BountyChange
+	diff := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, core.BountyChange{
+				URL:       bounty.URL,
+				Field:     field,
+				OldValue:  oldValue,
+				NewValue:  newValue,
+				ChangedAt: now,
+			})
+		}
+	}
+	diff("title", stored.Title, bounty.Title)
+	diff("reward", stored.Reward, bounty.Reward)
+	diff("currency", stored.Currency, bounty.Currency)
+	diff("expires_at", oldExpires, newExpires)
+	diff("status", string(stored.Status), string(newStatus))
+	diff("description", stored.Description, bounty.Description)
+	diff("tags", tagsValue(stored.Tags), tagsValue(bounty.Tags))
+
+	if len(changes) > 0 {
+		stored.Title, stored.Reward, stored.Currency, stored.Status = bounty.Title, bounty.Reward, bounty.Currency, newStatus
+		stored.Description, stored.Tags = bounty.Description, slices.Clone(bounty.Tags)
+		stored.ExpiresAt = nil
+		if bounty.ExpiresAt != nil {
+			expires := storedTime(*bounty.ExpiresAt)
+			stored.ExpiresAt = &expires
+		}
+		stored.RewardMin, stored.RewardMax, stored.RewardToken = bounty.RewardMin, bounty.RewardMax, bounty.RewardToken
+		stored.RewardExact, stored.RewardUSD = bounty.RewardExact, bounty.RewardUSD
+		m.recordHistory(changes)
+	}
+	stored.LastSeen = storedSeen(now)
+	m.bounties[bounty.URL] = stored
+	return changes, nil
+}
+
+// recordHistory appends changes to the history. Callers hold m.mu.
+func (m *MemoryStorage) recordHistory(changes []core.BountyChange) {
+	for _, c := range changes {
+		c.ChangedAt = storedTime(c.ChangedAt)
+		m.history = append(m.history, c)
+	}
+}
+
+// CloseMissing marks open bounties from platform that have not been seen
+// since cutoff as closed, returning how many were closed.
+func (m *MemoryStorage) CloseMissing(platform string, cutoff time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cutoff = storedSeen(cutoff)
+	return m.transition(func(b core.Bounty) bool {
+		return b.Platform == platform && b.LastSeen.Before(cutoff)
+	}, core.StatusClosed), nil
+}
+
+// ExpireDue marks open bounties whose ExpiresAt is before now as expired,
+// returning how many changed.
+func (m *MemoryStorage) ExpireDue(now time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.transition(func(b core.Bounty) bool {
+		return b.ExpiresAt != nil && b.ExpiresAt.Before(now)
+	}, core.StatusExpired), nil
+}
+
+// transition moves the open bounties due accepts to status to, recording
+// each change. Callers hold m.mu.
+func (m *MemoryStorage) transition(due func(b core.Bounty) bool, to core.BountyStatus) int {
+	now := time.Now()
+	var changes []core.BountyChange
+	for _, b := range m.filtered(func(b core.Bounty) bool { return isOpen(b) && due(b) }) {
+		stored := m.bounties[b.URL]
+		stored.Status = to
+		m.bounties[b.URL] = stored
+		changes = append(changes, core.BountyChange{
+			URL:       b.URL,
+			Field:     "status",
+			OldValue:  string(core.StatusOpen),
+			NewValue:  string(to),
+			ChangedAt: now,
+		})
+	}
+	m.recordHistory(changes)
+	return len(changes)
+}
+
+// GetHistory returns the recorded changes for the bounty with id, oldest first.
+func (m *MemoryStorage) GetHistory(id string) ([]core.BountyChange, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	changes := []core.BountyChange{}
+	url, err := m.urlForID(id)
+	if err != nil {
+		return changes, nil
+	}
+	for _, c := range m.history {
+		if c.URL == url {
+			changes = append(changes, c)
+		}
+	}
+	return changes, nil
+}
+
+// FindDuplicate returns the stored bounty that b is another sighting of and
+// how it matched, by the same rules as the SQL backends. The match kind is
+// empty when b is new.
+func (m *MemoryStorage) FindDuplicate(b core.Bounty, opts core.DedupOptions) (core.Bounty, string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// Candidates in the order the SQL backends consider them.
+	stored := m.filtered(func(core.Bounty) bool { return true })
+	sort.SliceStable(stored, func(i, j int) bool { return stored[i].FirstSeen.Before(stored[j].FirstSeen) })
+	first := func(match func(core.Bounty) bool) (core.Bounty, bool) {
+		for _, candidate := range stored {
+			if match(candidate) {
+				return candidate, true
+			}
+		}
+		return core.Bounty{}, false
+	}
+
+	canonical := core.CanonicalURL(b.URL)
+	if found, ok := first(func(c core.Bounty) bool { return c.URL == b.URL || core.CanonicalURL(c.URL) == canonical }); ok {
+		return found, core.MatchURL, nil
+	}
+
+	// b links to a stored listing from another platform.
+	for _, link := range core.ExtractLinks(b.Description) {
+		if link == canonical {
+			continue
+		}
+		found, ok := first(func(c core.Bounty) bool { return core.CanonicalURL(c.URL) == link })
+		if ok && !sameSource(found, b) {
+			return found, core.MatchLink, nil
+		}
+	}
+	// A stored listing from another platform links to b.
+	if found, ok := first(func(c core.Bounty) bool {
+		if sameSource(c, b) || !(strings.Contains(c.Description, b.URL) || strings.Contains(c.Description, canonical)) {
+			return false
+		}
+		return slices.Contains(core.ExtractLinks(c.Description), canonical)
+	}); ok {
+		return found, core.MatchLink, nil
+	}
+
+	if opts.TitleSimilarity <= 0 {
+		return core.Bounty{}, "", nil
+	}
+	created := b.CreatedAt
+	if created.IsZero() {
+		created = time.Now()
+	}
+	var best core.Bounty
+	bestScore := 0.0
+	for _, candidate := range stored {
+		if !isOpen(candidate) || sameSource(candidate, b) {
+			continue
+		}
+		if opts.Window > 0 && absDuration(created.Sub(candidate.CreatedAt)) > opts.Window {
+			continue
+		}
+		if score := core.TitleSimilarity(b.Title, candidate.Title); score >= opts.TitleSimilarity && score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+	if bestScore == 0 {
+		return core.Bounty{}, "", nil
+	}
+	return best, core.MatchTitle, nil
+}
+
+// RecordSource notes that the stored bounty at bountyURL was seen as src,
+// keeping the first sighting time and match kind of a known source.
+func (m *MemoryStorage) RecordSource(bountyURL string, src core.BountySource) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := storedSeen(time.Now())
+	sources := m.sources[bountyURL]
+	for i, known := range sources {
+		if known.Platform == src.Platform && known.Label == src.Label && known.URL == src.URL {
+			if src.SourceID != "" {
+				sources[i].SourceID = src.SourceID
+			}
+			sources[i].LastSeen = now
+			return nil
+		}
+	}
+	if src.Match == "" {
+		src.Match = core.MatchURL
+	}
+	src.FirstSeen, src.LastSeen = now, now
+	m.sources[bountyURL] = append(sources, src)
+	return nil
+}
+
+// GetSources returns every source recorded for the bounty with id, first
+// seen first.
+func (m *MemoryStorage) GetSources(id string) ([]core.BountySource, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sources := []core.BountySource{}
+	url, err := m.urlForID(id)
+	if err != nil {
+		return sources, nil
+	}
+	sources = append(sources, m.sources[url]...)
+	sort.SliceStable(sources, func(i, j int) bool {
+		a, b := sources[i], sources[j]
+		if !a.FirstSeen.Equal(b.FirstSeen) {
+			return a.FirstSeen.Before(b.FirstSeen)
+		}
+		if a.Platform != b.Platform {
+			return a.Platform < b.Platform
+		}
+		return a.Label < b.Label
+	})
+	return sources, nil
+}
+
+// SaveRejection records why a bounty was filtered out, replacing any earlier
+// rejection of the same URL.
+func (m *MemoryStorage) SaveRejection(r core.Rejection) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r.RejectedAt = storedTime(r.RejectedAt)
+	m.rejections[r.URL] = r
+	return nil
+}
+
+// GetRejections returns the most recent rejections, newest first.
+func (m *MemoryStorage) GetRejections(limit int) ([]core.Rejection, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var rejections []core.Rejection
+	for _, r := range m.rejections {
+		rejections = append(rejections, r)
+	}
+	sort.Slice(rejections, func(i, j int) bool {
+		if !rejections[i].RejectedAt.Equal(rejections[j].RejectedAt) {
+			return rejections[i].RejectedAt.After(rejections[j].RejectedAt)
+		}
+		return rejections[i].URL < rejections[j].URL
+	})
+	if limit >= 0 && len(rejections) > limit {
+		rejections = rejections[:limit]
+	}
+	return rejections, nil
+}
+
+// GetTracking returns the pipeline record of the bounty with id. It returns
+// ErrNotFound for unknown bounties and ErrNotTracked for untracked ones.
+func (m *MemoryStorage) GetTracking(id string) (core.Tracking, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	url, err := m.urlForID(id)
+	if err != nil {
+		return core.Tracking{}, err
+	}
+	return m.getTracking(url)
+}
+
+// getTracking returns the record for url with its bounty's fields. Callers
+// hold m.mu.
+func (m *MemoryStorage) getTracking(url string) (core.Tracking, error) {
+	tracking, ok := m.tracking[url]
+	b, stored := m.bounties[url]
+	if !ok || !stored {
+		return core.Tracking{}, ErrNotTracked
+	}
+	tracking = copyTracking(tracking)
+	tracking.BountyID, tracking.Title, tracking.Platform = b.ID, b.Title, b.Platform
+	return tracking, nil
+}
+
+// saveTracking stores tracking for url at column precision. Callers hold
+// m.mu.
+func (m *MemoryStorage) saveTracking(url string, tracking core.Tracking) {
+	tracking.BountyID, tracking.Title, tracking.Platform = "", "", ""
+	tracking.URL = url
+	tracking.CreatedAt = storedSeen(tracking.CreatedAt)
+	tracking.UpdatedAt = storedSeen(tracking.UpdatedAt)
+	tracking.WatchedAt = storedSeenPtr(tracking.WatchedAt)
+	tracking.ClaimedAt = storedSeenPtr(tracking.ClaimedAt)
+	tracking.StartedAt = storedSeenPtr(tracking.StartedAt)
+	tracking.SubmittedAt = storedSeenPtr(tracking.SubmittedAt)
+	tracking.PaidAt = storedSeenPtr(tracking.PaidAt)
+	m.tracking[url] = tracking
+}
+
+// UpdateTracking applies update to the pipeline record of the bounty with
+// id, creating it if needed, and returns the result.
+func (m *MemoryStorage) UpdateTracking(id string, update core.TrackingUpdate) (core.Tracking, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	url, err := m.urlForID(id)
+	if err != nil {
+		return core.Tracking{}, err
+	}
+	tracking, err := m.getTracking(url)
+	if err != nil && !errors.Is(err, ErrNotTracked) {
+		return core.Tracking{}, err
+	}
+	tracking.Apply(update, time.Now())
+	m.saveTracking(url, tracking)
+	return m.getTracking(url)
+}
+
+// DeleteTracking stops tracking the bounty with id.
+func (m *MemoryStorage) DeleteTracking(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	url, err := m.urlForID(id)
+	if err != nil {
+		return err
+	}
+	delete(m.tracking, url)
+	return nil
+}
+
+// ListTracking returns pipeline records in state, or all of them when state
+// is empty, most recently updated first.
+func (m *MemoryStorage) ListTracking(state core.PipelineState) ([]core.Tracking, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.listTracking(func(t core.Tracking) bool { return state == "" || t.State == state }), nil
+}
+
+// listTracking returns the records keep accepts, most recently updated
+// first. Callers hold m.mu.
+func (m *MemoryStorage) listTracking(keep func(t core.Tracking) bool) []core.Tracking {
+	list := []core.Tracking{}
+	for url := range m.tracking {
+		tracking, err := m.getTracking(url)
+		if err == nil && keep(tracking) {
+			list = append(list, tracking)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].UpdatedAt.Equal(list[j].UpdatedAt) {
+			return list[i].UpdatedAt.After(list[j].UpdatedAt)
+		}
+		return list[i].URL < list[j].URL
+	})
+	return list
+}
+
+// RecordPayment adds a received payment for the bounty with id to the
+// ledger and moves its pipeline record to paid, creating it if needed.
+// The stored entry is returned with its ledger ID.
+func (m *MemoryStorage) RecordPayment(id string, entry core.LedgerEntry) (core.LedgerEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	url, err := m.urlForID(id)
+	if err != nil {
+		return core.LedgerEntry{}, err
+	}
+	now := time.Now()
+	if entry.ReceivedAt.IsZero() {
+		entry.ReceivedAt = now
+	}
+	m.ledgerID++
+	stored := core.LedgerEntry{
+		ID:         m.ledgerID,
+		URL:        url,
+		Amount:     entry.Amount,
+		Token:      entry.Token,
+		AmountUSD:  entry.AmountUSD,
+		Reference:  entry.Reference,
+		Tier:       entry.Tier,
+		ReceivedAt: storedSeen(entry.ReceivedAt),
+		RecordedAt: storedSeen(now),
+	}
+	m.ledger = append(m.ledger, stored)
+
+	tracking, err := m.getTracking(url)
+	if err != nil && !errors.Is(err, ErrNotTracked) {
+		return core.LedgerEntry{}, err
+	}
+	if tracking.State != core.StatePaid {
+		paid := core.StatePaid
+		tracking.Apply(core.TrackingUpdate{State: &paid}, now)
+		received := entry.ReceivedAt
+		tracking.PaidAt = &received
+		m.saveTracking(url, tracking)
+	}
+	return m.ledgerEntry(stored), nil
+}
+
+// ledgerEntry fills e's bounty and reconciliation fields. Callers hold m.mu.
+func (m *MemoryStorage) ledgerEntry(e core.LedgerEntry) core.LedgerEntry {
+	if b, ok := m.bounties[e.URL]; ok {
+		e.BountyID, e.Title, e.Platform = b.ID, b.Title, b.Platform
+	}
+	if t, ok := m.tracking[e.URL]; ok {
+		e.ExpectedPayout, e.PayoutCurrency = t.ExpectedPayout, t.PayoutCurrency
+		e.ClaimedAt = copyTracking(t).ClaimedAt
+	}
+	return e
+}
+
+// GetLedger returns every recorded payment, most recently received first.
+func (m *MemoryStorage) GetLedger() ([]core.LedgerEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entries := make([]core.LedgerEntry, 0, len(m.ledger))
+	for _, e := range m.ledger {
+		entries = append(entries, m.ledgerEntry(e))
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].ReceivedAt.Equal(entries[j].ReceivedAt) {
+			return entries[i].ReceivedAt.After(entries[j].ReceivedAt)
+		}
+		return entries[i].ID > entries[j].ID
+	})
+	return entries, nil
+}
+
+// UnreconciledPayments returns bounties marked paid that have no ledger entry.
+func (m *MemoryStorage) UnreconciledPayments() ([]core.Tracking, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	paid := make(map[string]bool)
+	for _, e := range m.ledger {
+		paid[e.URL] = true
+	}
+	return m.listTracking(func(t core.Tracking) bool {
+		return t.State == core.StatePaid && !paid[t.URL]
+	}), nil
+}
+
+// RetiredBounties returns up to limit bounties that have been closed or
+// expired since before, longest retired first, with their history and
+// sources. Bounties with a pipeline record are never retired.
+func (m *MemoryStorage) RetiredBounties(before time.Time, limit int) ([]core.ArchivedBounty, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	archived := []core.ArchivedBounty{}
+	for _, b := range m.filtered(func(core.Bounty) bool { return true }) {
+		if r, ok := m.retired(b, before); ok {
+			archived = append(archived, r)
+		}
+	}
+	sort.SliceStable(archived, func(i, j int) bool { return archived[i].RetiredAt.Before(archived[j].RetiredAt) })
+	if limit >= 0 && len(archived) > limit {
+		archived = archived[:limit]
+	}
+	return archived, nil
+}
+
+// retired returns b as archived when it has been retired since before.
+// Callers hold m.mu.
+func (m *MemoryStorage) retired(b core.Bounty, before time.Time) (core.ArchivedBounty, bool) {
+	if _, tracked := m.tracking[b.URL]; tracked || !slices.Contains(core.RetiredStatuses, b.Status) {
+		return core.ArchivedBounty{}, false
+	}
+	history := []core.BountyChange{}
+	for _, c := range m.history {
+		if c.URL == b.URL {
+			history = append(history, c)
+		}
+	}
+	r := core.ArchivedBounty{Bounty: b, RetiredAt: retiredTime(b, history), History: history}
+	if !r.RetiredAt.Before(storedSeen(before)) {
+		return core.ArchivedBounty{}, false
+	}
+	r.Sources = append([]core.BountySource{}, m.sources[b.URL]...)
+	return r, true
+}
+
+// DeleteRetired deletes the bounties at urls that are still retired since
+// before, with their history and sources, and returns how many it deleted.
+func (m *MemoryStorage) DeleteRetired(urls []string, before time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deleted := make(map[string]bool)
+	for _, url := range urls {
+		b, ok := m.bounties[url]
+		if !ok {
+			continue
+		}
+		if _, ok := m.retired(b, before); ok {
+			delete(m.bounties, url)
+			delete(m.sources, url)
+			delete(m.linkChecks, url)
+			deleted[url] = true
+		}
+	}
+	m.history = slices.DeleteFunc(m.history, func(c core.BountyChange) bool { return deleted[c.URL] })
+	return len(deleted), nil
+}
+
+// Vacuum does nothing; deleted bounties are freed by the garbage collector.
+func (m *MemoryStorage) Vacuum(ctx context.Context) error {
+	return nil
+}
+
+// RecordAudit appends entry to the audit log, stamped now if At is unset.
+func (m *MemoryStorage) RecordAudit(entry core.AuditEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if entry.At.IsZero() {
+		entry.At = time.Now()
+	}
+	entry.ID = int64(len(m.audit) + 1)
+	entry.At = storedSeen(entry.At)
+	m.audit = append(m.audit, entry)
+	return nil
+}
+
+// GetAuditLog returns the latest limit audit entries for action, or for
+// every action when it is empty, newest first.
+func (m *MemoryStorage) GetAuditLog(action string, limit int) ([]core.AuditEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entries := []core.AuditEntry{}
+	for i := len(m.audit) - 1; i >= 0 && (limit < 0 || len(entries) < limit); i-- {
+		if action == "" || m.audit[i].Action == action {
+			entries = append(entries, m.audit[i])
+		}
+	}
+	return entries, nil
+}
+
+// LinkCheck returns the latest recorded reachability of url. found is false
+// when url was never checked or was last checked before since.
+func (m *MemoryStorage) LinkCheck(url string, since time.Time) (reachable, found bool, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	check, ok := m.linkChecks[url]
+	if !ok || check.checkedAt.Before(since) {
+		return false, false, nil
+	}
+	return check.reachable, true, nil
+}
+
+// SaveLinkCheck records the reachability of url as of checkedAt.
+func (m *MemoryStorage) SaveLinkCheck(url string, reachable bool, checkedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.linkChecks[url] = linkCheck{reachable: reachable, checkedAt: storedSeen(checkedAt)}
+	return nil
+}
+
+// GetScanState returns the value scanner stored under key, or "" if none.
+func (m *MemoryStorage) GetScanState(scanner, key string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.scanState[[2]string{scanner, key}], nil
+}
+
+// SetScanState stores value under key for scanner.
+func (m *MemoryStorage) SetScanState(scanner, key, value string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.scanState[[2]string{scanner, key}] = value
+	return nil
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+func TestMemoryStorage_Concurrent(t *testing.T) {
+	store := NewMemoryStorage()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				b := core.Bounty{URL: fmt.Sprintf("https://example.com/%d/%d", w, i), Title: "t", Platform: "TEST", CreatedAt: time.Now()}
+				if err := store.Save(b); err != nil {
+					t.Errorf("Save() error = %v", err)
+					return
+				}
+				if _, err := store.Refresh(b); err != nil {
+					t.Errorf("Refresh() error = %v", err)
+					return
+				}
+				if _, err := store.Query(core.BountyQuery{Limit: 10}); err != nil {
+					t.Errorf("Query() error = %v", err)
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	stats, err := store.Stats(core.BountyQuery{})
+	if err != nil || stats.TotalCount != 200 {
+		t.Errorf("Stats() = %+v, %v; want 200 bounties", stats, err)
+	}
+}
+
+func TestMemoryStorage_ReturnsCopies(t *testing.T) {
+	store := NewMemoryStorage()
+	if err := store.Save(core.Bounty{URL: "https://example.com/a", Tags: []string{"go"}, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	recent, _ := store.GetRecent(10)
+	recent[0].Tags[0] = "changed"
+	got, err := store.GetByID(core.BountyID("https://example.com/a"))
+	if err != nil || got.Tags[0] != "go" {
+		t.Errorf("stored bounty changed through a returned copy: %+v, %v", got, err)
+	}
+}
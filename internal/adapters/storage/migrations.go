@@ -0,0 +1,387 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dbtx is satisfied by *database, *txn, *sql.DB and *sql.Tx.
+type dbtx interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// migration is one schema step. Steps must tolerate databases created
+// before versioning, which may already have some of their tables and
+// columns, so they use IF NOT EXISTS and ensureColumns.
+type migration struct {
+	version int
+	name    string
+	up      func(tx dbtx) error
+}
+
+// MigrationState describes one migration and whether it has been applied.
+type MigrationState struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// migrations lists every SQLite schema step in order. Append new steps;
+// never edit or reorder applied ones. postgresMigrations mirrors it.
+var migrations = []migration{
+	{1, "create bounties table", func(tx dbtx) error {
+		_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS bounties (
+			url TEXT PRIMARY KEY,
+			title TEXT,
+			platform TEXT,
+			reward TEXT,
+			currency TEXT,
+			created_at DATETIME,
+			score INTEGER,
+			description TEXT,
+			tags TEXT,
+			expires_at DATETIME,
+			payment_type TEXT
+		);`)
+		return err
+	}},
+	{2, "structured reward columns", func(tx dbtx) error {
+		if err := ensureColumns(tx, "bounties", map[string]string{
+			"reward_min":   "REAL DEFAULT 0",
+			"reward_max":   "REAL DEFAULT 0",
+			"reward_token": "TEXT DEFAULT ''",
+			"reward_exact": "INTEGER DEFAULT 0",
+		}); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_bounties_reward_max ON bounties(reward_max)`)
+		return err
+	}},
+	{3, "reward usd value", func(tx dbtx) error {
+		return ensureColumns(tx, "bounties", map[string]string{"reward_usd": "REAL DEFAULT 0"})
+	}},
+	{4, "rejections table", func(tx dbtx) error {
+		// Bounties filtered out by reward thresholds, one row per URL.
+		_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS rejections (
+			url TEXT PRIMARY KEY,
+			title TEXT,
+			platform TEXT,
+			reward TEXT,
+			currency TEXT,
+			reward_usd REAL,
+			reason TEXT,
+			stage TEXT,
+			rejected_at DATETIME
+		);`)
+		return err
+	}},
+	{5, "bounty ids and score breakdowns", func(tx dbtx) error {
+		if err := ensureColumns(tx, "bounties", map[string]string{
+			"id":              "TEXT",
+			"score_breakdown": "TEXT",
+		}); err != nil {
+			return err
+		}
+		if err := backfillIDs(tx); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_bounties_id ON bounties(id)`)
+		return err
+	}},
+	{6, "bounty lifecycle and history", func(tx dbtx) error {
+		if err := ensureColumns(tx, "bounties", map[string]string{
+			"status":     "TEXT DEFAULT 'open'",
+			"first_seen": "DATETIME",
+			"last_seen":  "DATETIME",
+		}); err != nil {
+			return err
+		}
+		if err := backfillLifecycle(tx); err != nil {
+			return err
+		}
+		// Field and status changes observed across scans.
+		if _, err := tx.Exec(`CREATE TABLE IF NOT EXISTS bounty_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			url TEXT,
+			field TEXT,
+			old_value TEXT,
+			new_value TEXT,
+			changed_at DATETIME
+		);`); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_bounty_history_url ON bounty_history(url)`)
+		return err
+	}},
+	{7, "pipeline tracking", func(tx dbtx) error {
+		// The user's own workflow state for bounties they pursue.
+		_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS tracking (
+			url TEXT PRIMARY KEY,
+			state TEXT NOT NULL,
+			notes TEXT DEFAULT '',
+			expected_payout REAL DEFAULT 0,
+			payout_currency TEXT DEFAULT '',
+			created_at DATETIME,
+			updated_at DATETIME,
+			watched_at DATETIME,
+			claimed_at DATETIME,
+			started_at DATETIME,
+			submitted_at DATETIME,
+			paid_at DATETIME
+		);`)
+		return err
+	}},
+	{8, "earnings ledger", func(tx dbtx) error {
+		// Payments actually received, possibly several per bounty.
+		if _, err := tx.Exec(`CREATE TABLE IF NOT EXISTS ledger (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			url TEXT NOT NULL,
+			amount REAL,
+			token TEXT,
+			amount_usd REAL DEFAULT 0,
+			reference TEXT DEFAULT '',
+			tier TEXT,
+			received_at DATETIME,
+			recorded_at DATETIME
+		);`); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_ledger_url ON ledger(url)`)
+		return err
+	}},
+	{9, "canonical identity and bounty sources", func(tx dbtx) error {
+		if err := ensureColumns(tx, "bounties", map[string]string{"canonical_url": "TEXT"}); err != nil {
+			return err
+		}
+		// Every platform, label and listing a bounty was seen under.
+		if _, err := tx.Exec(`CREATE TABLE IF NOT EXISTS bounty_sources (
+			bounty_url TEXT NOT NULL,
+			platform TEXT NOT NULL,
+			label TEXT NOT NULL DEFAULT '',
+			source_id TEXT DEFAULT '',
+			url TEXT NOT NULL,
+			match TEXT,
+			first_seen DATETIME,
+			last_seen DATETIME,
+			PRIMARY KEY (bounty_url, platform, label, url)
+		);`); err != nil {
+			return err
+		}
+		if err := backfillIdentity(tx); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_bounties_canonical_url ON bounties(canonical_url)`)
+		return err
+	}},
+	{10, "maintenance audit log", func(tx dbtx) error {
+		// Retention and vacuum runs, so removed data can be accounted for.
+		_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS audit_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			action TEXT NOT NULL,
+			count INTEGER DEFAULT 0,
+			detail TEXT DEFAULT '',
+			at DATETIME
+		);`)
+		return err
+	}},
+	{11, "link check cache", func(tx dbtx) error {
+		// Link validation results, reused until they are LINK_CACHE_TTL_HOURS old.
+		_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS link_checks (
+			url TEXT PRIMARY KEY,
+			reachable INTEGER DEFAULT 0,
+			checked_at DATETIME
+		);`)
+		return err
+	}},
+	{12, "scanner state", func(tx dbtx) error {
+		// Scanner progress such as GitHub label cursors, so restarts resume.
+		_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS scanner_state (
+			scanner TEXT NOT NULL,
+			key TEXT NOT NULL,
+			value TEXT DEFAULT '',
+			updated_at DATETIME,
+			PRIMARY KEY (scanner, key)
+		);`)
+		return err
+	}},
+	{13, "search tags as words", func(tx dbtx) error {
+		// Postgres rebuilds its search index; FTS5 already splits tags on
+		// punctuation.
+		return nil
+	}},
+	{14, "bounty links", func(tx dbtx) error {
+		// The canonical links in each description, so duplicate detection
+		// finds listings linking to a bounty without scanning descriptions.
+		if _, err := tx.Exec(`CREATE TABLE IF NOT EXISTS bounty_links (
+			bounty_url TEXT NOT NULL,
+			link TEXT NOT NULL,
+			PRIMARY KEY (bounty_url, link)
+		);`); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_bounty_links_link ON bounty_links(link)`); err != nil {
+			return err
+		}
+		return backfillLinks(tx)
+	}},
+}
+
+// SchemaVersion is the version a fully migrated database is at.
+func SchemaVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+// MigrationStatus reports every migration for the database driver and dsn
+// name (see Open) and when it was applied, without changing the database.
+func MigrationStatus(driver, dsn string) ([]MigrationState, error) {
+	db, steps, err := openDatabase(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return migrationStatus(db, steps)
+}
+
+// MigrateUp applies pending migrations to the database driver and dsn name
+// and returns the ones it applied.
+func MigrateUp(driver, dsn string) ([]MigrationState, error) {
+	db, steps, err := openDatabase(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return migrateUp(db, steps)
+}
+
+// openDatabase connects without migrating and returns the backend's
+// migration steps.
+func openDatabase(driver, dsn string) (*database, []migration, error) {
+	switch normalizeDriver(driver) {
+	case DriverSQLite:
+		db, err := openSQLite(dsn)
+		return db, migrations, err
+	case DriverPostgres:
+		db, err := openPostgres(dsn)
+		return db, postgresMigrations, err
+	case DriverMemory:
+		return nil, nil, fmt.Errorf("%s storage has no schema to migrate", DriverMemory)
+	default:
+		return nil, nil, fmt.Errorf("unknown storage driver %q: want %s or %s", driver, DriverSQLite, DriverPostgres)
+	}
+}
+
+func migrationStatus(db *database, steps []migration) ([]MigrationState, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	states := make([]MigrationState, 0, len(steps))
+	for _, m := range steps {
+		state := MigrationState{Version: m.version, Name: m.name}
+		if at, ok := applied[m.version]; ok {
+			at := at
+			state.AppliedAt = &at
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// migrateUp runs each pending migration in its own transaction, recording it
+// in schema_version in the same transaction.
+func migrateUp(db *database, steps []migration) ([]MigrationState, error) {
+	stamp := "DATETIME"
+	if db.dialect == postgresDialect {
+		stamp = "TEXT"
+	}
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER PRIMARY KEY,
+		name TEXT,
+		applied_at ` + stamp + `
+	);`); err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var ran []MigrationState
+	for _, m := range steps {
+		if _, ok := applied[m.version]; ok {
+			continue
+		}
+		now := time.Now().UTC().Truncate(time.Second)
+		ok, err := applyMigration(db, m, now)
+		if err != nil {
+			return ran, fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+		}
+		if ok {
+			ran = append(ran, MigrationState{Version: m.version, Name: m.name, AppliedAt: &now})
+		}
+	}
+	return ran, nil
+}
+
+// applyMigration runs m and reports whether it ran. Postgres instances
+// sharing a database serialise on an advisory lock and skip a step another
+// instance applied meanwhile.
+func applyMigration(db *database, m migration, now time.Time) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	if db.dialect == postgresDialect {
+		if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(?)`, migrationLockKey); err != nil {
+			return false, err
+		}
+		var done int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM schema_version WHERE version = ?`, m.version).Scan(&done); err != nil {
+			return false, err
+		}
+		if done > 0 {
+			return false, nil
+		}
+	}
+
+	if err := m.up(tx); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_version (version, name, applied_at) VALUES (?, ?, ?)`,
+		m.version, m.name, formatSeen(now)); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// appliedMigrations maps applied versions to their application time. A
+// database without schema_version has none applied.
+func appliedMigrations(db *database) (map[int]time.Time, error) {
+	applied := make(map[int]time.Time)
+	rows, err := db.Query(`SELECT version, applied_at FROM schema_version`)
+	if err != nil {
+		// SQLite and Postgres wording respectively.
+		if msg := err.Error(); strings.Contains(msg, "no such table") || strings.Contains(msg, "does not exist") {
+			return applied, nil
+		}
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int
+		var appliedAt sql.NullString
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		at, _ := parseTime(appliedAt.String)
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"bountyos-v8/internal/core"
+)
+
+func TestMigrateUpLegacyDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "legacy.db")
+
+	// A bounties.db written before versioned migrations.
+	legacy, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := legacy.Exec(`CREATE TABLE bounties (
+		url TEXT PRIMARY KEY, title TEXT, platform TEXT, reward TEXT, currency TEXT,
+		created_at DATETIME, score INTEGER, description TEXT, tags TEXT, expires_at DATETIME, payment_type TEXT
+	);`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := legacy.Exec(`INSERT INTO bounties VALUES
+		('https://example.com/old', 'Old bounty', 'TEST', '100', 'USDC', '2024-01-02T00:00:00Z', 70, '', '[]', NULL, 'crypto')`); err != nil {
+		t.Fatal(err)
+	}
+	legacy.Close()
+
+	states, err := MigrationStatus(DriverSQLite, dbPath)
+	if err != nil {
+		t.Fatalf("MigrationStatus() error = %v", err)
+	}
+	for _, state := range states {
+		if state.AppliedAt != nil {
+			t.Fatalf("legacy migration %d reported applied", state.Version)
+		}
+	}
+
+	applied, err := MigrateUp(DriverSQLite, dbPath)
+	if err != nil {
+		t.Fatalf("MigrateUp() error = %v", err)
+	}
+	if len(applied) != len(migrations) || applied[len(applied)-1].Version != SchemaVersion() {
+		t.Fatalf("MigrateUp() applied %+v", applied)
+	}
+	if again, err := MigrateUp(DriverSQLite, dbPath); err != nil || len(again) != 0 {
+		t.Fatalf("second MigrateUp() = %+v, %v; want nothing applied", again, err)
+	}
+
+	store, err := NewSQLiteStorage(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage() error = %v", err)
+	}
+	defer store.Close()
+	old, err := store.GetByID(core.BountyID("https://example.com/old"))
+	if err != nil || old.Title != "Old bounty" || old.Score != 70 || old.Status != core.StatusOpen || old.FirstSeen.IsZero() {
+		t.Errorf("legacy bounty after migration = %+v, %v", old, err)
+	}
+}
+
+func TestMigrateUpRollsBackFailedStep(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "failing.db")
+	store, err := NewSQLiteStorage(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage() error = %v", err)
+	}
+	store.Close()
+
+	saved := migrations
+	defer func() { migrations = saved }()
+	migrations = append(append([]migration(nil), saved...), migration{
+		version: SchemaVersion() + 1,
+		name:    "broken",
+		up: func(tx dbtx) error {
+			if _, err := tx.Exec(`CREATE TABLE half_done (id INTEGER)`); err != nil {
+				return err
+			}
+			return errors.New("boom")
+		},
+	})
+
+	if _, err := MigrateUp(DriverSQLite, dbPath); err == nil {
+		t.Fatal("MigrateUp() expected error from broken migration")
+	}
+	states, err := MigrationStatus(DriverSQLite, dbPath)
+	if err != nil {
+		t.Fatalf("MigrationStatus() error = %v", err)
+	}
+	if last := states[len(states)-1]; last.AppliedAt != nil {
+		t.Errorf("broken migration recorded as applied")
+	}
+
+	db, err := openSQLite(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var name string
+	if err := db.QueryRow(`SELECT name FROM sqlite_master WHERE name = 'half_done'`).Scan(&name); err != sql.ErrNoRows {
+		t.Errorf("half_done table survived rollback (err = %v)", err)
+	}
+}
+
+func TestPostgresMigrationsMirrorSQLite(t *testing.T) {
+	if len(postgresMigrations) != len(migrations) {
+		t.Fatalf("%d Postgres migrations, %d SQLite", len(postgresMigrations), len(migrations))
+	}
+	for i, m := range migrations {
+		if pg := postgresMigrations[i]; pg.version != m.version || pg.name != m.name {
+			t.Errorf("Postgres migration %d %q, SQLite %d %q", pg.version, pg.name, m.version, m.name)
+		}
+	}
+}
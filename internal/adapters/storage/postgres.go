@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"database/sql"
+
+	_ "github.com/lib/pq"
+)
+
+// PostgresStorage stores bounties in a PostgreSQL database that several
+// instances can share. It runs the same queries as SQLiteStorage; its schema
+// keeps timestamps as RFC 3339 text so they compare the same way.
+type PostgresStorage struct {
+	sqlStore
+}
+
+// NewPostgresStorage connects with the lib/pq connection string dsn, e.g.
+// "postgres://bountyos@localhost/bountyos?sslmode=disable", and applies any
+// pending schema migrations.
+func NewPostgresStorage(dsn string) (*PostgresStorage, error) {
+	db, err := openPostgres(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := migrateUp(db, postgresMigrations); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &PostgresStorage{sqlStore{db: db}}, nil
+}
+
+func openPostgres(dsn string) (*database, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &database{DB: db, dialect: postgresDialect}, nil
+}
+
+// migrationLockKey is the advisory lock Postgres instances take while
+// migrating a shared database.
+const migrationLockKey = 0x626f756e7479 // "bounty"
+
+// pgSearchDocument is the text Search matches on Postgres. It must match
+// the idx_bounties_search expression for the index to be used. Tags are
+// stored as a JSON array; its brackets, quotes and commas become spaces.
+const pgSearchDocument = `to_tsvector('english', COALESCE(title, '') || ' ' || COALESCE(description, '') || ' ' || translate(COALESCE(tags, ''), '[]",', '    '))`
+
+func pgStep(statements ...string) func(tx dbtx) error {
+	return func(tx dbtx) error {
+		for _, stmt := range statements {
+			if _, err := tx.Exec(stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// postgresMigrations mirrors migrations version for version, so both
+// backends report the same schema version. Postgres databases never held a
+// pre-versioning schema, so only steps adding derived tables backfill.
+var postgresMigrations = []migration{
+	{1, "create bounties table", pgStep(
+		`CREATE TABLE IF NOT EXISTS bounties (
+			url TEXT PRIMARY KEY,
+			title TEXT,
+			platform TEXT,
+			reward TEXT,
+			currency TEXT,
+			created_at TEXT,
+			score INTEGER,
+			description TEXT,
+			tags TEXT,
+			expires_at TEXT,
+			payment_type TEXT
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_bounties_search ON bounties USING GIN (`+pgSearchDocument+`)`,
+	)},
+	{2, "structured reward columns", pgStep(
+		`ALTER TABLE bounties
+			ADD COLUMN IF NOT EXISTS reward_min DOUBLE PRECISION DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS reward_max DOUBLE PRECISION DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS reward_token TEXT DEFAULT '',
+			ADD COLUMN IF NOT EXISTS reward_exact BOOLEAN DEFAULT FALSE`,
+		`CREATE INDEX IF NOT EXISTS idx_bounties_reward_max ON bounties(reward_max)`,
+	)},
+	{3, "reward usd value", pgStep(
+		`ALTER TABLE bounties ADD COLUMN IF NOT EXISTS reward_usd DOUBLE PRECISION DEFAULT 0`,
+	)},
+	{4, "rejections table", pgStep(
+		`CREATE TABLE IF NOT EXISTS rejections (
+			url TEXT PRIMARY KEY,
+			title TEXT,
+			platform TEXT,
+			reward TEXT,
+			currency TEXT,
+			reward_usd DOUBLE PRECISION,
+			reason TEXT,
+			stage TEXT,
+			rejected_at TEXT
+		)`,
+	)},
+	{5, "bounty ids and score breakdowns", pgStep(
+		`ALTER TABLE bounties
+			ADD COLUMN IF NOT EXISTS id TEXT,
+			ADD COLUMN IF NOT EXISTS score_breakdown TEXT`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_bounties_id ON bounties(id)`,
+	)},
+	{6, "bounty lifecycle and history", pgStep(
+		`ALTER TABLE bounties
+			ADD COLUMN IF NOT EXISTS status TEXT DEFAULT 'open',
+			ADD COLUMN IF NOT EXISTS first_seen TEXT,
+			ADD COLUMN IF NOT EXISTS last_seen TEXT`,
+		`CREATE TABLE IF NOT EXISTS bounty_history (
+			id BIGSERIAL PRIMARY KEY,
+			url TEXT,
+			field TEXT,
+			old_value TEXT,
+			new_value TEXT,
+			changed_at TEXT
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_bounty_history_url ON bounty_history(url)`,
+	)},
+	{7, "pipeline tracking", pgStep(
+		`CREATE TABLE IF NOT EXISTS tracking (
+			url TEXT PRIMARY KEY,
+			state TEXT NOT NULL,
+			notes TEXT DEFAULT '',
+			expected_payout DOUBLE PRECISION DEFAULT 0,
+			payout_currency TEXT DEFAULT '',
+			created_at TEXT,
+			updated_at TEXT,
+			watched_at TEXT,
+			claimed_at TEXT,
+			started_at TEXT,
+			submitted_at TEXT,
+			paid_at TEXT
+		)`,
+	)},
+	{8, "earnings ledger", pgStep(
+		`CREATE TABLE IF NOT EXISTS ledger (
+			id BIGSERIAL PRIMARY KEY,
+			url TEXT NOT NULL,
+			amount DOUBLE PRECISION,
+			token TEXT,
+			amount_usd DOUBLE PRECISION DEFAULT 0,
+			reference TEXT DEFAULT '',
+			tier TEXT,
+			received_at TEXT,
+			recorded_at TEXT
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_ledger_url ON ledger(url)`,
+	)},
+	{9, "canonical identity and bounty sources", pgStep(
+		`ALTER TABLE bounties ADD COLUMN IF NOT EXISTS canonical_url TEXT`,
+		`CREATE TABLE IF NOT EXISTS bounty_sources (
+			bounty_url TEXT NOT NULL,
+			platform TEXT NOT NULL,
+			label TEXT NOT NULL DEFAULT '',
+			source_id TEXT DEFAULT '',
+			url TEXT NOT NULL,
+			match TEXT,
+			first_seen TEXT,
+			last_seen TEXT,
+			PRIMARY KEY (bounty_url, platform, label, url)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_bounties_canonical_url ON bounties(canonical_url)`,
+	)},
+	{10, "maintenance audit log", pgStep(
+		`CREATE TABLE IF NOT EXISTS audit_log (
+			id BIGSERIAL PRIMARY KEY,
+			action TEXT NOT NULL,
+			count INTEGER DEFAULT 0,
+			detail TEXT DEFAULT '',
+			at TEXT
+		)`,
+	)},
+	{11, "link check cache", pgStep(
+		`CREATE TABLE IF NOT EXISTS link_checks (
+			url TEXT PRIMARY KEY,
+			reachable BOOLEAN DEFAULT FALSE,
+			checked_at TEXT
+		)`,
+	)},
+	{12, "scanner state", pgStep(
+		`CREATE TABLE IF NOT EXISTS scanner_state (
+			scanner TEXT NOT NULL,
+			key TEXT NOT NULL,
+			value TEXT DEFAULT '',
+			updated_at TEXT,
+			PRIMARY KEY (scanner, key)
+		)`,
+	)},
+	{13, "search tags as words", pgStep(
+		`DROP INDEX IF EXISTS idx_bounties_search`,
+		`CREATE INDEX idx_bounties_search ON bounties USING GIN (`+pgSearchDocument+`)`,
+	)},
+	{14, "bounty links", func(tx dbtx) error {
+		if err := pgStep(
+			`CREATE TABLE IF NOT EXISTS bounty_links (
+				bounty_url TEXT NOT NULL,
+				link TEXT NOT NULL,
+				PRIMARY KEY (bounty_url, link)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_bounty_links_link ON bounty_links(link)`,
+		)(tx); err != nil {
+			return err
+		}
+		return backfillLinks(tx)
+	}},
+}
@@ -0,0 +1,202 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"bountyos-v8/internal/core"
+)
+
+// noDeadline stands in for a missing expires_at so bounties without one
+// sort last in either direction. It is beyond any julian day or Unix time.
+const noDeadline = 1e12
+
+// sortExpr returns the SQL expression bounties are ordered by. Timestamps
+// compare through dialect.epoch so mixed zone offsets order correctly.
+func sortExpr(d dialect, sort core.BountySort, ascending bool) string {
+	switch sort {
+	case core.SortCreatedAt:
+		return `COALESCE(` + d.epoch("created_at") + `, 0)`
+	case core.SortRewardUSD:
+		return `COALESCE(reward_usd, 0)`
+	case core.SortExpiresAt:
+		if ascending {
+			return fmt.Sprintf(`COALESCE(%s, %g)`, d.epoch("expires_at"), float64(noDeadline))
+		}
+		return fmt.Sprintf(`COALESCE(%s, %g)`, d.epoch("expires_at"), float64(-noDeadline))
+	default:
+		return `COALESCE(score, 0)`
+	}
+}
+
+// pageCursor is the position after the last row of a page: its sort value
+// and URL, which breaks ties. Sort and Ascending reject a cursor replayed
+// against a different ordering.
+type pageCursor struct {
+	Sort      core.BountySort `json:"s"`
+	Ascending bool            `json:"a,omitempty"`
+	Value     float64         `json:"v"`
+	URL       string          `json:"u"`
+}
+
+func encodeCursor(c pageCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string, sort core.BountySort, ascending bool) (pageCursor, error) {
+	var c pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || json.Unmarshal(data, &c) != nil || c.URL == "" {
+		return c, core.ErrInvalidCursor
+	}
+	if c.Sort != sort || c.Ascending != ascending {
+		return c, fmt.Errorf("%w: issued for sort %s", core.ErrInvalidCursor, c.Sort)
+	}
+	return c, nil
+}
+
+// Query returns one page of bounties matching q, using keyset pagination so
+// pages stay stable while new bounties arrive.
+func (s *sqlStore) Query(q core.BountyQuery) (core.BountyPage, error) {
+	page := core.BountyPage{Bounties: []core.Bounty{}}
+	if q.Sort == "" {
+		q.Sort = core.SortScore
+	}
+	if q.Limit <= 0 {
+		q.Limit = 50
+	}
+
+	where, args := bountyQueryClause(s.db.dialect, q)
+	expr := sortExpr(s.db.dialect, q.Sort, q.Ascending)
+	dir, cmp := "DESC", "<"
+	if q.Ascending {
+		dir, cmp = "ASC", ">"
+	}
+	if q.Cursor != "" {
+		c, err := decodeCursor(q.Cursor, q.Sort, q.Ascending)
+		if err != nil {
+			return page, err
+		}
+		where = append(where, fmt.Sprintf(`(%s %s ? OR (%s = ? AND url %s ?))`, expr, cmp, expr, cmp))
+		args = append(args, c.Value, c.Value, c.URL)
+	}
+
+	query := `SELECT ` + bountyColumns + ` FROM bounties`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, ` AND `)
+	}
+	query += fmt.Sprintf(` ORDER BY %s %s, url %s LIMIT ?`, expr, dir, dir)
+
+	// One extra row tells whether another page follows.
+	rows, err := s.db.Query(query, append(args, q.Limit+1)...)
+	if err != nil {
+		return page, err
+	}
+	bounties := scanBounties(rows)
+	rows.Close()
+	if len(bounties) <= q.Limit {
+		if bounties != nil {
+			page.Bounties = bounties
+		}
+		return page, nil
+	}
+
+	page.Bounties = bounties[:q.Limit]
+	last := page.Bounties[q.Limit-1]
+	// Read the sort value back from SQLite so the cursor compares exactly.
+	var value float64
+	if err := s.db.QueryRow(`SELECT `+expr+` FROM bounties WHERE url = ?`, last.URL).Scan(&value); err != nil {
+		return page, err
+	}
+	page.NextCursor = encodeCursor(pageCursor{Sort: q.Sort, Ascending: q.Ascending, Value: value, URL: last.URL})
+	return page, nil
+}
+
+// Stats aggregates every bounty matching q's filters; sorting and paging
+// are ignored.
+func (s *sqlStore) Stats(q core.BountyQuery) (core.BountyStats, error) {
+	stats := core.BountyStats{ByPlatform: make(map[string]int)}
+	where, args := bountyQueryClause(s.db.dialect, q)
+	whereSQL := ""
+	if len(where) > 0 {
+		whereSQL = ` WHERE ` + strings.Join(where, ` AND `)
+	}
+
+	if err := s.db.QueryRow(`SELECT COUNT(*), COALESCE(AVG(score), 0), COALESCE(SUM(CASE WHEN payment_type = 'crypto' THEN 1 ELSE 0 END), 0)
+		FROM bounties`+whereSQL, args...).Scan(&stats.TotalCount, &stats.AvgScore, &stats.CryptoCount); err != nil {
+		return stats, err
+	}
+
+	rows, err := s.db.Query(`SELECT COALESCE(platform, ''), COUNT(*) FROM bounties`+whereSQL+` GROUP BY platform`, args...)
+	if err != nil {
+		return stats, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var platform string
+		var count int
+		if err := rows.Scan(&platform, &count); err != nil {
+			return stats, err
+		}
+		stats.ByPlatform[platform] = count
+	}
+	return stats, rows.Err()
+}
+
+func bountyQueryClause(d dialect, q core.BountyQuery) ([]string, []interface{}) {
+	var where []string
+	var args []interface{}
+
+	statuses := q.Statuses
+	if len(statuses) == 0 {
+		statuses = []core.BountyStatus{core.StatusOpen}
+	}
+	placeholders := make([]string, len(statuses))
+	for i, status := range statuses {
+		placeholders[i] = "?"
+		args = append(args, string(status))
+	}
+	where = append(where, `status IN (`+strings.Join(placeholders, ", ")+`)`)
+
+	if q.Platform != "" {
+		clause, platformArgs := platformClause(q.Platform)
+		where = append(where, clause)
+		args = append(args, platformArgs...)
+	}
+	if q.Currency != "" {
+		currency := strings.ToUpper(strings.TrimSpace(q.Currency))
+		where = append(where, `(UPPER(currency) = ? OR reward_token = ?)`)
+		args = append(args, currency, currency)
+	}
+	if q.PaymentType != "" {
+		where = append(where, `payment_type = ?`)
+		args = append(args, strings.ToLower(strings.TrimSpace(q.PaymentType)))
+	}
+	if q.MinScore != nil {
+		where = append(where, `score >= ?`)
+		args = append(args, *q.MinScore)
+	}
+	if q.Tag != "" {
+		where = append(where, d.hasTag())
+		args = append(args, strings.ToLower(strings.TrimSpace(q.Tag)))
+	}
+	if !q.CreatedAfter.IsZero() {
+		where = append(where, d.epoch("created_at")+` >= `+d.epoch("?"))
+		args = append(args, formatSeen(q.CreatedAfter))
+	}
+	if !q.ExpiresBefore.IsZero() {
+		where = append(where, d.epoch("expires_at")+` < `+d.epoch("?"))
+		args = append(args, formatSeen(q.ExpiresBefore))
+	}
+	return where, args
+}
+
+// platformClause matches a platform and every label under it, so "GITHUB"
+// covers "GITHUB/BOUNTY".
+func platformClause(platform string) (string, []interface{}) {
+	platform = strings.ToUpper(strings.TrimSpace(platform))
+	return `(platform = ? OR platform LIKE ? ESCAPE '\')`, []interface{}{platform, escapeLike(platform) + "/%"}
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+func TestStorage_Query(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+
+		now := time.Now().Truncate(time.Second)
+		soon, later := now.Add(24*time.Hour), now.Add(72*time.Hour)
+		for _, b := range []core.Bounty{
+			{URL: "https://example.com/a", Platform: "GITHUB/BOUNTY", Currency: "USDC", PaymentType: "crypto", Score: 90,
+				RewardUSD: 100, Tags: []string{"Rust"}, CreatedAt: now.Add(-3 * time.Hour), ExpiresAt: &later},
+			{URL: "https://example.com/b", Platform: "GITHUB/FUNDED", Currency: "USD", PaymentType: "fiat", Score: 70,
+				RewardUSD: 500, Tags: []string{"go"}, CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: &soon},
+			{URL: "https://example.com/c", Platform: "SUPERTEAM", Currency: "USDC", PaymentType: "crypto", Score: 70,
+				RewardUSD: 300, Tags: []string{"design"}, CreatedAt: now.Add(-time.Hour)},
+			{URL: "https://example.com/d", Platform: "SUPERTEAM", Currency: "SOL", PaymentType: "crypto", Score: 95,
+				RewardUSD: 900, CreatedAt: now, Status: core.StatusClosed},
+		} {
+			if err := store.Save(b); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		minScore := 80
+		tests := []struct {
+			name  string
+			query core.BountyQuery
+			want  string
+		}{
+			{"open by score, ties by url", core.BountyQuery{}, "a c b"},
+			{"platform prefix", core.BountyQuery{Platform: "github"}, "a b"},
+			{"currency", core.BountyQuery{Currency: "usdc"}, "a c"},
+			{"payment type and min score", core.BountyQuery{PaymentType: "crypto", MinScore: &minScore}, "a"},
+			{"tag ignores case", core.BountyQuery{Tag: "rust"}, "a"},
+			{"created after", core.BountyQuery{CreatedAfter: now.Add(-150 * time.Minute), Sort: core.SortCreatedAt}, "c b"},
+			{"expires before", core.BountyQuery{ExpiresBefore: now.Add(48 * time.Hour)}, "b"},
+			{"status", core.BountyQuery{Statuses: []core.BountyStatus{core.StatusClosed, core.StatusOpen}, Sort: core.SortRewardUSD}, "d b c a"},
+			{"deadline soonest first, none last", core.BountyQuery{Sort: core.SortExpiresAt, Ascending: true}, "b a c"},
+			{"deadline latest first, none last", core.BountyQuery{Sort: core.SortExpiresAt}, "a b c"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				page, err := store.Query(tt.query)
+				if err != nil {
+					t.Fatalf("Query() error = %v", err)
+				}
+				if got := pageKeys(page.Bounties); got != tt.want {
+					t.Errorf("Query() = %q, want %q", got, tt.want)
+				}
+			})
+		}
+
+		t.Run("cursor pagination", func(t *testing.T) {
+			q := core.BountyQuery{Limit: 1}
+			var got []string
+			for i := 0; i < 5; i++ {
+				page, err := store.Query(q)
+				if err != nil {
+					t.Fatalf("Query() error = %v", err)
+				}
+				got = append(got, pageKeys(page.Bounties))
+				if page.NextCursor == "" {
+					break
+				}
+				q.Cursor = page.NextCursor
+			}
+			if fmt.Sprint(got) != "[a c b]" {
+				t.Errorf("pages = %v, want [a c b]", got)
+			}
+		})
+
+		t.Run("cursor from another sort", func(t *testing.T) {
+			page, err := store.Query(core.BountyQuery{Limit: 1})
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			_, err = store.Query(core.BountyQuery{Limit: 1, Sort: core.SortCreatedAt, Cursor: page.NextCursor})
+			if !errors.Is(err, core.ErrInvalidCursor) {
+				t.Errorf("Query() error = %v, want ErrInvalidCursor", err)
+			}
+			if _, err := store.Query(core.BountyQuery{Cursor: "not-a-cursor"}); !errors.Is(err, core.ErrInvalidCursor) {
+				t.Errorf("Query() error = %v, want ErrInvalidCursor", err)
+			}
+		})
+	})
+}
+
+func TestStorage_Stats(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+
+		for _, b := range []core.Bounty{
+			{URL: "https://example.com/a", Platform: "GITHUB/BOUNTY", PaymentType: "crypto", Score: 90, CreatedAt: time.Now()},
+			{URL: "https://example.com/b", Platform: "SUPERTEAM", PaymentType: "fiat", Score: 60, CreatedAt: time.Now()},
+			{URL: "https://example.com/c", Platform: "SUPERTEAM", PaymentType: "crypto", Score: 10, CreatedAt: time.Now(), Status: core.StatusClosed},
+		} {
+			if err := store.Save(b); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		stats, err := store.Stats(core.BountyQuery{})
+		if err != nil {
+			t.Fatalf("Stats() error = %v", err)
+		}
+		if stats.TotalCount != 2 || stats.CryptoCount != 1 || stats.AvgScore != 75 {
+			t.Errorf("Stats() = %+v, want 2 open, 1 crypto, average 75", stats)
+		}
+		if stats.ByPlatform["SUPERTEAM"] != 1 || stats.ByPlatform["GITHUB/BOUNTY"] != 1 {
+			t.Errorf("Stats().ByPlatform = %v", stats.ByPlatform)
+		}
+	})
+}
+
+// pageKeys lists the last path element of each bounty URL.
+func pageKeys(bounties []core.Bounty) string {
+	keys := ""
+	for i, b := range bounties {
+		if i > 0 {
+			keys += " "
+		}
+		keys += b.URL[len(b.URL)-1:]
+	}
+	return keys
+}
@@ -0,0 +1,261 @@
+package storage
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+// RetentionPolicy removes bounties that have been closed or expired for
+// longer than After. Bounties with a pipeline record are kept.
+type RetentionPolicy struct {
+	After time.Duration
+	// ArchiveDir receives each batch as gzip-compressed JSON lines before
+	// it is deleted. Empty deletes without exporting.
+	ArchiveDir string
+	// BatchSize is the number of bounties per deletion and archive file.
+	BatchSize int
+}
+
+// ApplyRetention removes the bounties policy retires as of now, in batches,
+// and records each batch in the audit log. A batch is deleted only after
+// its archive file is safely written. It returns how many bounties were
+// removed.
+func ApplyRetention(store Store, policy RetentionPolicy, now time.Time) (int, error) {
+	if policy.After <= 0 {
+		return 0, nil
+	}
+	if policy.BatchSize <= 0 {
+		policy.BatchSize = 500
+	}
+	before := now.Add(-policy.After)
+
+	removed := 0
+	for batch := 1; ; batch++ {
+		retired, err := store.RetiredBounties(before, policy.BatchSize)
+		if err != nil || len(retired) == 0 {
+			return removed, err
+		}
+
+		entry := core.AuditEntry{Action: core.AuditDelete}
+		if policy.ArchiveDir != "" {
+			entry.Action = core.AuditArchive
+			if entry.Detail, err = writeArchive(policy.ArchiveDir, now, batch, retired); err != nil {
+				store.RecordAudit(core.AuditEntry{Action: core.AuditArchive, Detail: "export failed: " + err.Error()})
+				return removed, err
+			}
+		}
+
+		urls := make([]string, len(retired))
+		for i, r := range retired {
+			urls[i] = r.Bounty.URL
+		}
+		deleted, err := store.DeleteRetired(urls, before)
+		if err != nil {
+			entry.Detail = strings.TrimSpace(entry.Detail + " delete failed: " + err.Error())
+			store.RecordAudit(entry)
+			return removed, err
+		}
+		removed += deleted
+		entry.Count = deleted
+		if err := store.RecordAudit(entry); err != nil {
+			return removed, err
+		}
+		// Every candidate changed since it was read; stop rather than
+		// read the same batch again.
+		if deleted == 0 {
+			return removed, nil
+		}
+	}
+}
+
+// writeArchive writes records as gzip-compressed JSON lines to a new file in
+// dir and returns its path. The file appears under its final name only
+// once it is complete and synced.
+func writeArchive(dir string, now time.Time, batch int, records []core.ArchivedBounty) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("bounties-%s-%03d.jsonl.gz", now.UTC().Format("20060102T150405Z"), batch))
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return "", err
+	}
+	fail := func(err error) (string, error) {
+		f.Close()
+		os.Remove(tmp)
+		return "", err
+	}
+
+	gz := gzip.NewWriter(f)
+	enc := json.NewEncoder(gz)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return fail(err)
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return fail(err)
+	}
+	if err := f.Sync(); err != nil {
+		return fail(err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	return path, nil
+}
+
+// retiredTime is when b left open: its latest recorded status change, or
+// its last sighting when none was recorded.
+func retiredTime(b core.Bounty, history []core.BountyChange) time.Time {
+	retired := b.LastSeen
+	found := false
+	for _, c := range history {
+		if c.Field == "status" && (!found || c.ChangedAt.After(retired)) {
+			retired, found = c.ChangedAt, true
+		}
+	}
+	return retired
+}
+
+// retiredAtExpr is the SQL counterpart of retiredTime, through
+// dialect.epoch.
+func retiredAtExpr(d dialect) string {
+	return `COALESCE((SELECT MAX(` + d.epoch("h.changed_at") + `) FROM bounty_history h
+		WHERE h.url = bounties.url AND h.field = 'status'), ` + d.epoch("last_seen") + `)`
+}
+
+func retiredClause(d dialect, before time.Time) (string, []interface{}) {
+	placeholders := make([]string, len(core.RetiredStatuses))
+	args := make([]interface{}, 0, len(core.RetiredStatuses)+1)
+	for i, status := range core.RetiredStatuses {
+		placeholders[i] = "?"
+		args = append(args, string(status))
+	}
+	return `status IN (` + strings.Join(placeholders, ", ") + `)
+		AND NOT EXISTS (SELECT 1 FROM tracking t WHERE t.url = bounties.url)
+		AND ` + retiredAtExpr(d) + ` < ` + d.epoch("?"), append(args, formatSeen(before))
+}
+
+// RetiredBounties returns up to limit bounties that have been closed or
+// expired since before, longest retired first, with their history and
+// sources. Bounties with a pipeline record are never retired.
+func (s *sqlStore) RetiredBounties(before time.Time, limit int) ([]core.ArchivedBounty, error) {
+	where, args := retiredClause(s.db.dialect, before)
+	rows, err := s.db.Query(`SELECT `+bountyColumns+` FROM bounties WHERE `+where+`
+		ORDER BY `+retiredAtExpr(s.db.dialect)+`, url LIMIT ?`, append(args, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	bounties := scanBounties(rows)
+	rows.Close()
+
+	archived := make([]core.ArchivedBounty, 0, len(bounties))
+	for _, b := range bounties {
+		history, err := s.GetHistory(b.ID)
+		if err != nil {
+			return nil, err
+		}
+		sources, err := s.GetSources(b.ID)
+		if err != nil {
+			return nil, err
+		}
+		archived = append(archived, core.ArchivedBounty{Bounty: b, RetiredAt: retiredTime(b, history), History: history, Sources: sources})
+	}
+	return archived, nil
+}
+
+// DeleteRetired deletes the bounties at urls that are still retired since
+// before, with their history, sources, links and link checks, and returns
+// how many it deleted.
+func (s *sqlStore) DeleteRetired(urls []string, before time.Time) (int, error) {
+	where, args := retiredClause(s.db.dialect, before)
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	deleted := 0
+	for _, url := range urls {
+		res, err := tx.Exec(`DELETE FROM bounties WHERE url = ? AND `+where, append([]interface{}{url}, args...)...)
+		if err != nil {
+			return 0, err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			continue
+		}
+		if _, err := tx.Exec(`DELETE FROM bounty_history WHERE url = ?`, url); err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(`DELETE FROM bounty_sources WHERE bounty_url = ?`, url); err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(`DELETE FROM bounty_links WHERE bounty_url = ?`, url); err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(`DELETE FROM link_checks WHERE url = ?`, url); err != nil {
+			return 0, err
+		}
+		deleted++
+	}
+	return deleted, tx.Commit()
+}
+
+// Vacuum returns the space freed by deleted rows to the filesystem.
+func (s *sqlStore) Vacuum(ctx context.Context) error {
+	stmt := `VACUUM`
+	if s.db.dialect == postgresDialect {
+		stmt = `VACUUM ANALYZE`
+	}
+	_, err := s.db.DB.ExecContext(ctx, stmt)
+	return err
+}
+
+// RecordAudit appends entry to the audit log, stamped now if At is unset.
+func (s *sqlStore) RecordAudit(entry core.AuditEntry) error {
+	if entry.At.IsZero() {
+		entry.At = time.Now()
+	}
+	_, err := s.db.Exec(`INSERT INTO audit_log (action, count, detail, at) VALUES (?, ?, ?, ?)`,
+		entry.Action, entry.Count, entry.Detail, formatSeen(entry.At))
+	return err
+}
+
+// GetAuditLog returns the latest limit audit entries for action, or for
+// every action when it is empty, newest first.
+func (s *sqlStore) GetAuditLog(action string, limit int) ([]core.AuditEntry, error) {
+	rows, err := s.db.Query(`SELECT id, action, COALESCE(count, 0), COALESCE(detail, ''), at FROM audit_log
+		WHERE CAST(? AS TEXT) = '' OR action = ?
+		ORDER BY id DESC LIMIT ?`, action, action, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []core.AuditEntry{}
+	for rows.Next() {
+		var e core.AuditEntry
+		var at string
+		if err := rows.Scan(&e.ID, &e.Action, &e.Count, &e.Detail, &at); err != nil {
+			return nil, err
+		}
+		e.At, _ = parseTime(at)
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+func TestStorage_ApplyRetention(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+		past := time.Now().Add(-time.Hour)
+		for _, b := range []core.Bounty{
+			{URL: "https://example.com/open", Title: "open", CreatedAt: past},
+			{URL: "https://example.com/closed", Title: "closed", CreatedAt: past, Status: core.StatusClosed},
+			{URL: "https://example.com/expired", Title: "expired", CreatedAt: past, ExpiresAt: &past},
+			{URL: "https://example.com/tracked", Title: "tracked", CreatedAt: past, Status: core.StatusClosed},
+		} {
+			if err := store.Save(b); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+		if _, err := store.ExpireDue(time.Now()); err != nil {
+			t.Fatalf("ExpireDue() error = %v", err)
+		}
+		if err := store.RecordSource("https://example.com/expired", core.BountySource{Platform: "TEST", URL: "https://example.com/expired"}); err != nil {
+			t.Fatalf("RecordSource() error = %v", err)
+		}
+		if _, err := store.UpdateTracking(core.BountyID("https://example.com/tracked"), core.TrackingUpdate{}); err != nil {
+			t.Fatalf("UpdateTracking() error = %v", err)
+		}
+
+		policy := RetentionPolicy{After: 30 * 24 * time.Hour, ArchiveDir: t.TempDir(), BatchSize: 1}
+		if removed, err := ApplyRetention(store, policy, time.Now()); err != nil || removed != 0 {
+			t.Fatalf("ApplyRetention(now) = %d, %v; want nothing retired yet", removed, err)
+		}
+
+		removed, err := ApplyRetention(store, policy, time.Now().Add(31*24*time.Hour))
+		if err != nil || removed != 2 {
+			t.Fatalf("ApplyRetention() = %d, %v; want 2 removed", removed, err)
+		}
+		for url, wantNew := range map[string]bool{
+			"https://example.com/open":    false,
+			"https://example.com/closed":  true,
+			"https://example.com/expired": true,
+			"https://example.com/tracked": false,
+		} {
+			if isNew, err := store.IsNew(url); err != nil || isNew != wantNew {
+				t.Errorf("IsNew(%s) = %v, %v; want %v", url, isNew, err, wantNew)
+			}
+		}
+
+		audit, err := store.GetAuditLog(core.AuditArchive, 10)
+		if err != nil || len(audit) != 2 {
+			t.Fatalf("GetAuditLog() = %+v, %v; want one entry per batch", audit, err)
+		}
+		var archived []core.ArchivedBounty
+		for _, entry := range audit {
+			if entry.Count != 1 {
+				t.Errorf("audit entry %+v, want count 1", entry)
+			}
+			archived = append(archived, readArchive(t, entry.Detail)...)
+		}
+		if len(archived) != 2 {
+			t.Fatalf("archives hold %d bounties, want 2", len(archived))
+		}
+		for _, r := range archived {
+			if r.Bounty.URL == "https://example.com/expired" && (len(r.History) != 1 || len(r.Sources) != 1 || r.RetiredAt.IsZero()) {
+				t.Errorf("archived expired bounty = %+v, want its history and source", r)
+			}
+		}
+		if history, err := store.GetHistory(core.BountyID("https://example.com/expired")); err != nil || len(history) != 0 {
+			t.Errorf("GetHistory() after retention = %+v, %v; want nothing", history, err)
+		}
+	})
+}
+
+func TestStorage_ApplyRetentionWithoutArchive(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+		if err := store.Save(core.Bounty{URL: "https://example.com/closed", CreatedAt: time.Now(), Status: core.StatusClosed}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		removed, err := ApplyRetention(store, RetentionPolicy{After: time.Hour}, time.Now().Add(2*time.Hour))
+		if err != nil || removed != 1 {
+			t.Fatalf("ApplyRetention() = %d, %v; want 1 removed", removed, err)
+		}
+		audit, err := store.GetAuditLog("", 10)
+		if err != nil || len(audit) != 1 || audit[0].Action != core.AuditDelete || audit[0].Count != 1 {
+			t.Errorf("GetAuditLog() = %+v, %v; want one delete entry", audit, err)
+		}
+		if err := store.Vacuum(context.Background()); err != nil {
+			t.Errorf("Vacuum() error = %v", err)
+		}
+	})
+}
+
+func readArchive(t *testing.T, path string) []core.ArchivedBounty {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("read archive: %v", err)
+	}
+	var records []core.ArchivedBounty
+	lines := bufio.NewScanner(gz)
+	for lines.Scan() {
+		var r core.ArchivedBounty
+		if err := json.Unmarshal(lines.Bytes(), &r); err != nil {
+			t.Fatalf("archive line %q: %v", lines.Text(), err)
+		}
+		records = append(records, r)
+	}
+	return records
+}
@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// GetScanState returns the value scanner stored under key, or "" if none.
+func (s *sqlStore) GetScanState(scanner, key string) (string, error) {
+	var value string
+	err := s.db.QueryRow(`SELECT COALESCE(value, '') FROM scanner_state WHERE scanner = ? AND key = ?`, scanner, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return value, err
+}
+
+// SetScanState stores value under key for scanner.
+func (s *sqlStore) SetScanState(scanner, key, value string) error {
+	_, err := s.db.Exec(`INSERT INTO scanner_state (scanner, key, value, updated_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT(scanner, key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at`,
+		scanner, key, value, formatSeen(time.Now()))
+	return err
+}
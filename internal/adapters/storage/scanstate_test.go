@@ -0,0 +1,25 @@
+package storage
+
+import "testing"
+
+func TestStorage_ScanState(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+		if value, err := store.GetScanState("github", "cursor:bounty"); err != nil || value != "" {
+			t.Fatalf("GetScanState() before saving = %q, %v; want empty", value, err)
+		}
+		for _, value := range []string{"2024-05-01T10:00:00Z", "2024-05-02T10:00:00Z"} {
+			if err := store.SetScanState("github", "cursor:bounty", value); err != nil {
+				t.Fatalf("SetScanState() error = %v", err)
+			}
+		}
+		if err := store.SetScanState("github", "cursor:polar", "2024-01-01T00:00:00Z"); err != nil {
+			t.Fatalf("SetScanState() error = %v", err)
+		}
+		if value, err := store.GetScanState("github", "cursor:bounty"); err != nil || value != "2024-05-02T10:00:00Z" {
+			t.Errorf("GetScanState() = %q, %v; want the latest value", value, err)
+		}
+		if value, err := store.GetScanState("other", "cursor:bounty"); err != nil || value != "" {
+			t.Errorf("GetScanState() of another scanner = %q, %v; want empty", value, err)
+		}
+	})
+}
@@ -0,0 +1,357 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"bountyos-v8/internal/core"
+)
+
+var searchTriggerNames = []string{"bounties_fts_insert", "bounties_fts_delete", "bounties_fts_update"}
+
+// searchTriggers keep bounties_fts in step with bounties.
+var searchTriggers = []string{
+	`CREATE TRIGGER bounties_fts_insert AFTER INSERT ON bounties BEGIN
+		INSERT INTO bounties_fts (url, title, description, tags) VALUES (new.url, new.title, new.description, new.tags);
+	END`,
+	`CREATE TRIGGER bounties_fts_delete AFTER DELETE ON bounties BEGIN
+		DELETE FROM bounties_fts WHERE url = old.url;
+	END`,
+	`CREATE TRIGGER bounties_fts_update AFTER UPDATE OF url, title, description, tags ON bounties BEGIN
+		DELETE FROM bounties_fts WHERE url = old.url;
+		INSERT INTO bounties_fts (url, title, description, tags) VALUES (new.url, new.title, new.description, new.tags);
+	END`,
+}
+
+// ensureSearchIndex maintains the bounties_fts full-text index when the
+// SQLite build includes FTS5 (go build -tags sqlite_fts5) and reports
+// whether it is available. The index is not a migration because it depends
+// on how the binary was built, not on the database. A build without FTS5
+// drops the sync triggers, which it could not run; the next FTS5 build
+// finds them missing and rebuilds the index.
+func ensureSearchIndex(db *sql.DB) (bool, error) {
+	// A standalone table keyed by url rather than external content keyed by
+	// rowid: VACUUM may renumber the rowids of a table with a TEXT key.
+	_, err := db.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS bounties_fts USING fts5(url UNINDEXED, title, description, tags)`)
+	if err == nil {
+		// IF NOT EXISTS skips the module check for an index an FTS5 build
+		// created; reading it does not.
+		_, err = db.Exec(`SELECT 1 FROM bounties_fts LIMIT 0`)
+	}
+	if err != nil && strings.Contains(err.Error(), "no such module") {
+		for _, name := range searchTriggerNames {
+			if _, err := db.Exec(`DROP TRIGGER IF EXISTS ` + name); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var triggers int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name LIKE 'bounties_fts_%'`).Scan(&triggers); err != nil {
+		return false, err
+	}
+	if triggers == len(searchTriggers) {
+		return true, nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	for _, name := range searchTriggerNames {
+		if _, err := tx.Exec(`DROP TRIGGER IF EXISTS ` + name); err != nil {
+			return false, err
+		}
+	}
+	stmts := append([]string{`DELETE FROM bounties_fts`}, searchTriggers...)
+	stmts = append(stmts, `INSERT INTO bounties_fts (url, title, description, tags) SELECT url, title, description, tags FROM bounties`)
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return false, err
+		}
+	}
+	return true, tx.Commit()
+}
+
+// Search returns bounties matching query and filters, best match first.
+// With FTS5 the query uses its syntax: AND/OR/NOT, "quoted phrases" and
+// prefix* terms over title, description and tags. Postgres takes the same
+// words, phrases, OR and NOT/-term exclusions through its own text search;
+// SQLite without FTS5 matches them as substrings.
+// An empty query lists every bounty that passes the filters, newest first.
+// A malformed query returns an error wrapping core.ErrInvalidQuery.
+func (s *sqlStore) Search(query string, filters core.SearchFilters) (core.SearchResult, error) {
+	if filters.Limit <= 0 {
+		filters.Limit = 20
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+	result := core.SearchResult{Limit: filters.Limit, Offset: filters.Offset, Bounties: []core.Bounty{}}
+
+	where, args := searchFilterClause(s.db.dialect, filters)
+	from := `bounties`
+	order := `created_at DESC`
+	query = strings.TrimSpace(query)
+	if query != "" {
+		switch {
+		case s.db.dialect == postgresDialect:
+			tsquery, err := websearchQuery(query)
+			if err != nil {
+				return result, err
+			}
+			from = `bounties JOIN (SELECT url AS fts_url, ts_rank(` + pgSearchDocument + `, q) AS rank
+				FROM bounties, websearch_to_tsquery('english', ?) q WHERE ` + pgSearchDocument + ` @@ q) ranked ON fts_url = url`
+			args = append([]interface{}{tsquery}, args...)
+			order = `rank DESC, score DESC`
+		case s.fts:
+			from = `bounties JOIN (SELECT url AS fts_url, bm25(bounties_fts) AS rank FROM bounties_fts WHERE bounties_fts MATCH ?) ON fts_url = url`
+			args = append([]interface{}{query}, args...)
+			order = `rank, score DESC`
+		default:
+			clause, likeArgs, err := likeSearchClause(query)
+			if err != nil {
+				return result, err
+			}
+			where = append(where, clause)
+			args = append(args, likeArgs...)
+			order = `score DESC, created_at DESC`
+		}
+	}
+	whereSQL := ""
+	if len(where) > 0 {
+		whereSQL = ` WHERE ` + strings.Join(where, ` AND `)
+	}
+
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM `+from+whereSQL, args...).Scan(&result.Total); err != nil {
+		return result, searchError(err)
+	}
+	rows, err := s.db.Query(`SELECT `+bountyColumns+` FROM `+from+whereSQL+` ORDER BY `+order+` LIMIT ? OFFSET ?`,
+		append(args, filters.Limit, filters.Offset)...)
+	if err != nil {
+		return result, searchError(err)
+	}
+	defer rows.Close()
+
+	if bounties := scanBounties(rows); bounties != nil {
+		result.Bounties = bounties
+	}
+	return result, nil
+}
+
+// searchError maps FTS5 syntax errors onto core.ErrInvalidQuery.
+func searchError(err error) error {
+	msg := err.Error()
+	for _, syntax := range []string{"fts5", "syntax error", "unterminated string", "no such column"} {
+		if strings.Contains(msg, syntax) {
+			return fmt.Errorf("%w: %s", core.ErrInvalidQuery, msg)
+		}
+	}
+	return err
+}
+
+func searchFilterClause(d dialect, f core.SearchFilters) ([]string, []interface{}) {
+	var where []string
+	var args []interface{}
+	if f.Platform != "" {
+		clause, platformArgs := platformClause(f.Platform)
+		where = append(where, clause)
+		args = append(args, platformArgs...)
+	}
+	if f.PaymentType != "" {
+		where = append(where, `payment_type = ?`)
+		args = append(args, strings.ToLower(strings.TrimSpace(f.PaymentType)))
+	}
+	if f.Status != "" {
+		where = append(where, `status = ?`)
+		args = append(args, string(f.Status))
+	}
+	if f.MinScore != nil {
+		where = append(where, `score >= ?`)
+		args = append(args, *f.MinScore)
+	}
+	if f.MaxScore != nil {
+		where = append(where, `score <= ?`)
+		args = append(args, *f.MaxScore)
+	}
+	if f.MinUSD > 0 {
+		where = append(where, `reward_usd >= ?`)
+		args = append(args, f.MinUSD)
+	}
+	if f.MaxUSD > 0 {
+		where = append(where, `reward_usd <= ?`)
+		args = append(args, f.MaxUSD)
+	}
+	// created_at is stored as RFC 3339 in the scanner's zone; compare
+	// through dialect.epoch so offsets are honoured.
+	if !f.CreatedAfter.IsZero() {
+		where = append(where, d.epoch("created_at")+` >= `+d.epoch("?"))
+		args = append(args, formatSeen(f.CreatedAfter))
+	}
+	if !f.CreatedBefore.IsZero() {
+		where = append(where, d.epoch("created_at")+` < `+d.epoch("?"))
+		args = append(args, formatSeen(f.CreatedBefore))
+	}
+	return where, args
+}
+
+// likeSearchClause translates the FTS5-style query subset into LIKE
+// conditions for builds without FTS5.
+func likeSearchClause(query string) (string, []interface{}, error) {
+	groups, err := parseSearchGroups(query)
+	if err != nil {
+		return "", nil, err
+	}
+
+	const haystack = `(COALESCE(title, '') || ' ' || COALESCE(description, '') || ' ' || COALESCE(tags, ''))`
+	var args []interface{}
+	ors := make([]string, 0, len(groups))
+	for _, group := range groups {
+		ands := make([]string, 0, len(group))
+		for _, cond := range group {
+			if cond.negate {
+				ands = append(ands, haystack+` NOT LIKE ? ESCAPE '\'`)
+			} else {
+				ands = append(ands, haystack+` LIKE ? ESCAPE '\'`)
+			}
+			args = append(args, "%"+escapeLike(cond.text)+"%")
+		}
+		ors = append(ors, `(`+strings.Join(ands, ` AND `)+`)`)
+	}
+	return `(` + strings.Join(ors, ` OR `) + `)`, args, nil
+}
+
+// searchCondition is a substring a matching bounty must contain, or with
+// negate must not.
+type searchCondition struct {
+	text   string
+	negate bool
+}
+
+// parseSearchGroups reads the query subset as substring conditions, an OR
+// of AND groups. Terms are ANDed unless joined by OR; NOT or a leading "-"
+// excludes a term, and a trailing "*" is dropped.
+func parseSearchGroups(query string) ([][]searchCondition, error) {
+	terms, err := splitSearchTerms(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups [][]searchCondition
+	var current []searchCondition
+	negate := false
+	for _, term := range terms {
+		switch {
+		case !term.quoted && term.text == "OR":
+			if len(current) == 0 {
+				return nil, fmt.Errorf("%w: OR without a left-hand term", core.ErrInvalidQuery)
+			}
+			groups = append(groups, current)
+			current = nil
+			continue
+		case !term.quoted && term.text == "AND":
+			continue
+		case !term.quoted && term.text == "NOT":
+			negate = true
+			continue
+		}
+
+		text := term.text
+		if !term.quoted && strings.HasPrefix(text, "-") && len(text) > 1 {
+			negate = true
+			text = text[1:]
+		}
+		current = append(current, searchCondition{text: strings.TrimSuffix(text, "*"), negate: negate})
+		negate = false
+	}
+	if len(current) == 0 {
+		return nil, fmt.Errorf("%w: dangling operator", core.ErrInvalidQuery)
+	}
+	return append(groups, current), nil
+}
+
+// websearchQuery rewrites the query subset for Postgres
+// websearch_to_tsquery, which spells NOT as "-" and has no prefix terms.
+func websearchQuery(query string) (string, error) {
+	terms, err := splitSearchTerms(query)
+	if err != nil {
+		return "", err
+	}
+	var words []string
+	pending, negate := false, false
+	for _, term := range terms {
+		switch {
+		case !term.quoted && term.text == "OR":
+			if len(words) == 0 || pending {
+				return "", fmt.Errorf("%w: OR without a left-hand term", core.ErrInvalidQuery)
+			}
+			words = append(words, "or")
+			pending = true
+			continue
+		case !term.quoted && term.text == "AND":
+			continue
+		case !term.quoted && term.text == "NOT":
+			negate = true
+			continue
+		}
+
+		text := term.text
+		if term.quoted {
+			text = `"` + text + `"`
+		} else {
+			text = strings.TrimSuffix(text, "*")
+		}
+		if negate && !strings.HasPrefix(text, "-") {
+			text = "-" + text
+		}
+		words = append(words, text)
+		pending, negate = false, false
+	}
+	if len(words) == 0 || pending || negate {
+		return "", fmt.Errorf("%w: dangling operator", core.ErrInvalidQuery)
+	}
+	return strings.Join(words, " "), nil
+}
+
+type searchTerm struct {
+	text   string
+	quoted bool
+}
+
+func splitSearchTerms(query string) ([]searchTerm, error) {
+	var terms []searchTerm
+	for i := 0; i < len(query); {
+		switch c := query[i]; {
+		case c == ' ' || c == '\t' || c == '(' || c == ')':
+			i++
+		case c == '"':
+			end := strings.IndexByte(query[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated phrase", core.ErrInvalidQuery)
+			}
+			if phrase := strings.TrimSpace(query[i+1 : i+1+end]); phrase != "" {
+				terms = append(terms, searchTerm{text: phrase, quoted: true})
+			}
+			i += end + 2
+		default:
+			end := strings.IndexAny(query[i:], " \t()\"")
+			if end < 0 {
+				end = len(query) - i
+			}
+			terms = append(terms, searchTerm{text: query[i : i+end]})
+			i += end
+		}
+	}
+	return terms, nil
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+func TestStorage_Search(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+
+		now := time.Now()
+		for _, b := range []core.Bounty{
+			{URL: "https://github.com/org/repo/issues/1", Title: "Solana wallet adapter", Description: "Add a Phantom wallet integration",
+				Platform: "GITHUB/BOUNTY", PaymentType: "crypto", Score: 80, RewardUSD: 500, Tags: []string{"rust"}, CreatedAt: now.Add(-48 * time.Hour)},
+			{URL: "https://github.com/org/repo/issues/2", Title: "Fix flaky CI", Description: "The wallet tests time out",
+				Platform: "GITHUB/FUNDED", PaymentType: "fiat", Score: 40, RewardUSD: 100, Tags: []string{"go"}, CreatedAt: now.Add(-24 * time.Hour)},
+			{URL: "https://superteam.fun/listing/docs", Title: "Write adapter docs", Description: "Document the hardware wallet flow",
+				Platform: "SUPERTEAM", PaymentType: "crypto", Score: 60, RewardUSD: 250, Tags: []string{"docs"}, CreatedAt: now},
+		} {
+			if err := store.Save(b); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		minScore := 50
+		tests := []struct {
+			name    string
+			query   string
+			filters core.SearchFilters
+			want    []string
+		}{
+			{"term across fields", "wallet", core.SearchFilters{}, []string{"issues/1", "listing/docs", "issues/2"}},
+			{"implicit and", "wallet adapter", core.SearchFilters{}, []string{"issues/1", "listing/docs"}},
+			{"or", "phantom OR flaky", core.SearchFilters{}, []string{"issues/1", "issues/2"}},
+			{"not", "wallet NOT hardware", core.SearchFilters{}, []string{"issues/1", "issues/2"}},
+			{"phrase", `"hardware wallet"`, core.SearchFilters{}, []string{"listing/docs"}},
+			{"tags", "rust", core.SearchFilters{}, []string{"issues/1"}},
+			{"platform prefix", "wallet", core.SearchFilters{Platform: "github"}, []string{"issues/1", "issues/2"}},
+			{"payment type", "wallet", core.SearchFilters{PaymentType: "crypto"}, []string{"issues/1", "listing/docs"}},
+			{"score and reward", "wallet", core.SearchFilters{MinScore: &minScore, MaxUSD: 300}, []string{"listing/docs"}},
+			{"date range", "", core.SearchFilters{CreatedAfter: now.Add(-36 * time.Hour), CreatedBefore: now.Add(-time.Hour)}, []string{"issues/2"}},
+			{"filters only, newest first", "", core.SearchFilters{PaymentType: "crypto"}, []string{"listing/docs", "issues/1"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				result, err := store.Search(tt.query, tt.filters)
+				if err != nil {
+					t.Fatalf("Search() error = %v", err)
+				}
+				if result.Total != len(tt.want) {
+					t.Fatalf("Search() total = %d, want %d", result.Total, len(tt.want))
+				}
+				for i, suffix := range tt.want {
+					if !strings.HasSuffix(result.Bounties[i].URL, suffix) {
+						t.Errorf("Search() result %d = %s, want .../%s", i, result.Bounties[i].URL, suffix)
+					}
+				}
+			})
+		}
+
+		t.Run("pagination", func(t *testing.T) {
+			result, err := store.Search("wallet", core.SearchFilters{Limit: 2, Offset: 2})
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if result.Total != 3 || len(result.Bounties) != 1 {
+				t.Fatalf("Search() total = %d, page = %d, want 3 and 1", result.Total, len(result.Bounties))
+			}
+		})
+
+		t.Run("index follows updates", func(t *testing.T) {
+			updated := core.Bounty{URL: "https://github.com/org/repo/issues/2", Title: "Fix flaky CI", Description: "Runner images are stale",
+				Platform: "GITHUB/FUNDED", PaymentType: "fiat", Score: 40, CreatedAt: now.Add(-24 * time.Hour)}
+			if err := store.Save(updated); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			result, err := store.Search("wallet", core.SearchFilters{})
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if result.Total != 2 {
+				t.Errorf("Search() total = %d after update, want 2", result.Total)
+			}
+		})
+
+		t.Run("invalid query", func(t *testing.T) {
+			if _, err := store.Search(`"unterminated`, core.SearchFilters{}); !errors.Is(err, core.ErrInvalidQuery) {
+				t.Errorf("Search() error = %v, want ErrInvalidQuery", err)
+			}
+		})
+	})
+}
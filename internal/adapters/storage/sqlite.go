@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
 	"time"
 
 	"bountyos-v8/internal/core"
@@ -12,48 +15,87 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// ErrNotFound is returned when a lookup matches no stored bounty.
+var ErrNotFound = errors.New("bounty not found")
+
+// SQLiteStorage stores bounties in a local SQLite file.
 type SQLiteStorage struct {
-	db *sql.DB
+	sqlStore
 }
 
+// NewSQLiteStorage opens the database at dbPath and applies any pending
+// schema migrations.
 func NewSQLiteStorage(dbPath string) (*SQLiteStorage, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := openSQLite(dbPath)
 	if err != nil {
 		return nil, err
 	}
-
-	// Create the table
-	query := `CREATE TABLE IF NOT EXISTS bounties (
-		url TEXT PRIMARY KEY,
-		title TEXT,
-		platform TEXT,
-		reward TEXT,
-		currency TEXT,
-		created_at DATETIME,
-		score INTEGER,
-		description TEXT,
-		tags TEXT,
-		expires_at DATETIME,
-		payment_type TEXT
-	);`
-	_, err = db.Exec(query)
+	if _, err := migrateUp(db, migrations); err != nil {
+		db.Close()
+		return nil, err
+	}
+	fts, err := ensureSearchIndex(db.DB)
 	if err != nil {
+		db.Close()
 		return nil, err
 	}
+	return &SQLiteStorage{sqlStore{db: db, fts: fts}}, nil
+}
 
-	return &SQLiteStorage{db: db}, nil
+func openSQLite(dbPath string) (*database, error) {
+	// Background jobs write alongside ingest; wait for locks instead of
+	// failing with SQLITE_BUSY.
+	dsn := dbPath
+	if !strings.Contains(dsn, "?") {
+		dsn += "?_busy_timeout=5000"
+	}
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &database{DB: db, dialect: sqliteDialect}, nil
 }
 
-func (s *SQLiteStorage) Save(bounty core.Bounty) error {
+func (s *sqlStore) Save(bounty core.Bounty) error {
 	// Convert tags to JSON string
 	tagsJSON, err := json.Marshal(bounty.Tags)
 	if err != nil {
 		return err
 	}
 
-	query := `INSERT OR REPLACE INTO bounties 
-		(url, title, platform, reward, currency, created_at, score, description, tags, expires_at, payment_type) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	breakdownJSON, err := json.Marshal(bounty.ScoreBreakdown)
+	if err != nil {
+		return err
+	}
+
+	status := bounty.Status
+	if status == "" {
+		status = core.StatusOpen
+	}
+	now := time.Now()
+	firstSeen, lastSeen := bounty.FirstSeen, bounty.LastSeen
+	if firstSeen.IsZero() {
+		firstSeen = now
+	}
+	if lastSeen.IsZero() {
+		lastSeen = now
+	}
+
+	// Upsert so a re-saved bounty keeps its first_seen.
+	query := `INSERT INTO bounties 
+		(url, title, platform, reward, currency, created_at, score, description, tags, expires_at, payment_type,
+		 reward_min, reward_max, reward_token, reward_exact, reward_usd, id, score_breakdown,
+		 status, first_seen, last_seen, canonical_url) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(url) DO UPDATE SET
+		 title = excluded.title, platform = excluded.platform, reward = excluded.reward,
+		 currency = excluded.currency, created_at = excluded.created_at, score = excluded.score,
+		 description = excluded.description, tags = excluded.tags, expires_at = excluded.expires_at,
+		 payment_type = excluded.payment_type, reward_min = excluded.reward_min,
+		 reward_max = excluded.reward_max, reward_token = excluded.reward_token,
+		 reward_exact = excluded.reward_exact, reward_usd = excluded.reward_usd, id = excluded.id,
+		 score_breakdown = excluded.score_breakdown, status = excluded.status, last_seen = excluded.last_seen,
+		 canonical_url = excluded.canonical_url`
 
 	var expiresAt *string
 	if bounty.ExpiresAt != nil {
@@ -63,7 +105,13 @@ func (s *SQLiteStorage) Save(bounty core.Bounty) error {
 		expiresAt = nil
 	}
 
-	_, err = s.db.Exec(query,
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(query,
 		bounty.URL,
 		bounty.Title,
 		bounty.Platform,
@@ -75,12 +123,79 @@ func (s *SQLiteStorage) Save(bounty core.Bounty) error {
 		string(tagsJSON),
 		expiresAt,
 		bounty.PaymentType,
+		bounty.RewardMin,
+		bounty.RewardMax,
+		bounty.RewardToken,
+		bounty.RewardExact,
+		bounty.RewardUSD,
+		core.BountyID(bounty.URL),
+		string(breakdownJSON),
+		string(status),
+		formatSeen(firstSeen),
+		formatSeen(lastSeen),
+		core.CanonicalURL(bounty.URL),
 	)
+	if err != nil {
+		return err
+	}
+	if err := saveLinks(tx, bounty.URL, bounty.Description); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
 
+// SaveRejection records why a bounty was filtered out, replacing any earlier
+// rejection of the same URL.
+func (s *sqlStore) SaveRejection(r core.Rejection) error {
+	_, err := s.db.Exec(`INSERT INTO rejections
+		(url, title, platform, reward, currency, reward_usd, reason, stage, rejected_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(url) DO UPDATE SET
+		 title = excluded.title, platform = excluded.platform, reward = excluded.reward,
+		 currency = excluded.currency, reward_usd = excluded.reward_usd, reason = excluded.reason,
+		 stage = excluded.stage, rejected_at = excluded.rejected_at`,
+		r.URL,
+		r.Title,
+		r.Platform,
+		r.Reward,
+		r.Currency,
+		r.RewardUSD,
+		r.Reason,
+		string(r.Stage),
+		r.RejectedAt.Format(time.RFC3339),
+	)
 	return err
 }
 
-func (s *SQLiteStorage) IsNew(url string) (bool, error) {
+// GetRejections returns the most recent rejections, newest first.
+func (s *sqlStore) GetRejections(limit int) ([]core.Rejection, error) {
+	rows, err := s.db.Query(`SELECT url, title, platform, reward, currency, reward_usd, reason, stage, rejected_at
+		FROM rejections
+		ORDER BY rejected_at DESC
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rejections []core.Rejection
+	for rows.Next() {
+		var r core.Rejection
+		var stage, rejectedAt string
+		if err := rows.Scan(&r.URL, &r.Title, &r.Platform, &r.Reward, &r.Currency, &r.RewardUSD, &r.Reason, &stage, &rejectedAt); err != nil {
+			security.GetLogger().Error("Error scanning rejection: %v", err)
+			continue
+		}
+		r.Stage = core.FilterStage(stage)
+		if t, err := parseTime(rejectedAt); err == nil {
+			r.RejectedAt = t
+		}
+		rejections = append(rejections, r)
+	}
+	return rejections, nil
+}
+
+func (s *sqlStore) IsNew(url string) (bool, error) {
 	var exists int
 	err := s.db.QueryRow("SELECT 1 FROM bounties WHERE url = ?", url).Scan(&exists)
 	if err != nil && err != sql.ErrNoRows {
@@ -90,9 +205,14 @@ func (s *SQLiteStorage) IsNew(url string) (bool, error) {
 	return exists == 0, nil
 }
 
-func (s *SQLiteStorage) GetRecent(limit int) ([]core.Bounty, error) {
-	query := `SELECT url, title, platform, reward, currency, created_at, score, description, tags, expires_at, payment_type
+const bountyColumns = `url, title, platform, reward, currency, created_at, score, description, tags, expires_at, payment_type,
+		reward_min, reward_max, reward_token, reward_exact, reward_usd, id, score_breakdown,
+		status, first_seen, last_seen`
+
+func (s *sqlStore) GetRecent(limit int) ([]core.Bounty, error) {
+	query := `SELECT ` + bountyColumns + `
 		FROM bounties 
+		WHERE status = 'open'
 		ORDER BY created_at DESC 
 		LIMIT ?`
 
@@ -102,11 +222,116 @@ func (s *SQLiteStorage) GetRecent(limit int) ([]core.Bounty, error) {
 	}
 	defer rows.Close()
 
+	return scanBounties(rows), nil
+}
+
+// Rescore recomputes every open bounty's score with score and writes back
+// those that changed. It returns the number of updated rows.
+func (s *sqlStore) Rescore(score func(*core.Bounty) core.ScoreResult) (int, error) {
+	rows, err := s.db.Query(`SELECT ` + bountyColumns + ` FROM bounties WHERE status = 'open'`)
+	if err != nil {
+		return 0, err
+	}
+	bounties := scanBounties(rows)
+	rows.Close()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`UPDATE bounties SET score = ?, score_breakdown = ? WHERE url = ?`)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	updated := 0
+	for i := range bounties {
+		bounty := &bounties[i]
+		result := score(bounty)
+		if result.Total == bounty.Score && sameBreakdown(result.Breakdown, bounty.ScoreBreakdown) {
+			continue
+		}
+		breakdownJSON, err := json.Marshal(result.Breakdown)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := stmt.Exec(result.Total, string(breakdownJSON), bounty.URL); err != nil {
+			return 0, err
+		}
+		updated++
+	}
+
+	return updated, tx.Commit()
+}
+
+func sameBreakdown(a, b []core.ScoreContribution) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// GetByID returns the bounty stored under id, or ErrNotFound.
+func (s *sqlStore) GetByID(id string) (core.Bounty, error) {
+	rows, err := s.db.Query(`SELECT `+bountyColumns+` FROM bounties WHERE id = ?`, id)
+	if err != nil {
+		return core.Bounty{}, err
+	}
+	defer rows.Close()
+
+	bounties := scanBounties(rows)
+	if len(bounties) == 0 {
+		return core.Bounty{}, ErrNotFound
+	}
+	return bounties[0], nil
+}
+
+// GetByReward returns open bounties whose parsed maximum reward lies within
+// [minAmount, maxAmount], highest first. A maxAmount of 0 means no upper
+// bound; a non-empty token restricts results to that symbol.
+func (s *sqlStore) GetByReward(minAmount, maxAmount float64, token string, limit int) ([]core.Bounty, error) {
+	where := `status = 'open' AND reward_max >= ?`
+	args := []interface{}{minAmount}
+	if maxAmount != 0 {
+		where += ` AND reward_max <= ?`
+		args = append(args, maxAmount)
+	}
+	if token = strings.ToUpper(strings.TrimSpace(token)); token != "" {
+		where += ` AND reward_token = ?`
+		args = append(args, token)
+	}
+	query := `SELECT ` + bountyColumns + `
+		FROM bounties
+		WHERE ` + where + `
+		ORDER BY reward_max DESC, created_at DESC
+		LIMIT ?`
+
+	rows, err := s.db.Query(query, append(args, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanBounties(rows), nil
+}
+
+func scanBounties(rows *sql.Rows) []core.Bounty {
 	var bounties []core.Bounty
 	for rows.Next() {
 		var bounty core.Bounty
 		var createdAtStr, expiresAtStr sql.NullString
-		var tagsStr sql.NullString
+		var tagsStr, rewardToken, id, breakdownStr sql.NullString
+		var status, firstSeen, lastSeen sql.NullString
+		var rewardMin, rewardMax, rewardUSD sql.NullFloat64
+		var rewardExact sql.NullBool
 
 		err := rows.Scan(
 			&bounty.URL,
@@ -120,6 +345,16 @@ func (s *SQLiteStorage) GetRecent(limit int) ([]core.Bounty, error) {
 			&tagsStr,
 			&expiresAtStr,
 			&bounty.PaymentType,
+			&rewardMin,
+			&rewardMax,
+			&rewardToken,
+			&rewardExact,
+			&rewardUSD,
+			&id,
+			&breakdownStr,
+			&status,
+			&firstSeen,
+			&lastSeen,
 		)
 		if err != nil {
 			security.GetLogger().Error("Error scanning bounty: %v", err)
@@ -152,59 +387,101 @@ func (s *SQLiteStorage) GetRecent(limit int) ([]core.Bounty, error) {
 			}
 		}
 
+		bounty.RewardMin = rewardMin.Float64
+		bounty.RewardMax = rewardMax.Float64
+		bounty.RewardToken = rewardToken.String
+		bounty.RewardExact = rewardExact.Bool
+		bounty.RewardUSD = rewardUSD.Float64
+		bounty.ID = id.String
+		bounty.Status = core.BountyStatus(status.String)
+		if bounty.Status == "" {
+			bounty.Status = core.StatusOpen
+		}
+		if firstSeen.Valid {
+			bounty.FirstSeen, _ = parseTime(firstSeen.String)
+		}
+		if lastSeen.Valid {
+			bounty.LastSeen, _ = parseTime(lastSeen.String)
+		}
+
+		if breakdownStr.Valid && breakdownStr.String != "" {
+			var breakdown []core.ScoreContribution
+			if err := json.Unmarshal([]byte(breakdownStr.String), &breakdown); err == nil {
+				bounty.ScoreBreakdown = breakdown
+			}
+		}
+
 		bounties = append(bounties, bounty)
 	}
 
-	return bounties, nil
+	return bounties
 }
 
-func (s *SQLiteStorage) Close() error {
+func (s *sqlStore) Close() error {
 	return s.db.Close()
 }
 
-func (s *SQLiteStorage) PurgeInvalidURLs(ctx context.Context, validateHTTP bool, timeout time.Duration) (int, error) {
+// PurgeInvalidURLs normalizes stored URLs and deletes bounties whose URL is
+// invalid or, when links is non-nil, that links reports unreachable.
+func (s *sqlStore) PurgeInvalidURLs(ctx context.Context, links *security.LinkValidator) (int, error) {
 	rows, err := s.db.Query("SELECT url FROM bounties")
 	if err != nil {
 		return 0, err
 	}
-	defer rows.Close()
-
-	removed := 0
+	var urls []string
 	for rows.Next() {
 		var urlStr string
 		if err := rows.Scan(&urlStr); err != nil {
 			continue
 		}
+		urls = append(urls, urlStr)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
 
+	removed := 0
+	remove := func(urlStr string) error {
+		deleted, err := s.Delete(urlStr)
+		if deleted {
+			removed++
+		}
+		return err
+	}
+	valid := make([]string, 0, len(urls))
+	for _, urlStr := range urls {
 		normalized := security.NormalizeURL(urlStr)
 		if normalized == "" {
-			if _, err := s.db.Exec("DELETE FROM bounties WHERE url = ?", urlStr); err == nil {
-				removed++
+			if err := remove(urlStr); err != nil {
+				return removed, err
 			}
 			continue
 		}
 
 		if normalized != urlStr {
-			if _, err := s.db.Exec("UPDATE bounties SET url = ? WHERE url = ?", normalized, urlStr); err == nil {
+			renamed, err := s.renameURL(urlStr, normalized)
+			if err != nil {
+				return removed, err
+			}
+			if renamed {
 				urlStr = normalized
 			}
 		}
 
 		if !security.ValidateURL(urlStr) {
-			if _, err := s.db.Exec("DELETE FROM bounties WHERE url = ?", urlStr); err == nil {
-				removed++
+			if err := remove(urlStr); err != nil {
+				return removed, err
 			}
 			continue
 		}
+		valid = append(valid, urlStr)
+	}
 
-		if validateHTTP {
-			checkCtx, cancel := context.WithTimeout(ctx, timeout)
-			ok := security.ValidateURLReachable(checkCtx, urlStr, timeout)
-			cancel()
-			if !ok {
-				if _, err := s.db.Exec("DELETE FROM bounties WHERE url = ?", urlStr); err == nil {
-					removed++
-				}
+	if links != nil {
+		for _, urlStr := range links.Unreachable(ctx, valid) {
+			if err := remove(urlStr); err != nil {
+				return removed, err
 			}
 		}
 	}
@@ -212,6 +489,130 @@ func (s *SQLiteStorage) PurgeInvalidURLs(ctx context.Context, validateHTTP bool,
 	return removed, nil
 }
 
+// renameURL moves the bounty at from, and everything recorded against it,
+// to the URL to. It reports false when from is gone or to is already stored.
+func (s *sqlStore) renameURL(from, to string) (bool, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var taken int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM bounties WHERE url = ?`, to).Scan(&taken); err != nil || taken > 0 {
+		return false, err
+	}
+	res, err := tx.Exec(`UPDATE bounties SET url = ?, id = ?, canonical_url = ? WHERE url = ?`,
+		to, core.BountyID(to), core.CanonicalURL(to), from)
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+	for _, stmt := range []string{
+		`UPDATE bounty_history SET url = ? WHERE url = ?`,
+		`UPDATE bounty_sources SET bounty_url = ? WHERE bounty_url = ?`,
+		`UPDATE bounty_links SET bounty_url = ? WHERE bounty_url = ?`,
+		`UPDATE tracking SET url = ? WHERE url = ?`,
+		`UPDATE ledger SET url = ? WHERE url = ?`,
+	} {
+		if _, err := tx.Exec(stmt, to, from); err != nil {
+			return false, err
+		}
+	}
+	return true, tx.Commit()
+}
+
+// Delete removes the bounty stored under url with its history, sources and
+// links.
+// It reports false when there is none.
+func (s *sqlStore) Delete(url string) (bool, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`DELETE FROM bounties WHERE url = ?`, url)
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+	if _, err := tx.Exec(`DELETE FROM bounty_history WHERE url = ?`, url); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(`DELETE FROM bounty_sources WHERE bounty_url = ?`, url); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(`DELETE FROM bounty_links WHERE bounty_url = ?`, url); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// backfillIDs assigns URL-derived IDs to rows saved before IDs were stored.
+func backfillIDs(db dbtx) error {
+	rows, err := db.Query(`SELECT url FROM bounties WHERE id IS NULL OR id = ''`)
+	if err != nil {
+		return err
+	}
+	var urls []string
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			rows.Close()
+			return err
+		}
+		urls = append(urls, url)
+	}
+	rows.Close()
+
+	for _, url := range urls {
+		if _, err := db.Exec(`UPDATE bounties SET id = ? WHERE url = ?`, core.BountyID(url), url); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureColumns adds any of the given columns missing from table.
+func ensureColumns(db dbtx, table string, columns map[string]string) error {
+	rows, err := db.Query("PRAGMA table_info(" + table + ")")
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[name] = true
+	}
+	rows.Close()
+
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if existing[name] {
+			continue
+		}
+		if _, err := db.Exec("ALTER TABLE " + table + " ADD COLUMN " + name + " " + columns[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func parseTime(timeStr string) (time.Time, error) {
 	return time.Parse(time.RFC3339, timeStr)
 }
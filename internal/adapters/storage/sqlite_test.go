@@ -1,85 +1,308 @@
 package storage
 
 import (
-	"os"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"bountyos-v8/internal/core"
 )
 
-func TestSQLiteStorage(t *testing.T) {
-	// Create a temp file for the database
-	tmpfile, err := os.CreateTemp("", "testdb-*.sqlite")
+func TestStorage(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+
+		// Test Data
+		bounty := core.Bounty{
+			URL:         "https://example.com/bounty/1",
+			Title:       "Test Bounty",
+			Platform:    "TEST",
+			Reward:      "100 USDC",
+			Currency:    "USDC",
+			CreatedAt:   time.Now(),
+			Score:       85,
+			Description: "This is a test bounty",
+			Tags:        []string{"test", "urgent"},
+			PaymentType: "crypto",
+		}
+
+		// 1. Test IsNew (should be true initially)
+		isNew, err := store.IsNew(bounty.URL)
+		if err != nil {
+			t.Errorf("IsNew() error = %v", err)
+		}
+		if !isNew {
+			t.Errorf("IsNew() = false, want true for new bounty")
+		}
+
+		// 2. Test Save
+		if err := store.Save(bounty); err != nil {
+			t.Errorf("Save() error = %v", err)
+		}
+
+		// 3. Test IsNew (should be false now)
+		isNew, err = store.IsNew(bounty.URL)
+		if err != nil {
+			t.Errorf("IsNew() error = %v", err)
+		}
+		if isNew {
+			t.Errorf("IsNew() = true, want false for existing bounty")
+		}
+
+		// 4. Test GetRecent
+		recent, err := store.GetRecent(10)
+		if err != nil {
+			t.Errorf("GetRecent() error = %v", err)
+		}
+		if len(recent) != 1 {
+			t.Errorf("GetRecent() count = %d, want 1", len(recent))
+		}
+		if len(recent) > 0 {
+			got := recent[0]
+			if got.URL != bounty.URL {
+				t.Errorf("GetRecent() URL = %s, want %s", got.URL, bounty.URL)
+			}
+			if got.Title != bounty.Title {
+				t.Errorf("GetRecent() Title = %s, want %s", got.Title, bounty.Title)
+			}
+			// Check tags
+			if len(got.Tags) != 2 {
+				t.Errorf("GetRecent() Tags count = %d, want 2", len(got.Tags))
+			}
+		}
+	})
+}
+
+func TestSQLiteStorage_RewardFields(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reward.sqlite")
+
+	// Start from the pre-structured-reward schema to exercise the column upgrade.
+	legacy, err := sql.Open("sqlite3", dbPath)
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer os.Remove(tmpfile.Name()) // clean up
-	dbPath := tmpfile.Name()
+	if _, err := legacy.Exec(`CREATE TABLE bounties (
+		url TEXT PRIMARY KEY, title TEXT, platform TEXT, reward TEXT, currency TEXT,
+		created_at DATETIME, score INTEGER, description TEXT, tags TEXT,
+		expires_at DATETIME, payment_type TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+	legacy.Close()
 
-	// Initialize storage
 	store, err := NewSQLiteStorage(dbPath)
 	if err != nil {
-		t.Fatalf("Failed to create storage: %v", err)
+		t.Fatalf("Failed to open legacy storage: %v", err)
 	}
 	defer store.Close()
 
-	// Test Data
-	bounty := core.Bounty{
-		URL:         "https://example.com/bounty/1",
-		Title:       "Test Bounty",
-		Platform:    "TEST",
-		Reward:      "100 USDC",
-		Currency:    "USDC",
-		CreatedAt:   time.Now(),
-		Score:       85,
-		Description: "This is a test bounty",
-		Tags:        []string{"test", "urgent"},
-		PaymentType: "crypto",
+	for i, b := range []core.Bounty{
+		{Reward: "$250", Currency: "USD", RewardMin: 250, RewardMax: 250, RewardToken: "USD", RewardExact: true, RewardUSD: 250},
+		{Reward: "500-1000", Currency: "USDC", RewardMin: 500, RewardMax: 1000, RewardToken: "USDC"},
+		{Reward: "Funded", Currency: "USDC"},
+	} {
+		b.URL = fmt.Sprintf("https://example.com/bounty/%d", i)
+		b.Title = b.Reward
+		b.CreatedAt = time.Now()
+		if err := store.Save(b); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
 	}
 
-	// 1. Test IsNew (should be true initially)
-	isNew, err := store.IsNew(bounty.URL)
+	got, err := store.GetByReward(200, 0, "", 10)
 	if err != nil {
-		t.Errorf("IsNew() error = %v", err)
+		t.Fatalf("GetByReward() error = %v", err)
 	}
-	if !isNew {
-		t.Errorf("IsNew() = false, want true for new bounty")
+	if len(got) != 2 || got[0].RewardMax != 1000 || got[1].RewardMax != 250 {
+		t.Fatalf("GetByReward() = %+v, want 1000 then 250", got)
 	}
-
-	// 2. Test Save
-	if err := store.Save(bounty); err != nil {
-		t.Errorf("Save() error = %v", err)
+	if got[0].RewardMin != 500 || got[0].RewardToken != "USDC" || got[0].RewardExact {
+		t.Errorf("range reward round-trip = %+v", got[0])
 	}
-
-	// 3. Test IsNew (should be false now)
-	isNew, err = store.IsNew(bounty.URL)
-	if err != nil {
-		t.Errorf("IsNew() error = %v", err)
-	}
-	if isNew {
-		t.Errorf("IsNew() = true, want false for existing bounty")
+	if !got[1].RewardExact || got[1].RewardUSD != 250 {
+		t.Errorf("exact reward or USD value lost on round-trip: %+v", got[1])
 	}
 
-	// 4. Test GetRecent
-	recent, err := store.GetRecent(10)
+	got, err = store.GetByReward(0, 500, "usd", 10)
 	if err != nil {
-		t.Errorf("GetRecent() error = %v", err)
+		t.Fatalf("GetByReward() error = %v", err)
 	}
-	if len(recent) != 1 {
-		t.Errorf("GetRecent() count = %d, want 1", len(recent))
+	if len(got) != 1 || got[0].RewardToken != "USD" {
+		t.Fatalf("GetByReward(token=usd) = %+v, want the $250 bounty", got)
 	}
-	if len(recent) > 0 {
-		got := recent[0]
-		if got.URL != bounty.URL {
-			t.Errorf("GetRecent() URL = %s, want %s", got.URL, bounty.URL)
+}
+
+func TestStorage_Rejections(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+
+		bounty := core.Bounty{URL: "https://example.com/bounty/cheap", Title: "Cheap", Reward: "$5", RewardUSD: 5}
+		first := core.NewRejection(bounty, "reward $5 below MIN_USD_VALUE $100", core.FilterBeforeSave)
+		first.RejectedAt = time.Now().Add(-time.Hour)
+		if err := store.SaveRejection(first); err != nil {
+			t.Fatalf("SaveRejection() error = %v", err)
 		}
-		if got.Title != bounty.Title {
-			t.Errorf("GetRecent() Title = %s, want %s", got.Title, bounty.Title)
+		// A later rejection of the same URL replaces the earlier one.
+		if err := store.SaveRejection(core.NewRejection(bounty, "reward $5 below MIN_USD_VALUE $50", core.FilterBeforeAlert)); err != nil {
+			t.Fatalf("SaveRejection() error = %v", err)
 		}
-		// Check tags
-		if len(got.Tags) != 2 {
-			t.Errorf("GetRecent() Tags count = %d, want 2", len(got.Tags))
+
+		got, err := store.GetRejections(10)
+		if err != nil {
+			t.Fatalf("GetRejections() error = %v", err)
 		}
-	}
+		if len(got) != 1 {
+			t.Fatalf("GetRejections() count = %d, want 1", len(got))
+		}
+		if got[0].Reason != "reward $5 below MIN_USD_VALUE $50" || got[0].Stage != core.FilterBeforeAlert || got[0].RewardUSD != 5 {
+			t.Errorf("GetRejections() = %+v", got[0])
+		}
+
+		isNew, err := store.IsNew(bounty.URL)
+		if err != nil || !isNew {
+			t.Errorf("rejection should not mark the bounty as seen: isNew=%v err=%v", isNew, err)
+		}
+	})
+}
+
+func TestStorage_ScoreBreakdownAndID(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+
+		bounty := core.Bounty{
+			URL:       "https://example.com/bounty/scored",
+			Title:     "Scored",
+			CreatedAt: time.Now(),
+			Score:     90,
+			ScoreBreakdown: []core.ScoreContribution{
+				{Rule: "payment-crypto", Match: "USDC", Points: 50},
+				{Rule: "recency-super-fresh", Match: "age 0s", Points: 40},
+			},
+		}
+		if err := store.Save(bounty); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		id := core.BountyID(bounty.URL)
+		got, err := store.GetByID(id)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.ID != id || got.Score != 90 {
+			t.Errorf("GetByID() = %+v", got)
+		}
+		if len(got.ScoreBreakdown) != 2 || got.ScoreBreakdown[0] != bounty.ScoreBreakdown[0] {
+			t.Errorf("ScoreBreakdown round-trip = %+v", got.ScoreBreakdown)
+		}
+
+		if _, err := store.GetByID("missing"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetByID(missing) error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestStorage_Rescore(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+
+		for i, score := range []int{10, 40} {
+			b := core.Bounty{URL: fmt.Sprintf("https://example.com/bounty/%d", i), Title: "t", CreatedAt: time.Now(), Score: score}
+			if err := store.Save(b); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		flat := func(b *core.Bounty) core.ScoreResult {
+			return core.ScoreResult{Total: 40, Breakdown: []core.ScoreContribution{{Rule: "flat", Points: 40}}}
+		}
+		updated, err := store.Rescore(flat)
+		if err != nil {
+			t.Fatalf("Rescore() error = %v", err)
+		}
+		if updated != 2 {
+			t.Errorf("Rescore() updated = %d, want 2 (score or breakdown changed)", updated)
+		}
+
+		if updated, err = store.Rescore(flat); err != nil || updated != 0 {
+			t.Errorf("second Rescore() = %d, %v; want no changes", updated, err)
+		}
+
+		recent, err := store.GetRecent(10)
+		if err != nil {
+			t.Fatalf("GetRecent() error = %v", err)
+		}
+		for _, b := range recent {
+			if b.Score != 40 || len(b.ScoreBreakdown) != 1 {
+				t.Errorf("bounty not re-scored: %+v", b)
+			}
+		}
+	})
+}
+
+func TestStorage_PurgeInvalidURLs(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+		seed := func(url string) {
+			t.Helper()
+			b := core.Bounty{URL: url, Title: "before", Platform: "TEST", CreatedAt: time.Now()}
+			if err := store.Save(b); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			if err := store.RecordSource(url, core.BountySource{Platform: "TEST", URL: url, Match: core.MatchURL}); err != nil {
+				t.Fatalf("RecordSource() error = %v", err)
+			}
+			b.Title = "after"
+			if _, err := store.Refresh(b); err != nil {
+				t.Fatalf("Refresh() error = %v", err)
+			}
+		}
+		seed("https://example.com/bounty/renamed.")
+		seed("not a url")
+
+		removed, err := store.PurgeInvalidURLs(context.Background(), nil)
+		if err != nil || removed != 1 {
+			t.Fatalf("PurgeInvalidURLs() = %d, %v; want 1", removed, err)
+		}
+
+		// The normalized bounty keeps its history and sources.
+		id := core.BountyID("https://example.com/bounty/renamed")
+		if history, err := store.GetHistory(id); err != nil || len(history) != 1 {
+			t.Errorf("GetHistory(renamed) = %v, %v; want 1 change", history, err)
+		}
+		if sources, err := store.GetSources(id); err != nil || len(sources) != 1 {
+			t.Errorf("GetSources(renamed) = %v, %v; want 1 source", sources, err)
+		}
+
+		// The removed bounty leaves nothing behind for a later one at its URL.
+		if err := store.Save(core.Bounty{URL: "not a url", Title: "again", Platform: "TEST"}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		id = core.BountyID("not a url")
+		if history, _ := store.GetHistory(id); len(history) != 0 {
+			t.Errorf("GetHistory(removed) = %v; want none", history)
+		}
+		if sources, _ := store.GetSources(id); len(sources) != 0 {
+			t.Errorf("GetSources(removed) = %v; want none", sources)
+		}
+	})
+}
+
+func TestStorage_Delete(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+		b := core.Bounty{URL: "https://example.com/bounty/gone", Title: "t", Platform: "TEST", CreatedAt: time.Now()}
+		if err := store.Save(b); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := store.RecordSource(b.URL, core.BountySource{Platform: "TEST", Match: core.MatchURL}); err != nil {
+			t.Fatalf("RecordSource() error = %v", err)
+		}
+
+		if deleted, err := store.Delete(b.URL); err != nil || !deleted {
+			t.Fatalf("Delete() = %v, %v; want true", deleted, err)
+		}
+		if isNew, err := store.IsNew(b.URL); err != nil || !isNew {
+			t.Errorf("IsNew() after Delete = %v, %v; want true", isNew, err)
+		}
+		if deleted, err := store.Delete(b.URL); err != nil || deleted {
+			t.Errorf("second Delete() = %v, %v; want false", deleted, err)
+		}
+	})
 }
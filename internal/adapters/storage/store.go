@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"bountyos-v8/internal/core"
+	"bountyos-v8/internal/security"
+)
+
+// Storage drivers selectable with STORAGE_DRIVER.
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+	DriverMemory   = "memory"
+)
+
+// Store is everything the application persists. SQLiteStorage,
+// PostgresStorage and MemoryStorage implement it.
+type Store interface {
+	core.Storage
+
+	GetByID(id string) (core.Bounty, error)
+	GetByReward(minAmount, maxAmount float64, token string, limit int) ([]core.Bounty, error)
+	Query(q core.BountyQuery) (core.BountyPage, error)
+	Stats(q core.BountyQuery) (core.BountyStats, error)
+	Search(query string, filters core.SearchFilters) (core.SearchResult, error)
+	Rescore(score func(*core.Bounty) core.ScoreResult) (int, error)
+	PurgeInvalidURLs(ctx context.Context, links *security.LinkValidator) (int, error)
+	Delete(url string) (bool, error)
+
+	Refresh(bounty core.Bounty) ([]core.BountyChange, error)
+	CloseMissing(platform string, cutoff time.Time) (int, error)
+	ExpireDue(now time.Time) (int, error)
+	GetHistory(id string) ([]core.BountyChange, error)
+
+	FindDuplicate(b core.Bounty, opts core.DedupOptions) (core.Bounty, string, error)
+	RecordSource(bountyURL string, src core.BountySource) error
+	GetSources(id string) ([]core.BountySource, error)
+
+	SaveRejection(r core.Rejection) error
+	GetRejections(limit int) ([]core.Rejection, error)
+
+	GetTracking(id string) (core.Tracking, error)
+	UpdateTracking(id string, update core.TrackingUpdate) (core.Tracking, error)
+	DeleteTracking(id string) error
+	ListTracking(state core.PipelineState) ([]core.Tracking, error)
+
+	RecordPayment(id string, entry core.LedgerEntry) (core.LedgerEntry, error)
+	GetLedger() ([]core.LedgerEntry, error)
+	UnreconciledPayments() ([]core.Tracking, error)
+
+	RetiredBounties(before time.Time, limit int) ([]core.ArchivedBounty, error)
+	DeleteRetired(urls []string, before time.Time) (int, error)
+	Vacuum(ctx context.Context) error
+	RecordAudit(entry core.AuditEntry) error
+	GetAuditLog(action string, limit int) ([]core.AuditEntry, error)
+
+	LinkCheck(url string, since time.Time) (reachable, found bool, err error)
+	SaveLinkCheck(url string, reachable bool, checkedAt time.Time) error
+
+	GetScanState(scanner, key string) (string, error)
+	SetScanState(scanner, key, value string) error
+}
+
+var (
+	_ Store = (*SQLiteStorage)(nil)
+	_ Store = (*PostgresStorage)(nil)
+	_ Store = (*MemoryStorage)(nil)
+
+	_ security.LinkCache = Store(nil)
+)
+
+// Open connects to the backend named by driver and applies pending
+// migrations. dsn is a file path for SQLite and a connection string for
+// Postgres; the memory driver ignores it.
+func Open(driver, dsn string) (Store, error) {
+	switch normalizeDriver(driver) {
+	case DriverSQLite:
+		store, err := NewSQLiteStorage(dsn)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case DriverPostgres:
+		store, err := NewPostgresStorage(dsn)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case DriverMemory:
+		return NewMemoryStorage(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q: want %s, %s or %s", driver, DriverSQLite, DriverPostgres, DriverMemory)
+	}
+}
+
+func normalizeDriver(driver string) string {
+	switch driver = strings.ToLower(strings.TrimSpace(driver)); driver {
+	case "", "sqlite3":
+		return DriverSQLite
+	case "postgresql", "pg":
+		return DriverPostgres
+	default:
+		return driver
+	}
+}
+
+// sqlStore holds the queries both SQL backends share; they differ only in
+// how they connect, migrate and search.
+type sqlStore struct {
+	db  *database
+	fts bool // SQLite's bounties_fts is available for Search
+}
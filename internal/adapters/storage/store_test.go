@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// postgresDSNEnv names a Postgres server the storage tests also run
+// against, e.g. postgres://postgres@localhost/postgres?sslmode=disable.
+// Each test gets its own schema, dropped when it finishes.
+const postgresDSNEnv = "BOUNTYOS_TEST_POSTGRES_DSN"
+
+// forEachBackend runs test against an empty SQLite database, an empty
+// MemoryStorage and, when BOUNTYOS_TEST_POSTGRES_DSN is set, an empty
+// Postgres schema.
+func forEachBackend(t *testing.T, test func(t *testing.T, store Store)) {
+	t.Run("sqlite", func(t *testing.T) {
+		store, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "test.sqlite"))
+		if err != nil {
+			t.Fatalf("Failed to create storage: %v", err)
+		}
+		defer store.Close()
+		test(t, store)
+	})
+	t.Run("memory", func(t *testing.T) {
+		test(t, NewMemoryStorage())
+	})
+	t.Run("postgres", func(t *testing.T) {
+		dsn := os.Getenv(postgresDSNEnv)
+		if dsn == "" {
+			t.Skip(postgresDSNEnv + " not set")
+		}
+		store, err := NewPostgresStorage(testSchema(t, dsn))
+		if err != nil {
+			t.Fatalf("Failed to create storage: %v", err)
+		}
+		defer store.Close()
+		test(t, store)
+	})
+}
+
+// testSchema creates a throwaway schema on the server at dsn and returns a
+// connection string that uses it.
+func testSchema(t *testing.T, dsn string) string {
+	t.Helper()
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		t.Fatal(err)
+	}
+	schema := "bountyos_test_" + hex.EncodeToString(suffix)
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := admin.Exec(`CREATE SCHEMA ` + schema); err != nil {
+		admin.Close()
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		defer admin.Close()
+		if _, err := admin.Exec(`DROP SCHEMA ` + schema + ` CASCADE`); err != nil {
+			t.Errorf("drop schema: %v", err)
+		}
+	})
+
+	// lib/pq passes unknown parameters such as search_path to the server.
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		return dsn + sep + "search_path=" + schema
+	}
+	return dsn + " search_path=" + schema
+}
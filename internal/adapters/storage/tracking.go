@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+// ErrNotTracked is returned when a stored bounty has no pipeline record.
+var ErrNotTracked = errors.New("bounty not tracked")
+
+const trackingColumns = `b.id, t.url, COALESCE(b.title, ''), COALESCE(b.platform, ''), t.state, t.notes,
+		t.expected_payout, t.payout_currency, t.created_at, t.updated_at,
+		t.watched_at, t.claimed_at, t.started_at, t.submitted_at, t.paid_at`
+
+// GetTracking returns the pipeline record of the bounty with id. It returns
+// ErrNotFound for unknown bounties and ErrNotTracked for untracked ones.
+func (s *sqlStore) GetTracking(id string) (core.Tracking, error) {
+	url, err := s.urlForID(id)
+	if err != nil {
+		return core.Tracking{}, err
+	}
+	return getTracking(s.db, url)
+}
+
+// UpdateTracking applies update to the pipeline record of the bounty with
+// id, creating it if needed, and returns the result.
+func (s *sqlStore) UpdateTracking(id string, update core.TrackingUpdate) (core.Tracking, error) {
+	url, err := s.urlForID(id)
+	if err != nil {
+		return core.Tracking{}, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return core.Tracking{}, err
+	}
+	defer tx.Rollback()
+
+	tracking, err := getTracking(tx, url)
+	if err != nil && !errors.Is(err, ErrNotTracked) {
+		return core.Tracking{}, err
+	}
+	tracking.Apply(update, time.Now())
+	if err := saveTracking(tx, url, tracking); err != nil {
+		return core.Tracking{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return core.Tracking{}, err
+	}
+	return getTracking(s.db, url)
+}
+
+func saveTracking(tx dbtx, url string, tracking core.Tracking) error {
+	_, err := tx.Exec(`INSERT INTO tracking
+		(url, state, notes, expected_payout, payout_currency, created_at, updated_at,
+		 watched_at, claimed_at, started_at, submitted_at, paid_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(url) DO UPDATE SET
+		 state = excluded.state, notes = excluded.notes, expected_payout = excluded.expected_payout,
+		 payout_currency = excluded.payout_currency, created_at = excluded.created_at, updated_at = excluded.updated_at,
+		 watched_at = excluded.watched_at, claimed_at = excluded.claimed_at, started_at = excluded.started_at,
+		 submitted_at = excluded.submitted_at, paid_at = excluded.paid_at`,
+		url,
+		string(tracking.State),
+		tracking.Notes,
+		tracking.ExpectedPayout,
+		tracking.PayoutCurrency,
+		formatSeen(tracking.CreatedAt),
+		formatSeen(tracking.UpdatedAt),
+		nullTime(tracking.WatchedAt),
+		nullTime(tracking.ClaimedAt),
+		nullTime(tracking.StartedAt),
+		nullTime(tracking.SubmittedAt),
+		nullTime(tracking.PaidAt),
+	)
+	return err
+}
+
+// DeleteTracking stops tracking the bounty with id.
+func (s *sqlStore) DeleteTracking(id string) error {
+	url, err := s.urlForID(id)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`DELETE FROM tracking WHERE url = ?`, url)
+	return err
+}
+
+// ListTracking returns pipeline records in state, or all of them when state
+// is empty, most recently updated first.
+func (s *sqlStore) ListTracking(state core.PipelineState) ([]core.Tracking, error) {
+	rows, err := s.db.Query(`SELECT `+trackingColumns+`
+		FROM tracking t JOIN bounties b ON b.url = t.url
+		WHERE CAST(? AS TEXT) = '' OR t.state = ?
+		ORDER BY t.updated_at DESC`, string(state), string(state))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []core.Tracking{}
+	for rows.Next() {
+		tracking, err := scanTracking(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, tracking)
+	}
+	return list, rows.Err()
+}
+
+func (s *sqlStore) urlForID(id string) (string, error) {
+	var url string
+	err := s.db.QueryRow(`SELECT url FROM bounties WHERE id = ?`, id).Scan(&url)
+	if err == sql.ErrNoRows {
+		return "", ErrNotFound
+	}
+	return url, err
+}
+
+type queryRower interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func getTracking(q queryRower, url string) (core.Tracking, error) {
+	tracking, err := scanTracking(q.QueryRow(`SELECT `+trackingColumns+`
+		FROM tracking t JOIN bounties b ON b.url = t.url
+		WHERE t.url = ?`, url))
+	if err == sql.ErrNoRows {
+		return core.Tracking{}, ErrNotTracked
+	}
+	return tracking, err
+}
+
+func scanTracking(row interface{ Scan(...interface{}) error }) (core.Tracking, error) {
+	var t core.Tracking
+	var id, notes, currency sql.NullString
+	var state, createdAt, updatedAt string
+	var stamps [5]sql.NullString
+	if err := row.Scan(&id, &t.URL, &t.Title, &t.Platform, &state, &notes,
+		&t.ExpectedPayout, &currency, &createdAt, &updatedAt,
+		&stamps[0], &stamps[1], &stamps[2], &stamps[3], &stamps[4]); err != nil {
+		return core.Tracking{}, err
+	}
+	t.BountyID = id.String
+	t.State = core.PipelineState(state)
+	t.Notes = notes.String
+	t.PayoutCurrency = currency.String
+	t.CreatedAt, _ = parseTime(createdAt)
+	t.UpdatedAt, _ = parseTime(updatedAt)
+	for i, field := range []**time.Time{&t.WatchedAt, &t.ClaimedAt, &t.StartedAt, &t.SubmittedAt, &t.PaidAt} {
+		if !stamps[i].Valid {
+			continue
+		}
+		if parsed, err := parseTime(stamps[i].String); err == nil {
+			*field = &parsed
+		}
+	}
+	return t, nil
+}
+
+func nullTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return formatSeen(*t)
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+func TestStorage_Tracking(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+
+		bounty := core.Bounty{URL: "https://example.com/bounty/track", Title: "Tracked", Platform: "TEST", CreatedAt: time.Now()}
+		if err := store.Save(bounty); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		id := core.BountyID(bounty.URL)
+
+		if _, err := store.GetTracking(id); !errors.Is(err, ErrNotTracked) {
+			t.Fatalf("GetTracking(untracked) error = %v, want ErrNotTracked", err)
+		}
+		if _, err := store.UpdateTracking("missing", core.TrackingUpdate{}); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("UpdateTracking(missing) error = %v, want ErrNotFound", err)
+		}
+
+		claimed := core.StateClaimed
+		payout, token, notes := 750.0, "usdc", "DM'd the maintainer"
+		tracking, err := store.UpdateTracking(id, core.TrackingUpdate{
+			State:          &claimed,
+			ExpectedPayout: &payout,
+			PayoutCurrency: &token,
+			Notes:          &notes,
+		})
+		if err != nil {
+			t.Fatalf("UpdateTracking() error = %v", err)
+		}
+		if tracking.BountyID != id || tracking.Title != "Tracked" || tracking.State != core.StateClaimed ||
+			tracking.ClaimedAt == nil || tracking.ExpectedPayout != 750 || tracking.PayoutCurrency != "USDC" || tracking.Notes != notes {
+			t.Errorf("UpdateTracking() = %+v", tracking)
+		}
+
+		paid := core.StatePaid
+		if _, err := store.UpdateTracking(id, core.TrackingUpdate{State: &paid}); err != nil {
+			t.Fatalf("UpdateTracking(paid) error = %v", err)
+		}
+		tracking, err = store.GetTracking(id)
+		if err != nil || tracking.PaidAt == nil || tracking.ClaimedAt == nil || tracking.Notes != notes {
+			t.Errorf("GetTracking() = %+v, %v", tracking, err)
+		}
+
+		list, err := store.ListTracking(core.StatePaid)
+		if err != nil || len(list) != 1 {
+			t.Errorf("ListTracking(paid) = %+v, %v", list, err)
+		}
+		list, _ = store.ListTracking(core.StateWatch)
+		if len(list) != 0 {
+			t.Errorf("ListTracking(watch) = %+v, want none", list)
+		}
+
+		if err := store.DeleteTracking(id); err != nil {
+			t.Fatalf("DeleteTracking() error = %v", err)
+		}
+		if _, err := store.GetTracking(id); !errors.Is(err, ErrNotTracked) {
+			t.Errorf("GetTracking(deleted) error = %v, want ErrNotTracked", err)
+		}
+	})
+}
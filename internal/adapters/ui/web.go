@@ -2,12 +2,15 @@ package ui
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
-	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -20,47 +23,117 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+// HealthSource reports per-scanner health for /api/health.
+type HealthSource interface {
+	Snapshot() []core.ScannerHealth
+}
+
+// PipelineSource reports ingest pipeline stages for /api/pipeline.
+type PipelineSource interface {
+	Stats() []core.StageStats
+}
+
+// BountyStore is the storage the API reads and updates. storage.Store
+// satisfies it for every backend.
+type BountyStore interface {
+	GetByID(id string) (core.Bounty, error)
+	Query(q core.BountyQuery) (core.BountyPage, error)
+	Stats(q core.BountyQuery) (core.BountyStats, error)
+	Search(query string, filters core.SearchFilters) (core.SearchResult, error)
+	GetHistory(id string) ([]core.BountyChange, error)
+	GetSources(id string) ([]core.BountySource, error)
+	GetRejections(limit int) ([]core.Rejection, error)
+	UpdateTracking(id string, update core.TrackingUpdate) (core.Tracking, error)
+	DeleteTracking(id string) error
+	ListTracking(state core.PipelineState) ([]core.Tracking, error)
+	RecordPayment(id string, entry core.LedgerEntry) (core.LedgerEntry, error)
+	GetLedger() ([]core.LedgerEntry, error)
+	UnreconciledPayments() ([]core.Tracking, error)
+	GetAuditLog(action string, limit int) ([]core.AuditEntry, error)
+}
+
 type WebUI struct {
-	storage              *storage.SQLiteStorage
+	storage              BountyStore
+	health               HealthSource
+	pipeline             PipelineSource
+	oracle               core.PriceOracle
+	bindAddress          string
+	apiToken             string
 	port                 int
 	bountiesLimit        int
-	statsLimit           int
 	fetchIntervalSeconds int
 	staticDir            string
 	frontendEnabled      bool
 	clientsMu            sync.Mutex
+	broadcastMu          sync.Mutex // websocket connections allow one writer at a time
 	clients              map[*websocket.Conn]struct{}
 	server               *http.Server
 }
 
-func NewWebUI(storage *storage.SQLiteStorage, port int, bountiesLimit int, statsLimit int, fetchIntervalSeconds int, staticDir string) *WebUI {
+func NewWebUI(storage BountyStore, port int, bountiesLimit int, fetchIntervalSeconds int, staticDir string) *WebUI {
 	if bountiesLimit <= 0 {
 		bountiesLimit = 50
 	}
-	if statsLimit <= 0 {
-		statsLimit = 100
-	}
 	if fetchIntervalSeconds <= 0 {
 		fetchIntervalSeconds = 5
 	}
 
 	return &WebUI{
 		storage:              storage,
+		bindAddress:          "127.0.0.1",
 		port:                 port,
 		bountiesLimit:        bountiesLimit,
-		statsLimit:           statsLimit,
 		fetchIntervalSeconds: fetchIntervalSeconds,
 		staticDir:            staticDir,
 		clients:              make(map[*websocket.Conn]struct{}),
 	}
 }
 
+// SetHealthSource attaches the scanner health tracker served at /api/health.
+func (ui *WebUI) SetHealthSource(health HealthSource) {
+	ui.health = health
+}
+
+// SetPipelineSource attaches the ingest pipeline served at /api/pipeline.
+func (ui *WebUI) SetPipelineSource(pipeline PipelineSource) {
+	ui.pipeline = pipeline
+}
+
+// SetPriceOracle attaches the oracle used to value recorded payments in USD.
+func (ui *WebUI) SetPriceOracle(oracle core.PriceOracle) {
+	ui.oracle = oracle
+}
+
+// SetAccess sets the address the server listens on (default 127.0.0.1) and
+// the bearer token requests that change data must carry; an empty token
+// requires none.
+func (ui *WebUI) SetAccess(bindAddress, apiToken string) {
+	if bindAddress != "" {
+		ui.bindAddress = bindAddress
+	}
+	ui.apiToken = apiToken
+}
+
 func (ui *WebUI) Start(ctx context.Context) error {
 	mux := http.NewServeMux()
 
 	// API endpoints
 	mux.HandleFunc("/api/bounties", ui.handleBounties)
+	mux.HandleFunc("GET /api/bounties/search", ui.handleSearch)
+	mux.HandleFunc("/api/bounties/{id}/score", ui.handleBountyScore)
+	mux.HandleFunc("/api/bounties/{id}/history", ui.handleBountyHistory)
+	mux.HandleFunc("/api/bounties/{id}/sources", ui.handleBountySources)
+	mux.HandleFunc("PATCH /api/bounties/{id}", ui.handleUpdateTracking)
+	mux.HandleFunc("DELETE /api/bounties/{id}/tracking", ui.handleDeleteTracking)
+	mux.HandleFunc("/api/tracking", ui.handleTracking)
+	mux.HandleFunc("POST /api/bounties/{id}/payments", ui.handleRecordPayment)
+	mux.HandleFunc("/api/ledger", ui.handleLedger)
+	mux.HandleFunc("/api/earnings", ui.handleEarnings)
 	mux.HandleFunc("/api/stats", ui.handleStats)
+	mux.HandleFunc("/api/health", ui.handleHealth)
+	mux.HandleFunc("/api/pipeline", ui.handlePipeline)
+	mux.HandleFunc("/api/rejections", ui.handleRejections)
+	mux.HandleFunc("GET /api/audit", ui.handleAudit)
 	mux.HandleFunc("/ws", ui.handleWS)
 
 	// Static files (placeholder for now)
@@ -69,12 +142,12 @@ func (ui *WebUI) Start(ctx context.Context) error {
 	ui.frontendEnabled = ui.resolveStaticDir()
 
 	ui.server = &http.Server{
-		Addr:              fmt.Sprintf(":%d", ui.port),
-		Handler:           mux,
+		Addr:              net.JoinHostPort(ui.bindAddress, strconv.Itoa(ui.port)),
+		Handler:           ui.guard(mux),
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
-	security.GetLogger().Info("Starting Web UI on http://localhost:%d", ui.port)
+	security.GetLogger().Info("Starting Web UI on http://%s", ui.server.Addr)
 
 	go func() {
 		if err := ui.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -85,6 +158,49 @@ func (ui *WebUI) Start(ctx context.Context) error {
 	return nil
 }
 
+// guard refuses requests that change data unless they come from the
+// server's own origin and, with an API token set, carry it as a bearer
+// token. Reads stay open to the configured bind address.
+func (ui *WebUI) guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !sameOrigin(r) {
+			http.Error(w, "cross-origin request refused", http.StatusForbidden)
+			return
+		}
+		if ui.apiToken != "" {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(ui.apiToken)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "missing or invalid API token", http.StatusUnauthorized)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// sameOrigin reports whether a browser sent r from the server's own
+// origin. Requests without Origin or Sec-Fetch-Site headers come from
+// other clients, such as curl, and pass.
+func sameOrigin(r *http.Request) bool {
+	switch r.Header.Get("Sec-Fetch-Site") {
+	case "", "same-origin", "none":
+	default:
+		return false
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(parsed.Host, r.Host)
+}
+
 func (ui *WebUI) Stop() error {
 	if ui.server != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -94,56 +210,531 @@ func (ui *WebUI) Stop() error {
 	return nil
 }
 
+// handleBounties serves GET /api/bounties, a page of bounties filtered by
+// platform, currency, payment_type, min_score, tag, created_after,
+// expires_before and status (comma-separated, or "all"; default open),
+// ordered by sort (score, created_at, reward_usd, expires_at) and order
+// (asc or desc). The next page's cursor is returned in X-Next-Cursor and a
+// Link header; the body stays a plain array.
 func (ui *WebUI) handleBounties(w http.ResponseWriter, r *http.Request) {
-	bounties, err := ui.storage.GetRecent(ui.bountiesLimit)
+	query, err := parseBountyQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = ui.bountiesLimit
+	}
+
+	page, err := ui.storage.Query(query)
+	if errors.Is(err, core.ErrInvalidCursor) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Sort by score
-	sort.Slice(bounties, func(i, j int) bool {
-		return bounties[i].Score > bounties[j].Score
+	if page.NextCursor != "" {
+		next := r.URL.Query()
+		next.Set("cursor", page.NextCursor)
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+		w.Header().Set("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, next.Encode()))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page.Bounties)
+}
+
+func parseBountyQuery(params url.Values) (core.BountyQuery, error) {
+	query := core.BountyQuery{
+		Platform:    params.Get("platform"),
+		Currency:    params.Get("currency"),
+		PaymentType: params.Get("payment_type"),
+		Tag:         params.Get("tag"),
+		Cursor:      params.Get("cursor"),
+	}
+
+	sort, err := core.ParseBountySort(params.Get("sort"))
+	if err != nil {
+		return query, err
+	}
+	query.Sort = sort
+	switch order := strings.ToLower(params.Get("order")); order {
+	case "", "desc":
+	case "asc":
+		query.Ascending = true
+	default:
+		return query, fmt.Errorf("invalid order %q: want asc or desc", order)
+	}
+
+	if value := params.Get("status"); value != "" && value != "all" {
+		for _, status := range strings.Split(value, ",") {
+			query.Statuses = append(query.Statuses, core.ParseBountyStatus(status))
+		}
+	} else if value == "all" {
+		query.Statuses = []core.BountyStatus{core.StatusOpen, core.StatusClaimed, core.StatusClosed, core.StatusExpired}
+	}
+
+	if value := params.Get("min_score"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return query, fmt.Errorf("invalid min_score %q", value)
+		}
+		query.MinScore = &n
+	}
+	if value := params.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return query, fmt.Errorf("invalid limit %q", value)
+		}
+		query.Limit = min(n, 500)
+	}
+	for key, dst := range map[string]*time.Time{"created_after": &query.CreatedAfter, "expires_before": &query.ExpiresBefore} {
+		if value := params.Get(key); value != "" {
+			t, err := parseDate(value)
+			if err != nil {
+				return query, fmt.Errorf("invalid %s %q: want RFC 3339 or YYYY-MM-DD", key, value)
+			}
+			*dst = t
+		}
+	}
+	return query, nil
+}
+
+// handleSearch serves GET /api/bounties/search?q=, a page of full-text
+// matches narrowed by platform, payment_type, status, min_score, max_score,
+// min_usd, max_usd, created_after, created_before, limit and offset.
+func (ui *WebUI) handleSearch(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	filters, err := parseSearchFilters(params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := ui.storage.Search(params.Get("q"), filters)
+	if errors.Is(err, core.ErrInvalidQuery) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func parseSearchFilters(params url.Values) (core.SearchFilters, error) {
+	filters := core.SearchFilters{
+		Platform:    params.Get("platform"),
+		PaymentType: params.Get("payment_type"),
+	}
+	if value := params.Get("status"); value != "" {
+		filters.Status = core.ParseBountyStatus(value)
+	}
+
+	for key, dst := range map[string]**int{"min_score": &filters.MinScore, "max_score": &filters.MaxScore} {
+		if value := params.Get(key); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return filters, fmt.Errorf("invalid %s %q", key, value)
+			}
+			*dst = &n
+		}
+	}
+	for key, dst := range map[string]*int{"limit": &filters.Limit, "offset": &filters.Offset} {
+		if value := params.Get(key); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return filters, fmt.Errorf("invalid %s %q", key, value)
+			}
+			*dst = n
+		}
+	}
+	if filters.Limit > 100 {
+		filters.Limit = 100
+	}
+
+	for key, dst := range map[string]*float64{"min_usd": &filters.MinUSD, "max_usd": &filters.MaxUSD} {
+		if value := params.Get(key); value != "" {
+			amount, err := strconv.ParseFloat(value, 64)
+			if err != nil || amount < 0 {
+				return filters, fmt.Errorf("invalid %s %q", key, value)
+			}
+			*dst = amount
+		}
+	}
+	for key, dst := range map[string]*time.Time{"created_after": &filters.CreatedAfter, "created_before": &filters.CreatedBefore} {
+		if value := params.Get(key); value != "" {
+			t, err := parseDate(value)
+			if err != nil {
+				return filters, fmt.Errorf("invalid %s %q: want RFC 3339 or YYYY-MM-DD", key, value)
+			}
+			*dst = t
+		}
+	}
+	return filters, nil
+}
+
+func (ui *WebUI) handleBountyScore(w http.ResponseWriter, r *http.Request) {
+	bounty, err := ui.storage.GetByID(r.PathValue("id"))
+	if errors.Is(err, storage.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	breakdown := bounty.ScoreBreakdown
+	if breakdown == nil {
+		breakdown = []core.ScoreContribution{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		ID        string                   `json:"id"`
+		Title     string                   `json:"title"`
+		Score     int                      `json:"score"`
+		Breakdown []core.ScoreContribution `json:"breakdown"`
+	}{
+		ID:        bounty.ID,
+		Title:     bounty.Title,
+		Score:     bounty.Score,
+		Breakdown: breakdown,
 	})
+}
+
+func (ui *WebUI) handleBountyHistory(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	bounty, err := ui.storage.GetByID(id)
+	if errors.Is(err, storage.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	changes, err := ui.storage.GetHistory(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(bounties)
+	json.NewEncoder(w).Encode(struct {
+		ID        string              `json:"id"`
+		Status    core.BountyStatus   `json:"status"`
+		FirstSeen time.Time           `json:"first_seen"`
+		LastSeen  time.Time           `json:"last_seen"`
+		Changes   []core.BountyChange `json:"changes"`
+	}{
+		ID:        bounty.ID,
+		Status:    bounty.Status,
+		FirstSeen: bounty.FirstSeen,
+		LastSeen:  bounty.LastSeen,
+		Changes:   changes,
+	})
 }
 
-func (ui *WebUI) handleStats(w http.ResponseWriter, r *http.Request) {
-	bounties, err := ui.storage.GetRecent(ui.statsLimit)
+func (ui *WebUI) handleBountySources(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	bounty, err := ui.storage.GetByID(id)
+	if errors.Is(err, storage.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sources, err := ui.storage.GetSources(id)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	stats := struct {
-		TotalCount  int            `json:"total_count"`
-		ByPlatform  map[string]int `json:"by_platform"`
-		AvgScore    float64        `json:"avg_score"`
-		CryptoCount int            `json:"crypto_count"`
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		ID           string              `json:"id"`
+		CanonicalURL string              `json:"canonical_url"`
+		Sources      []core.BountySource `json:"sources"`
 	}{
-		ByPlatform: make(map[string]int),
+		ID:           bounty.ID,
+		CanonicalURL: core.CanonicalURL(bounty.URL),
+		Sources:      sources,
+	})
+}
+
+// trackingRequest is the PATCH /api/bounties/{id} body. Omitted fields are
+// left unchanged.
+type trackingRequest struct {
+	State          *string  `json:"state"`
+	Notes          *string  `json:"notes"`
+	ExpectedPayout *float64 `json:"expected_payout"`
+	PayoutCurrency *string  `json:"payout_currency"`
+}
+
+func (ui *WebUI) handleUpdateTracking(w http.ResponseWriter, r *http.Request) {
+	var req trackingRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body: "+err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	stats.TotalCount = len(bounties)
-	var totalScore int
-	for _, b := range bounties {
-		stats.ByPlatform[b.Platform]++
-		totalScore += b.Score
-		if b.PaymentType == "crypto" {
-			stats.CryptoCount++
+	update := core.TrackingUpdate{
+		Notes:          req.Notes,
+		ExpectedPayout: req.ExpectedPayout,
+		PayoutCurrency: req.PayoutCurrency,
+	}
+	if req.State != nil {
+		state, err := core.ParsePipelineState(*req.State)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
+		update.State = &state
+	}
+	if req.ExpectedPayout != nil && *req.ExpectedPayout < 0 {
+		http.Error(w, "expected_payout must not be negative", http.StatusBadRequest)
+		return
+	}
+
+	tracking, err := ui.storage.UpdateTracking(r.PathValue("id"), update)
+	if errors.Is(err, storage.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tracking)
+}
+
+func (ui *WebUI) handleDeleteTracking(w http.ResponseWriter, r *http.Request) {
+	err := ui.storage.DeleteTracking(r.PathValue("id"))
+	if errors.Is(err, storage.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (ui *WebUI) handleTracking(w http.ResponseWriter, r *http.Request) {
+	var state core.PipelineState
+	if value := r.URL.Query().Get("state"); value != "" {
+		parsed, err := core.ParsePipelineState(value)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		state = parsed
+	}
+
+	tracked, err := ui.storage.ListTracking(state)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tracked)
+}
+
+// paymentRequest is the POST /api/bounties/{id}/payments body. Token
+// defaults to the bounty's reward token and received_at (RFC 3339 or
+// YYYY-MM-DD) to now; amount_usd is estimated when omitted.
+type paymentRequest struct {
+	Amount     float64 `json:"amount"`
+	Token      string  `json:"token"`
+	AmountUSD  float64 `json:"amount_usd"`
+	Reference  string  `json:"reference"`
+	ReceivedAt string  `json:"received_at"`
+}
+
+func (ui *WebUI) handleRecordPayment(w http.ResponseWriter, r *http.Request) {
+	var req paymentRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Amount <= 0 || req.AmountUSD < 0 {
+		http.Error(w, "amount must be positive", http.StatusBadRequest)
+		return
+	}
+
+	id := r.PathValue("id")
+	bounty, err := ui.storage.GetByID(id)
+	if errors.Is(err, storage.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	entry := core.LedgerEntry{
+		Amount:    req.Amount,
+		Token:     req.Token,
+		AmountUSD: req.AmountUSD,
+		Reference: strings.TrimSpace(req.Reference),
+	}
+	if strings.TrimSpace(entry.Token) == "" {
+		entry.Token = bounty.RewardToken
+	}
+	if req.ReceivedAt != "" {
+		received, err := parseDate(req.ReceivedAt)
+		if err != nil {
+			http.Error(w, "invalid received_at: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		entry.ReceivedAt = received
+	}
+	core.PrepareLedgerEntry(r.Context(), ui.oracle, &entry, bounty.PaymentType)
+
+	recorded, err := ui.storage.RecordPayment(id, entry)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(recorded)
+}
+
+func (ui *WebUI) handleLedger(w http.ResponseWriter, r *http.Request) {
+	entries, err := ui.storage.GetLedger()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	if stats.TotalCount > 0 {
-		stats.AvgScore = float64(totalScore) / float64(stats.TotalCount)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+func (ui *WebUI) handleEarnings(w http.ResponseWriter, r *http.Request) {
+	entries, err := ui.storage.GetLedger()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	report := core.BuildEarningsReport(entries)
+	if report.Unreconciled, err = ui.storage.UnreconciledPayments(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// parseDate accepts an RFC 3339 timestamp or a plain YYYY-MM-DD date.
+func parseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// handleStats serves GET /api/stats over every bounty matching the
+// /api/bounties filters.
+func (ui *WebUI) handleStats(w http.ResponseWriter, r *http.Request) {
+	query, err := parseBountyQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := ui.storage.Stats(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(stats)
 }
 
+func (ui *WebUI) handleHealth(w http.ResponseWriter, r *http.Request) {
+	scanners := []core.ScannerHealth{}
+	if ui.health != nil {
+		scanners = ui.health.Snapshot()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Scanners []core.ScannerHealth `json:"scanners"`
+	}{
+		Scanners: scanners,
+	})
+}
+
+func (ui *WebUI) handlePipeline(w http.ResponseWriter, r *http.Request) {
+	stages := []core.StageStats{}
+	if ui.pipeline != nil {
+		stages = ui.pipeline.Stats()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Stages []core.StageStats `json:"stages"`
+	}{
+		Stages: stages,
+	})
+}
+
+func (ui *WebUI) handleRejections(w http.ResponseWriter, r *http.Request) {
+	rejections, err := ui.storage.GetRejections(ui.bountiesLimit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if rejections == nil {
+		rejections = []core.Rejection{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rejections)
+}
+
+// handleAudit lists maintenance actions newest first, optionally only one
+// action (?action=archive).
+func (ui *WebUI) handleAudit(w http.ResponseWriter, r *http.Request) {
+	limit := ui.bountiesLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			http.Error(w, fmt.Sprintf("invalid limit %q", value), http.StatusBadRequest)
+			return
+		}
+		limit = min(n, 500)
+	}
+	entries, err := ui.storage.GetAuditLog(r.URL.Query().Get("action"), limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
 func (ui *WebUI) handleIndex(w http.ResponseWriter, r *http.Request) {
 	if r.URL.Path != "/" {
 		if ui.frontendEnabled {
@@ -189,6 +780,10 @@ func (ui *WebUI) handleIndex(w http.ResponseWriter, r *http.Request) {
         .link { color: #6366f1; text-decoration: none; font-size: 12px; }
         .link:hover { text-decoration: underline; }
         .badge { display: inline-block; padding: 2px 8px; border-radius: 4px; font-size: 11px; margin-right: 5px; background: #475569; }
+        .health { margin-bottom: 20px; font-size: 12px; }
+        .health-ok { background: #065f46; }
+        .health-degraded { background: #92400e; }
+        .health-failing { background: #9f1239; }
     </style>
 </head>
 <body>
@@ -198,6 +793,8 @@ func (ui *WebUI) handleIndex(w http.ResponseWriter, r *http.Request) {
             <div id="last-updated" style="color: #64748b; font-size: 12px;"></div>
         </header>
 
+        <div class="health" id="health"></div>
+
         <div class="stats" id="stats">
             <!-- Stats will be loaded here -->
         </div>
@@ -220,14 +817,17 @@ func (ui *WebUI) handleIndex(w http.ResponseWriter, r *http.Request) {
     <script>
         async function fetchData() {
             try {
-                const [bountiesResp, statsResp] = await Promise.all([
+                const [bountiesResp, statsResp, healthResp] = await Promise.all([
                     fetch('/api/bounties'),
-                    fetch('/api/stats')
+                    fetch('/api/stats'),
+                    fetch('/api/health')
                 ]);
                 
                 const bounties = await bountiesResp.json();
                 const stats = await statsResp.json();
+                const health = await healthResp.json();
                 
+                updateHealth(health.scanners);
                 updateStats(stats);
                 updateBounties(bounties);
                 document.getElementById('last-updated').textContent = 'Last updated: ' + new Date().toLocaleTimeString();
@@ -236,6 +836,13 @@ func (ui *WebUI) handleIndex(w http.ResponseWriter, r *http.Request) {
             }
         }
 
+        function updateHealth(scanners) {
+            document.getElementById('health').innerHTML = scanners.map(s => {
+                const title = s.last_error ? ' title="' + s.last_error.replace(/"/g, '&quot;') + '"' : '';
+                return '<span class="badge health-' + s.status + '"' + title + '>' + s.name + ': ' + s.status + '</span>';
+            }).join('');
+        }
+
         function updateStats(stats) {
             const statsContainer = document.getElementById('stats');
             statsContainer.innerHTML = ' \
@@ -269,7 +876,7 @@ func (ui *WebUI) handleIndex(w http.ResponseWriter, r *http.Request) {
                     <tr> \
                         <td><span class="score ' + scoreClass + '">' + b.score + '</span></td> \
                         <td><span class="platform">' + b.platform + '</span></td> \
-                        <td><span class="payout">' + b.reward + (b.currency ? ' ' + b.currency : '') + '</span></td> \
+                        <td><span class="payout">' + b.reward + (b.currency ? ' ' + b.currency : '') + '</span>' + (b.reward_usd > 0 ? ' <span class="platform">≈ $' + Math.round(b.reward_usd).toLocaleString() + '</span>' : '') + '</td> \
                         <td> \
                             <div>' + b.title + '</div> \
                             <a href="' + b.url + '" class="link" target="_blank">' + b.url + '</a> \
@@ -332,6 +939,8 @@ func (ui *WebUI) Broadcast(bounty core.Bounty) {
 		return
 	}
 
+	ui.broadcastMu.Lock()
+	defer ui.broadcastMu.Unlock()
 	clients := ui.snapshotClients()
 	for _, conn := range clients {
 		if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
@@ -341,9 +950,7 @@ func (ui *WebUI) Broadcast(bounty core.Bounty) {
 }
 
 var wsUpgrader = websocket.Upgrader{
-	CheckOrigin: func(r *http.Request) bool {
-		return true
-	},
+	CheckOrigin: sameOrigin,
 }
 
 func (ui *WebUI) handleWS(w http.ResponseWriter, r *http.Request) {
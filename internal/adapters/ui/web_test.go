@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebUI_GuardRefusesCrossOriginAndMissingToken(t *testing.T) {
+	ui := NewWebUI(nil, 0, 0, 0, "")
+	ui.SetAccess("", "secret")
+	handler := ui.guard(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name    string
+		method  string
+		headers map[string]string
+		want    int
+	}{
+		{"read", http.MethodGet, map[string]string{"Origin": "https://evil.example"}, http.StatusNoContent},
+		{"write with token", http.MethodPatch, map[string]string{"Authorization": "Bearer secret"}, http.StatusNoContent},
+		{"write from own origin", http.MethodPost, map[string]string{"Authorization": "Bearer secret", "Origin": "http://localhost:12496", "Sec-Fetch-Site": "same-origin"}, http.StatusNoContent},
+		{"write without token", http.MethodDelete, nil, http.StatusUnauthorized},
+		{"write with wrong token", http.MethodPatch, map[string]string{"Authorization": "Bearer guess"}, http.StatusUnauthorized},
+		{"write from other origin", http.MethodPost, map[string]string{"Authorization": "Bearer secret", "Origin": "https://evil.example"}, http.StatusForbidden},
+		{"write from cross-site form", http.MethodPost, map[string]string{"Authorization": "Bearer secret", "Sec-Fetch-Site": "cross-site"}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "http://localhost:12496/api/bounties/abc", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("%s = %d, want %d", tt.name, rec.Code, tt.want)
+			}
+		})
+	}
+
+	if ui.bindAddress != "127.0.0.1" {
+		t.Errorf("default bind address = %q, want 127.0.0.1", ui.bindAddress)
+	}
+}
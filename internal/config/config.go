@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -12,82 +13,154 @@ import (
 const DefaultPath = "config/config.yaml"
 
 type Config struct {
-	GitHubToken             string   `yaml:"GITHUB_TOKEN"`
-	DiscordWebhookURL       string   `yaml:"DISCORD_WEBHOOK_URL"`
-	PollIntervalSeconds     int      `yaml:"POLL_INTERVAL_SECONDS"`
-	MinScore                int      `yaml:"MIN_SCORE"`
-	StoragePath             string   `yaml:"STORAGE_PATH"`
-	LogPath                 string   `yaml:"LOG_PATH"`
-	LogToStdout             bool     `yaml:"LOG_TO_STDOUT"`
-	LogToStderr             bool     `yaml:"LOG_TO_STDERR"`
-	QuietUILogs             bool     `yaml:"QUIET_UI_LOGS"`
-	ValidateLinksHTTP       bool     `yaml:"VALIDATE_LINKS_HTTP"`
-	LinkValidationTimeout   int      `yaml:"LINK_VALIDATION_TIMEOUT_SECONDS"`
-	WebStaticDir            string   `yaml:"WEB_STATIC_DIR"`
-	WebPort                 int      `yaml:"WEB_PORT"`
-	NoUI                    bool     `yaml:"NO_UI"`
-	UIRefreshSeconds        int      `yaml:"UI_REFRESH_SECONDS"`
-	TUIRecentLimit          int      `yaml:"TUI_RECENT_LIMIT"`
-	APIBountiesLimit        int      `yaml:"API_BOUNTIES_LIMIT"`
-	APIStatsLimit           int      `yaml:"API_STATS_LIMIT"`
-	WebFetchIntervalSeconds int      `yaml:"WEB_FETCH_INTERVAL_SECONDS"`
-	DisableRateLimitSleep   bool     `yaml:"BOUNTYOS_DISABLE_RATE_LIMIT_SLEEP"`
-	EnabledScanners         []string `yaml:"ENABLED_SCANNERS"`
-	GitHubLabels            []string `yaml:"GITHUB_LABELS"`
-	GitHubPerPage           int      `yaml:"GITHUB_PER_PAGE"`
-	GitHubMaxPages          int      `yaml:"GITHUB_MAX_PAGES"`
-	GitHubBaseURL           string   `yaml:"GITHUB_BASE_URL"`
-	SuperteamBaseURL        string   `yaml:"SUPERTEAM_BASE_URL"`
-	SuperteamStatuses       []string `yaml:"SUPERTEAM_STATUSES"`
-	BountycasterBaseURL     string   `yaml:"BOUNTYCASTER_BASE_URL"`
-	BountycasterStatuses    []string `yaml:"BOUNTYCASTER_STATUSES"`
-	UrgencyKeywords         []string `yaml:"URGENCY_KEYWORDS"`
-	DevTaskKeywords         []string `yaml:"DEV_TASK_KEYWORDS"`
-	AutomationKeywords      []string `yaml:"AUTOMATION_KEYWORDS"`
-	SecurityKeywords        []string `yaml:"SECURITY_KEYWORDS"`
-	AuditKeywords           []string `yaml:"AUDIT_KEYWORDS"`
-	PaymentPreferences      []string `yaml:"PAYMENT_PREFERENCES"`
-	CryptoCurrencies        []string `yaml:"CRYPTO_CURRENCIES"`
-	P2PMethods              []string `yaml:"P2P_METHODS"`
-	FiatMethods             []string `yaml:"FIAT_METHODS"`
+	GitHubToken              string   `yaml:"GITHUB_TOKEN"`
+	DiscordWebhookURL        string   `yaml:"DISCORD_WEBHOOK_URL"`
+	PollIntervalSeconds      int      `yaml:"POLL_INTERVAL_SECONDS"`
+	PollMinIntervalSeconds   int      `yaml:"POLL_MIN_INTERVAL_SECONDS"`
+	PollMaxIntervalSeconds   int      `yaml:"POLL_MAX_INTERVAL_SECONDS"`
+	PollJitterPercent        int      `yaml:"POLL_JITTER_PERCENT"`
+	MinScore                 int      `yaml:"MIN_SCORE"`
+	StorageDriver            string   `yaml:"STORAGE_DRIVER"`
+	StoragePath              string   `yaml:"STORAGE_PATH"`
+	StorageDSN               string   `yaml:"STORAGE_DSN"`
+	AutoMigrate              bool     `yaml:"AUTO_MIGRATE"`
+	LogPath                  string   `yaml:"LOG_PATH"`
+	LogToStdout              bool     `yaml:"LOG_TO_STDOUT"`
+	LogToStderr              bool     `yaml:"LOG_TO_STDERR"`
+	QuietUILogs              bool     `yaml:"QUIET_UI_LOGS"`
+	ValidateLinksHTTP        bool     `yaml:"VALIDATE_LINKS_HTTP"`
+	LinkValidationTimeout    int      `yaml:"LINK_VALIDATION_TIMEOUT_SECONDS"`
+	LinkValidationWorkers    int      `yaml:"LINK_VALIDATION_WORKERS"`
+	LinkValidationPerHost    int      `yaml:"LINK_VALIDATION_PER_HOST"`
+	LinkValidationBudget     int      `yaml:"LINK_VALIDATION_BUDGET"`
+	LinkCacheTTLHours        int      `yaml:"LINK_CACHE_TTL_HOURS"`
+	PipelineQueueSize        int      `yaml:"PIPELINE_QUEUE_SIZE"`
+	PipelineValidateWorkers  int      `yaml:"PIPELINE_VALIDATE_WORKERS"`
+	PipelineEnrichWorkers    int      `yaml:"PIPELINE_ENRICH_WORKERS"`
+	PipelineAlertWorkers     int      `yaml:"PIPELINE_ALERT_WORKERS"`
+	WebStaticDir             string   `yaml:"WEB_STATIC_DIR"`
+	WebPort                  int      `yaml:"WEB_PORT"`
+	WebBindAddress           string   `yaml:"WEB_BIND_ADDRESS"`
+	WebAPIToken              string   `yaml:"WEB_API_TOKEN"`
+	NoUI                     bool     `yaml:"NO_UI"`
+	DemoMode                 bool     `yaml:"DEMO_MODE"`
+	UIRefreshSeconds         int      `yaml:"UI_REFRESH_SECONDS"`
+	TUIRecentLimit           int      `yaml:"TUI_RECENT_LIMIT"`
+	APIBountiesLimit         int      `yaml:"API_BOUNTIES_LIMIT"`
+	WebFetchIntervalSeconds  int      `yaml:"WEB_FETCH_INTERVAL_SECONDS"`
+	DisableRateLimitSleep    bool     `yaml:"BOUNTYOS_DISABLE_RATE_LIMIT_SLEEP"`
+	EnabledScanners          []string `yaml:"ENABLED_SCANNERS"`
+	GitHubLabels             []string `yaml:"GITHUB_LABELS"`
+	GitHubPerPage            int      `yaml:"GITHUB_PER_PAGE"`
+	GitHubMaxPages           int      `yaml:"GITHUB_MAX_PAGES"`
+	GitHubFullScanHours      int      `yaml:"GITHUB_FULL_SCAN_HOURS"`
+	GitHubBaseURL            string   `yaml:"GITHUB_BASE_URL"`
+	SuperteamBaseURL         string   `yaml:"SUPERTEAM_BASE_URL"`
+	SuperteamStatuses        []string `yaml:"SUPERTEAM_STATUSES"`
+	BountycasterBaseURL      string   `yaml:"BOUNTYCASTER_BASE_URL"`
+	BountycasterStatuses     []string `yaml:"BOUNTYCASTER_STATUSES"`
+	UrgencyKeywords          []string `yaml:"URGENCY_KEYWORDS"`
+	DevTaskKeywords          []string `yaml:"DEV_TASK_KEYWORDS"`
+	AutomationKeywords       []string `yaml:"AUTOMATION_KEYWORDS"`
+	SecurityKeywords         []string `yaml:"SECURITY_KEYWORDS"`
+	AuditKeywords            []string `yaml:"AUDIT_KEYWORDS"`
+	PaymentPreferences       []string `yaml:"PAYMENT_PREFERENCES"`
+	CryptoCurrencies         []string `yaml:"CRYPTO_CURRENCIES"`
+	P2PMethods               []string `yaml:"P2P_METHODS"`
+	FiatMethods              []string `yaml:"FIAT_METHODS"`
+	ScoringRulesPath         string   `yaml:"SCORING_RULES_PATH"`
+	RescoreIntervalSeconds   int      `yaml:"RESCORE_INTERVAL_SECONDS"`
+	CloseMissingAfterHours   int      `yaml:"CLOSE_MISSING_AFTER_HOURS"`
+	DedupTitleSimilarity     float64  `yaml:"DEDUP_TITLE_SIMILARITY"`
+	DedupWindowDays          int      `yaml:"DEDUP_WINDOW_DAYS"`
+	RetentionDays            int      `yaml:"RETENTION_DAYS"`
+	RetentionAction          string   `yaml:"RETENTION_ACTION"`
+	ArchiveDir               string   `yaml:"ARCHIVE_DIR"`
+	MaintenanceIntervalHours int      `yaml:"MAINTENANCE_INTERVAL_HOURS"`
+	VacuumIntervalHours      int      `yaml:"VACUUM_INTERVAL_HOURS"`
+	PriceOracle              string   `yaml:"PRICE_ORACLE"`
+	PriceOracleURL           string   `yaml:"PRICE_ORACLE_URL"`
+	PriceCacheSeconds        int      `yaml:"PRICE_CACHE_SECONDS"`
+	MinUSDValue              float64  `yaml:"MIN_USD_VALUE"`
+	DropUnquantifiedRewards  bool     `yaml:"DROP_UNQUANTIFIED_REWARDS"`
+	RewardFilterStage        string   `yaml:"REWARD_FILTER_STAGE"`
+
+	// MinCurrencyAmounts sets a minimum reward amount per token symbol.
+	MinCurrencyAmounts map[string]float64 `yaml:"MIN_CURRENCY_AMOUNTS"`
+
+	// PriceTable holds static USD prices per token; it backs the "static"
+	// oracle and is the fallback for the "http" oracle.
+	PriceTable map[string]float64 `yaml:"PRICE_TABLE"`
+	// PriceCoinIDs maps token symbols to price API coin ids.
+	PriceCoinIDs map[string]string `yaml:"PRICE_COIN_IDS"`
+
+	// Scanners holds per-scanner option blocks keyed by instance name. A block
+	// may set "type" to reuse a registered scanner under another name.
+	Scanners map[string]map[string]interface{} `yaml:"SCANNERS"`
 }
 
 func Default() Config {
 	return Config{
-		PollIntervalSeconds:     60,
-		MinScore:                60,
-		StoragePath:             "./data/bounties.db",
-		LogPath:                 "./data/bountyos.log",
-		LogToStdout:             true,
-		LogToStderr:             false,
-		QuietUILogs:             true,
-		ValidateLinksHTTP:       true,
-		LinkValidationTimeout:   5,
-		WebStaticDir:            "./web/dist",
-		WebPort:                 12496,
-		UIRefreshSeconds:        5,
-		TUIRecentLimit:          15,
-		APIBountiesLimit:        50,
-		APIStatsLimit:           100,
-		WebFetchIntervalSeconds: 5,
-		EnabledScanners:         []string{"GITHUB_AGGREGATOR", "SUPERTEAM", "BOUNTYCASTER"},
-		GitHubLabels:            []string{"algora-bounty", "polar", "opire", "gitpay", "issuehunt", "bounty", "funded"},
-		GitHubPerPage:           100,
-		GitHubMaxPages:          10,
-		GitHubBaseURL:           "https://api.github.com",
-		SuperteamBaseURL:        "https://earn.superteam.fun/api/listings",
-		SuperteamStatuses:       []string{"open"},
-		BountycasterBaseURL:     "https://www.bountycaster.xyz/api/v1/bounties",
-		BountycasterStatuses:    []string{"open"},
-		UrgencyKeywords:         []string{"URGENT", "ASAP", "CRITICAL", "IMMEDIATE", "EMERGENCY"},
-		DevTaskKeywords:         []string{"FIX", "BUG", "API", "INTEGRATION", "SMART CONTRACT", "BLOCKCHAIN"},
-		AutomationKeywords:      []string{"SCRIPT", "BOT"},
-		SecurityKeywords:        []string{"SECURITY", "VULNERABILITY", "PENTEST", "HACK", "EXPLOIT"},
-		AuditKeywords:           []string{"AUDIT"},
-		PaymentPreferences:      []string{"USDC", "SOL", "ETH", "CASHAPP", "VENMO", "PAYPAL", "STRIPE", "WISE"},
-		CryptoCurrencies:        []string{"USDC", "USDT", "SOL", "ETH", "BTC", "MATIC", "AVAX", "ARB", "OP"},
-		P2PMethods:              []string{"CASHAPP", "VENMO", "CASH APP"},
-		FiatMethods:             []string{"USD", "PAYPAL", "STRIPE", "WISE"},
+		PollIntervalSeconds:      60,
+		PollMinIntervalSeconds:   15,
+		PollMaxIntervalSeconds:   900,
+		PollJitterPercent:        10,
+		MinScore:                 60,
+		StorageDriver:            "sqlite",
+		StoragePath:              "./data/bounties.db",
+		AutoMigrate:              true,
+		LogPath:                  "./data/bountyos.log",
+		LogToStdout:              true,
+		LogToStderr:              false,
+		QuietUILogs:              true,
+		ValidateLinksHTTP:        true,
+		LinkValidationTimeout:    5,
+		LinkValidationWorkers:    8,
+		LinkValidationPerHost:    2,
+		LinkValidationBudget:     200,
+		LinkCacheTTLHours:        24,
+		PipelineQueueSize:        100,
+		PipelineValidateWorkers:  8,
+		PipelineEnrichWorkers:    4,
+		PipelineAlertWorkers:     2,
+		WebStaticDir:             "./web/dist",
+		WebPort:                  12496,
+		WebBindAddress:           "127.0.0.1",
+		UIRefreshSeconds:         5,
+		TUIRecentLimit:           15,
+		APIBountiesLimit:         50,
+		WebFetchIntervalSeconds:  5,
+		EnabledScanners:          []string{"GITHUB_AGGREGATOR", "SUPERTEAM", "BOUNTYCASTER"},
+		GitHubLabels:             []string{"algora-bounty", "polar", "opire", "gitpay", "issuehunt", "bounty", "funded"},
+		GitHubPerPage:            100,
+		GitHubMaxPages:           10,
+		GitHubFullScanHours:      6,
+		GitHubBaseURL:            "https://api.github.com",
+		SuperteamBaseURL:         "https://earn.superteam.fun/api/listings",
+		SuperteamStatuses:        []string{"open"},
+		BountycasterBaseURL:      "https://www.bountycaster.xyz/api/v1/bounties",
+		BountycasterStatuses:     []string{"open"},
+		UrgencyKeywords:          []string{"URGENT", "ASAP", "CRITICAL", "IMMEDIATE", "EMERGENCY"},
+		DevTaskKeywords:          []string{"FIX", "BUG", "API", "INTEGRATION", "SMART CONTRACT", "BLOCKCHAIN"},
+		AutomationKeywords:       []string{"SCRIPT", "BOT"},
+		SecurityKeywords:         []string{"SECURITY", "VULNERABILITY", "PENTEST", "HACK", "EXPLOIT"},
+		AuditKeywords:            []string{"AUDIT"},
+		PaymentPreferences:       []string{"USDC", "SOL", "ETH", "CASHAPP", "VENMO", "PAYPAL", "STRIPE", "WISE"},
+		CryptoCurrencies:         []string{"USDC", "USDT", "SOL", "ETH", "BTC", "MATIC", "AVAX", "ARB", "OP"},
+		P2PMethods:               []string{"CASHAPP", "VENMO", "CASH APP"},
+		FiatMethods:              []string{"USD", "PAYPAL", "STRIPE", "WISE"},
+		PriceOracle:              "static",
+		PriceOracleURL:           "https://api.coingecko.com/api/v3",
+		PriceCacheSeconds:        300,
+		RewardFilterStage:        "alert",
+		RescoreIntervalSeconds:   300,
+		CloseMissingAfterHours:   24,
+		DedupTitleSimilarity:     0.85,
+		DedupWindowDays:          14,
+		RetentionAction:          "archive",
+		ArchiveDir:               "./data/archive",
+		MaintenanceIntervalHours: 24,
+		VacuumIntervalHours:      168,
 	}
 }
 
@@ -118,27 +191,44 @@ func applyEnvOverrides(cfg *Config) {
 	setString(&cfg.GitHubToken, "GITHUB_TOKEN")
 	setString(&cfg.DiscordWebhookURL, "DISCORD_WEBHOOK_URL")
 	setInt(&cfg.PollIntervalSeconds, "POLL_INTERVAL_SECONDS")
+	setInt(&cfg.PollMinIntervalSeconds, "POLL_MIN_INTERVAL_SECONDS")
+	setInt(&cfg.PollMaxIntervalSeconds, "POLL_MAX_INTERVAL_SECONDS")
+	setInt(&cfg.PollJitterPercent, "POLL_JITTER_PERCENT")
 	setInt(&cfg.MinScore, "MIN_SCORE")
+	setString(&cfg.StorageDriver, "STORAGE_DRIVER")
 	setString(&cfg.StoragePath, "STORAGE_PATH")
+	setString(&cfg.StorageDSN, "STORAGE_DSN")
+	setBool(&cfg.AutoMigrate, "AUTO_MIGRATE")
 	setString(&cfg.LogPath, "LOG_PATH")
 	setBool(&cfg.LogToStdout, "LOG_TO_STDOUT")
 	setBool(&cfg.LogToStderr, "LOG_TO_STDERR")
 	setBool(&cfg.QuietUILogs, "QUIET_UI_LOGS")
 	setBool(&cfg.ValidateLinksHTTP, "VALIDATE_LINKS_HTTP")
 	setInt(&cfg.LinkValidationTimeout, "LINK_VALIDATION_TIMEOUT_SECONDS")
+	setInt(&cfg.LinkValidationWorkers, "LINK_VALIDATION_WORKERS")
+	setInt(&cfg.LinkValidationPerHost, "LINK_VALIDATION_PER_HOST")
+	setInt(&cfg.LinkValidationBudget, "LINK_VALIDATION_BUDGET")
+	setInt(&cfg.LinkCacheTTLHours, "LINK_CACHE_TTL_HOURS")
+	setInt(&cfg.PipelineQueueSize, "PIPELINE_QUEUE_SIZE")
+	setInt(&cfg.PipelineValidateWorkers, "PIPELINE_VALIDATE_WORKERS")
+	setInt(&cfg.PipelineEnrichWorkers, "PIPELINE_ENRICH_WORKERS")
+	setInt(&cfg.PipelineAlertWorkers, "PIPELINE_ALERT_WORKERS")
 	setString(&cfg.WebStaticDir, "WEB_STATIC_DIR")
 	setInt(&cfg.WebPort, "WEB_PORT")
+	setString(&cfg.WebBindAddress, "WEB_BIND_ADDRESS")
+	setString(&cfg.WebAPIToken, "WEB_API_TOKEN")
 	setBool(&cfg.NoUI, "NO_UI")
+	setBool(&cfg.DemoMode, "DEMO_MODE")
 	setInt(&cfg.UIRefreshSeconds, "UI_REFRESH_SECONDS")
 	setInt(&cfg.TUIRecentLimit, "TUI_RECENT_LIMIT")
 	setInt(&cfg.APIBountiesLimit, "API_BOUNTIES_LIMIT")
-	setInt(&cfg.APIStatsLimit, "API_STATS_LIMIT")
 	setInt(&cfg.WebFetchIntervalSeconds, "WEB_FETCH_INTERVAL_SECONDS")
 	setBool(&cfg.DisableRateLimitSleep, "BOUNTYOS_DISABLE_RATE_LIMIT_SLEEP")
 	setList(&cfg.EnabledScanners, "ENABLED_SCANNERS")
 	setList(&cfg.GitHubLabels, "GITHUB_LABELS")
 	setInt(&cfg.GitHubPerPage, "GITHUB_PER_PAGE")
 	setInt(&cfg.GitHubMaxPages, "GITHUB_MAX_PAGES")
+	setInt(&cfg.GitHubFullScanHours, "GITHUB_FULL_SCAN_HOURS")
 	setString(&cfg.GitHubBaseURL, "GITHUB_BASE_URL")
 	setString(&cfg.SuperteamBaseURL, "SUPERTEAM_BASE_URL")
 	setList(&cfg.SuperteamStatuses, "SUPERTEAM_STATUSES")
@@ -153,6 +243,23 @@ func applyEnvOverrides(cfg *Config) {
 	setList(&cfg.CryptoCurrencies, "CRYPTO_CURRENCIES")
 	setList(&cfg.P2PMethods, "P2P_METHODS")
 	setList(&cfg.FiatMethods, "FIAT_METHODS")
+	setString(&cfg.ScoringRulesPath, "SCORING_RULES_PATH")
+	setInt(&cfg.RescoreIntervalSeconds, "RESCORE_INTERVAL_SECONDS")
+	setInt(&cfg.CloseMissingAfterHours, "CLOSE_MISSING_AFTER_HOURS")
+	setFloat(&cfg.DedupTitleSimilarity, "DEDUP_TITLE_SIMILARITY")
+	setInt(&cfg.DedupWindowDays, "DEDUP_WINDOW_DAYS")
+	setInt(&cfg.RetentionDays, "RETENTION_DAYS")
+	setString(&cfg.RetentionAction, "RETENTION_ACTION")
+	setString(&cfg.ArchiveDir, "ARCHIVE_DIR")
+	setInt(&cfg.MaintenanceIntervalHours, "MAINTENANCE_INTERVAL_HOURS")
+	setInt(&cfg.VacuumIntervalHours, "VACUUM_INTERVAL_HOURS")
+	setString(&cfg.PriceOracle, "PRICE_ORACLE")
+	setString(&cfg.PriceOracleURL, "PRICE_ORACLE_URL")
+	setInt(&cfg.PriceCacheSeconds, "PRICE_CACHE_SECONDS")
+	setFloat(&cfg.MinUSDValue, "MIN_USD_VALUE")
+	setBool(&cfg.DropUnquantifiedRewards, "DROP_UNQUANTIFIED_REWARDS")
+	setString(&cfg.RewardFilterStage, "REWARD_FILTER_STAGE")
+	setFloatMap(&cfg.MinCurrencyAmounts, "MIN_CURRENCY_AMOUNTS")
 }
 
 func normalize(cfg *Config) {
@@ -161,9 +268,22 @@ func normalize(cfg *Config) {
 	if cfg.PollIntervalSeconds <= 0 {
 		cfg.PollIntervalSeconds = defaults.PollIntervalSeconds
 	}
+	if cfg.PollMinIntervalSeconds <= 0 {
+		cfg.PollMinIntervalSeconds = defaults.PollMinIntervalSeconds
+	}
+	if cfg.PollMaxIntervalSeconds <= 0 {
+		cfg.PollMaxIntervalSeconds = defaults.PollMaxIntervalSeconds
+	}
+	if cfg.PollJitterPercent < 0 {
+		cfg.PollJitterPercent = defaults.PollJitterPercent
+	}
 	if cfg.MinScore <= 0 {
 		cfg.MinScore = defaults.MinScore
 	}
+	cfg.StorageDriver = strings.ToLower(strings.TrimSpace(cfg.StorageDriver))
+	if cfg.StorageDriver == "" {
+		cfg.StorageDriver = defaults.StorageDriver
+	}
 	if cfg.StoragePath == "" {
 		cfg.StoragePath = defaults.StoragePath
 	}
@@ -176,9 +296,34 @@ func normalize(cfg *Config) {
 	if cfg.LinkValidationTimeout <= 0 {
 		cfg.LinkValidationTimeout = defaults.LinkValidationTimeout
 	}
+	if cfg.LinkValidationWorkers <= 0 {
+		cfg.LinkValidationWorkers = defaults.LinkValidationWorkers
+	}
+	if cfg.LinkValidationPerHost <= 0 {
+		cfg.LinkValidationPerHost = defaults.LinkValidationPerHost
+	}
+	if cfg.LinkValidationBudget < 0 {
+		cfg.LinkValidationBudget = defaults.LinkValidationBudget
+	}
+	if cfg.LinkCacheTTLHours < 0 {
+		cfg.LinkCacheTTLHours = defaults.LinkCacheTTLHours
+	}
+	if cfg.PipelineQueueSize <= 0 {
+		cfg.PipelineQueueSize = defaults.PipelineQueueSize
+	}
+	if cfg.PipelineValidateWorkers <= 0 {
+		cfg.PipelineValidateWorkers = defaults.PipelineValidateWorkers
+	}
+	if cfg.PipelineEnrichWorkers <= 0 {
+		cfg.PipelineEnrichWorkers = defaults.PipelineEnrichWorkers
+	}
+	if cfg.PipelineAlertWorkers <= 0 {
+		cfg.PipelineAlertWorkers = defaults.PipelineAlertWorkers
+	}
 	if cfg.WebPort <= 0 {
 		cfg.WebPort = defaults.WebPort
 	}
+	cfg.WebBindAddress = firstNonEmpty(strings.TrimSpace(cfg.WebBindAddress), defaults.WebBindAddress)
 	if cfg.UIRefreshSeconds <= 0 {
 		cfg.UIRefreshSeconds = defaults.UIRefreshSeconds
 	}
@@ -188,12 +333,40 @@ func normalize(cfg *Config) {
 	if cfg.APIBountiesLimit <= 0 {
 		cfg.APIBountiesLimit = defaults.APIBountiesLimit
 	}
-	if cfg.APIStatsLimit <= 0 {
-		cfg.APIStatsLimit = defaults.APIStatsLimit
+	if cfg.CloseMissingAfterHours <= 0 {
+		cfg.CloseMissingAfterHours = defaults.CloseMissingAfterHours
+	}
+	if cfg.DedupTitleSimilarity < 0 || cfg.DedupTitleSimilarity > 1 {
+		cfg.DedupTitleSimilarity = defaults.DedupTitleSimilarity
+	}
+	if cfg.DedupWindowDays <= 0 {
+		cfg.DedupWindowDays = defaults.DedupWindowDays
+	}
+	if cfg.RescoreIntervalSeconds <= 0 {
+		cfg.RescoreIntervalSeconds = defaults.RescoreIntervalSeconds
+	}
+	if cfg.RetentionDays < 0 {
+		cfg.RetentionDays = 0
+	}
+	cfg.RetentionAction = strings.ToLower(strings.TrimSpace(cfg.RetentionAction))
+	if cfg.RetentionAction != "archive" && cfg.RetentionAction != "delete" {
+		cfg.RetentionAction = defaults.RetentionAction
+	}
+	if cfg.ArchiveDir == "" {
+		cfg.ArchiveDir = defaults.ArchiveDir
+	}
+	if cfg.MaintenanceIntervalHours <= 0 {
+		cfg.MaintenanceIntervalHours = defaults.MaintenanceIntervalHours
+	}
+	if cfg.VacuumIntervalHours < 0 {
+		cfg.VacuumIntervalHours = defaults.VacuumIntervalHours
 	}
 	if cfg.WebFetchIntervalSeconds <= 0 {
 		cfg.WebFetchIntervalSeconds = defaults.WebFetchIntervalSeconds
 	}
+	if cfg.GitHubFullScanHours < 0 {
+		cfg.GitHubFullScanHours = defaults.GitHubFullScanHours
+	}
 
 	cfg.EnabledScanners = normalizeUpperList(coalesceList(cfg.EnabledScanners, defaults.EnabledScanners))
 	cfg.GitHubLabels = normalizeTrimList(coalesceList(cfg.GitHubLabels, defaults.GitHubLabels))
@@ -226,6 +399,119 @@ func normalize(cfg *Config) {
 
 	cfg.DevTaskKeywords = removeOverlap(cfg.DevTaskKeywords, cfg.AutomationKeywords)
 	cfg.SecurityKeywords = removeOverlap(cfg.SecurityKeywords, cfg.AuditKeywords)
+
+	cfg.PriceOracle = strings.ToLower(strings.TrimSpace(firstNonEmpty(cfg.PriceOracle, defaults.PriceOracle)))
+	cfg.PriceOracleURL = strings.TrimRight(firstNonEmpty(cfg.PriceOracleURL, defaults.PriceOracleURL), "/")
+	if cfg.PriceCacheSeconds <= 0 {
+		cfg.PriceCacheSeconds = defaults.PriceCacheSeconds
+	}
+	if cfg.MinUSDValue < 0 {
+		cfg.MinUSDValue = 0
+	}
+	cfg.RewardFilterStage = strings.ToLower(strings.TrimSpace(cfg.RewardFilterStage))
+	if cfg.RewardFilterStage != "save" && cfg.RewardFilterStage != "alert" {
+		cfg.RewardFilterStage = defaults.RewardFilterStage
+	}
+	cfg.MinCurrencyAmounts = normalizeFloatMap(cfg.MinCurrencyAmounts)
+
+	cfg.Scanners = normalizeScannerBlocks(cfg.Scanners)
+}
+
+// ScannerOptions returns the raw option block for a scanner instance. The
+// legacy top-level keys (GITHUB_LABELS, SUPERTEAM_BASE_URL, ...) seed the
+// built-in scanners and are overridden by an explicit SCANNERS block.
+func (c *Config) ScannerOptions(name string) map[string]interface{} {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	opts := make(map[string]interface{})
+
+	switch name {
+	case "GITHUB", "GITHUB_AGGREGATOR":
+		opts["token"] = c.GitHubToken
+		opts["base_url"] = c.GitHubBaseURL
+		opts["labels"] = c.GitHubLabels
+		opts["per_page"] = c.GitHubPerPage
+		opts["max_pages"] = c.GitHubMaxPages
+		opts["full_scan_hours"] = c.GitHubFullScanHours
+	case "SUPERTEAM":
+		opts["base_url"] = c.SuperteamBaseURL
+		opts["statuses"] = c.SuperteamStatuses
+	case "BOUNTYCASTER":
+		opts["base_url"] = c.BountycasterBaseURL
+		opts["statuses"] = c.BountycasterStatuses
+	}
+
+	for key, value := range c.Scanners[name] {
+		opts[key] = value
+	}
+	return opts
+}
+
+// CanonicalizeScanners rekeys the SCANNERS blocks by canonical, such as
+// scanners.CanonicalName, so a block keyed by an alias or in another case
+// applies to the scanner ENABLED_SCANNERS resolves it to. When blocks merge,
+// the one already keyed by the canonical name wins.
+func (c *Config) CanonicalizeScanners(canonical func(name string) string) {
+	names := make([]string, 0, len(c.Scanners))
+	for name := range c.Scanners {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		// Aliases first, so canonical keys are applied last.
+		iCanonical, jCanonical := canonical(names[i]) == names[i], canonical(names[j]) == names[j]
+		if iCanonical != jCanonical {
+			return jCanonical
+		}
+		return names[i] < names[j]
+	})
+
+	out := make(map[string]map[string]interface{}, len(c.Scanners))
+	for _, name := range names {
+		key := canonical(name)
+		if out[key] == nil {
+			out[key] = make(map[string]interface{}, len(c.Scanners[name]))
+		}
+		for option, value := range c.Scanners[name] {
+			out[key][option] = value
+		}
+	}
+	c.Scanners = out
+}
+
+// StorageTarget is what the storage driver connects to: STORAGE_PATH for
+// SQLite, STORAGE_DSN otherwise.
+func (c *Config) StorageTarget() string {
+	if c.StorageDriver == "sqlite" || c.StorageDriver == "sqlite3" {
+		return c.StoragePath
+	}
+	return c.StorageDSN
+}
+
+func normalizeFloatMap(in map[string]float64) map[string]float64 {
+	out := make(map[string]float64, len(in))
+	for key, value := range in {
+		key = strings.ToUpper(strings.TrimSpace(key))
+		if key == "" || value <= 0 {
+			continue
+		}
+		out[key] = value
+	}
+	return out
+}
+
+func normalizeScannerBlocks(blocks map[string]map[string]interface{}) map[string]map[string]interface{} {
+	out := make(map[string]map[string]interface{}, len(blocks))
+	for name, block := range blocks {
+		normalized := strings.ToUpper(strings.TrimSpace(name))
+		if normalized == "" {
+			continue
+		}
+		opts := make(map[string]interface{}, len(block))
+		for key, value := range block {
+			opts[strings.ToLower(strings.TrimSpace(key))] = value
+		}
+		out[normalized] = opts
+	}
+	return out
 }
 
 func derivePaymentTiers(cfg *Config) {
@@ -277,6 +563,33 @@ func setInt(target *int, key string) {
 	}
 }
 
+func setFloat(target *float64, key string) {
+	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			*target = parsed
+		}
+	}
+}
+
+// setFloatMap parses "SOL=2,USDC=50" style overrides.
+func setFloatMap(target *map[string]float64, key string) {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return
+	}
+	out := make(map[string]float64)
+	for _, pair := range splitList(value) {
+		name, amount, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		if parsed, err := strconv.ParseFloat(strings.TrimSpace(amount), 64); err == nil {
+			out[strings.TrimSpace(name)] = parsed
+		}
+	}
+	*target = out
+}
+
 func setBool(target *bool, key string) {
 	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
 		if parsed, err := strconv.ParseBool(value); err == nil {
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bountyos-v8/internal/adapters/scanners"
+)
+
+func TestScannerOptions_AliasBlocks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `ENABLED_SCANNERS: ["h1", "feed"]
+SCANNERS:
+  h1:
+    username: "hunter"
+    token: "alias"
+  HACKERONE:
+    token: "canonical"
+  Atom:
+    feeds: ["https://example.com/feed.xml"]
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	cfg.CanonicalizeScanners(scanners.CanonicalName)
+
+	h1 := cfg.ScannerOptions(scanners.CanonicalName("H1"))
+	if h1["username"] != "hunter" || h1["token"] != "canonical" {
+		t.Errorf("ScannerOptions(H1) = %v; want the alias block merged under HACKERONE's", h1)
+	}
+	feeds, ok := cfg.ScannerOptions(scanners.CanonicalName("feed"))["feeds"].([]interface{})
+	if !ok || len(feeds) != 1 {
+		t.Errorf("ScannerOptions(feed) feeds = %v; want the ATOM block", feeds)
+	}
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"bountyos-v8/internal/core"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ScoringRulesFile is the layout of a SCORING_RULES_PATH file.
+type ScoringRulesFile struct {
+	Rules []core.Rule `yaml:"rules"`
+}
+
+// LoadScoringRules reads scoring rules from a YAML file and validates them.
+func LoadScoringRules(path string) ([]core.Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file ScoringRulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(file.Rules) == 0 {
+		return nil, fmt.Errorf("%s defines no rules", path)
+	}
+	if _, err := core.NewRuleEngine(file.Rules); err != nil {
+		return nil, err
+	}
+	return file.Rules, nil
+}
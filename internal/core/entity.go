@@ -1,6 +1,8 @@
 package core
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"strings"
 	"time"
 )
@@ -19,6 +21,34 @@ type Bounty struct {
 	Tags        []string   `json:"tags"`
 	ExpiresAt   *time.Time `json:"expires_at"`
 	PaymentType string     `json:"payment_type"`
+
+	// SourceID is the listing's ID at its source, kept from the scanner's
+	// ID before the pipeline assigns the storage ID.
+	SourceID string `json:"source_id,omitempty"`
+
+	// Structured reward parsed from Reward and Currency; see ParseReward.
+	RewardMin   float64 `json:"reward_min"`
+	RewardMax   float64 `json:"reward_max"`
+	RewardToken string  `json:"reward_token"`
+	RewardExact bool    `json:"reward_exact"`
+
+	// RewardUSD is RewardMax converted through the configured PriceOracle.
+	RewardUSD float64 `json:"reward_usd"`
+
+	// ScoreBreakdown lists the scoring rules that contributed to Score.
+	ScoreBreakdown []ScoreContribution `json:"score_breakdown,omitempty"`
+
+	// Lifecycle, maintained by storage across scans.
+	Status    BountyStatus `json:"status"`
+	FirstSeen time.Time    `json:"first_seen"`
+	LastSeen  time.Time    `json:"last_seen"`
+}
+
+// BountyID derives the stable storage ID for a bounty from its normalized URL.
+// Scanner-specific IDs are not unique across platforms.
+func BountyID(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:8])
 }
 
 // PaymentPriority defines the priority hierarchy
@@ -31,6 +61,20 @@ const (
 	LowPriority                         // Everything else
 )
 
+// String returns the tier name, e.g. "CryptoKing".
+func (p PaymentPriority) String() string {
+	switch p {
+	case CryptoKing:
+		return "CryptoKing"
+	case P2PPremium:
+		return "P2PPremium"
+	case FiatStandard:
+		return "FiatStandard"
+	default:
+		return "LowPriority"
+	}
+}
+
 type PaymentConfig struct {
 	CryptoCurrencies []string
 	P2PMethods       []string
@@ -41,6 +85,7 @@ var paymentConfig = defaultPaymentConfig()
 
 func SetPaymentConfig(cfg PaymentConfig) {
 	paymentConfig = normalizePaymentConfig(cfg)
+	resetDefaultEngine()
 }
 
 // GetPaymentPriority returns the priority level for a given currency/payment type
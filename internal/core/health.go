@@ -0,0 +1,65 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// ScanErrorKind classifies why a scanner failed to read its source.
+type ScanErrorKind string
+
+const (
+	ScanErrorRequest ScanErrorKind = "request" // transport failure or retries exhausted
+	ScanErrorStatus  ScanErrorKind = "status"  // upstream answered with a non-2xx status
+	ScanErrorDecode  ScanErrorKind = "decode"  // payload could not be parsed
+)
+
+// ScanError is returned or reported by scanners when an upstream source
+// fails. Scanners never substitute placeholder data for a failed source.
+type ScanError struct {
+	Scanner    string
+	Kind       ScanErrorKind
+	URL        string
+	StatusCode int
+	Err        error
+}
+
+func (e *ScanError) Error() string {
+	prefix := string(e.Kind)
+	if e.Scanner != "" {
+		prefix = e.Scanner + ": " + prefix
+	}
+	if e.Err == nil {
+		return fmt.Sprintf("%s error for %s", prefix, e.URL)
+	}
+	return fmt.Sprintf("%s error: %v", prefix, e.Err)
+}
+
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// HealthStatus summarizes the outcome of a scanner's most recent pass.
+type HealthStatus string
+
+const (
+	HealthUnknown  HealthStatus = "unknown"  // not scanned yet
+	HealthOK       HealthStatus = "ok"       // last pass had no errors
+	HealthDegraded HealthStatus = "degraded" // last pass returned data but reported errors
+	HealthFailing  HealthStatus = "failing"  // last pass returned nothing and reported errors
+)
+
+// ScannerHealth is the per-scanner status exposed to the TUI and web UI.
+type ScannerHealth struct {
+	Name                string        `json:"name"`
+	Status              HealthStatus  `json:"status"`
+	LastScanAt          *time.Time    `json:"last_scan_at"`
+	LastSuccessAt       *time.Time    `json:"last_success_at"`
+	LastDurationMS      int64         `json:"last_duration_ms"`
+	LastCount           int           `json:"last_count"`
+	LastError           string        `json:"last_error,omitempty"`
+	LastErrorKind       ScanErrorKind `json:"last_error_kind,omitempty"`
+	ConsecutiveFailures int           `json:"consecutive_failures"`
+	IntervalSeconds     int           `json:"interval_seconds"` // current adaptive polling interval
+	NextScanAt          *time.Time    `json:"next_scan_at"`
+}
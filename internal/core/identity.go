@@ -0,0 +1,144 @@
+package core
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// BountySource is one platform, label or listing under which a bounty was
+// seen. Match records how the sighting was tied to the stored bounty: "url"
+// for the same canonical URL, "link" when the listing links to it and
+// "title" for a fuzzy title match.
+type BountySource struct {
+	Platform  string    `json:"platform"`
+	Label     string    `json:"label,omitempty"`
+	SourceID  string    `json:"source_id,omitempty"`
+	URL       string    `json:"url"`
+	Match     string    `json:"match"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// Duplicate match kinds.
+const (
+	MatchURL   = "url"
+	MatchLink  = "link"
+	MatchTitle = "title"
+)
+
+// DedupOptions controls how listings from different sources are linked.
+type DedupOptions struct {
+	// TitleSimilarity is the minimum TitleSimilarity for a fuzzy match;
+	// zero disables title matching.
+	TitleSimilarity float64
+	// Window bounds how far apart two listings' CreatedAt may be for a
+	// title match.
+	Window time.Duration
+}
+
+// SourceOf describes where b was seen. Scanner platforms of the form
+// "GITHUB/BOUNTY" are split into platform and label.
+func SourceOf(b Bounty) BountySource {
+	platform, label, _ := strings.Cut(b.Platform, "/")
+	return BountySource{
+		Platform: platform,
+		Label:    label,
+		SourceID: b.SourceID,
+		URL:      b.URL,
+	}
+}
+
+// trackingParams are query parameters that never change what a URL points at.
+var trackingParams = map[string]bool{
+	"ref": true, "source": true, "fbclid": true, "gclid": true,
+}
+
+// CanonicalURL reduces a listing URL to a stable identity: https scheme,
+// lower-case host without "www.", no fragment, credentials, tracking
+// parameters or trailing slash, and sorted query parameters. Unparseable
+// input is returned trimmed.
+func CanonicalURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme == "http" {
+		u.Scheme = "https"
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+	u.Host = host
+	u.User = nil
+	u.Fragment = ""
+	u.RawFragment = ""
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+
+	query := u.Query()
+	for key := range query {
+		if trackingParams[strings.ToLower(key)] || strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+var linkPattern = regexp.MustCompile(`https?://[^\s<>"'()\[\]]+`)
+
+// ExtractLinks returns the canonical form of each http(s) URL in text, in
+// order of first appearance.
+func ExtractLinks(text string) []string {
+	var links []string
+	seen := make(map[string]bool)
+	for _, match := range linkPattern.FindAllString(text, -1) {
+		link := CanonicalURL(strings.TrimRight(match, ".,;:!?"))
+		if !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// TitleSimilarity returns the Jaccard similarity of the word sets of two
+// titles, ignoring case, punctuation and bracketed tags such as "[Bounty]".
+// Titles with fewer than three words score 0: they are too generic to match.
+func TitleSimilarity(a, b string) float64 {
+	wa, wb := titleWords(a), titleWords(b)
+	if len(wa) < 3 || len(wb) < 3 {
+		return 0
+	}
+	shared := 0
+	for word := range wa {
+		if wb[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wa)+len(wb)-shared)
+}
+
+var bracketed = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\)`)
+
+func titleWords(title string) map[string]bool {
+	title = bracketed.ReplaceAllString(strings.ToLower(title), " ")
+	words := strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		if word == "bounty" {
+			continue
+		}
+		set[word] = true
+	}
+	return set
+}
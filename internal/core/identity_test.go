@@ -0,0 +1,51 @@
+package core
+
+import "testing"
+
+func TestCanonicalURL(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/org/repo/issues/1":                 "https://github.com/org/repo/issues/1",
+		"http://www.GitHub.com/org/repo/issues/1/":             "https://github.com/org/repo/issues/1",
+		"https://github.com/org/repo/issues/1#issuecomment-9":  "https://github.com/org/repo/issues/1",
+		"https://example.com/b?utm_source=x&id=7&ref=feed&a=1": "https://example.com/b?a=1&id=7",
+		"https://user:pw@example.com:443/listing":              "https://example.com/listing",
+		"https://example.com:8443/listing":                     "https://example.com:8443/listing",
+		"  not a url  ":                                        "not a url",
+	}
+	for input, want := range tests {
+		if got := CanonicalURL(input); got != want {
+			t.Errorf("CanonicalURL(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestExtractLinks(t *testing.T) {
+	text := "Bounty for https://github.com/org/repo/issues/1. See (https://www.github.com/org/repo/issues/1/) and http://docs.example.com/x, thanks"
+	links := ExtractLinks(text)
+	if len(links) != 2 || links[0] != "https://github.com/org/repo/issues/1" || links[1] != "https://docs.example.com/x" {
+		t.Errorf("ExtractLinks() = %v", links)
+	}
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	same := TitleSimilarity("[Bounty] Add Solana wallet adapter support", "Add Solana Wallet Adapter support (500 USDC)")
+	if same != 1 {
+		t.Errorf("similar titles = %v, want 1", same)
+	}
+	if got := TitleSimilarity("Add Solana wallet adapter support", "Fix flaky CI on Windows runners"); got != 0 {
+		t.Errorf("unrelated titles = %v, want 0", got)
+	}
+	if got := TitleSimilarity("Fix bug", "Fix bug"); got != 0 {
+		t.Errorf("short titles = %v, want 0", got)
+	}
+}
+
+func TestSourceOf(t *testing.T) {
+	src := SourceOf(Bounty{Platform: "GITHUB/ALGORA-BOUNTY", URL: "https://github.com/o/r/issues/2", SourceID: "42"})
+	if src.Platform != "GITHUB" || src.Label != "ALGORA-BOUNTY" || src.SourceID != "42" {
+		t.Errorf("SourceOf() = %+v", src)
+	}
+	if src := SourceOf(Bounty{Platform: "BOUNTYCASTER"}); src.Platform != "BOUNTYCASTER" || src.Label != "" {
+		t.Errorf("SourceOf(BOUNTYCASTER) = %+v", src)
+	}
+}
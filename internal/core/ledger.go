@@ -0,0 +1,148 @@
+package core
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LedgerEntry is one payment actually received for a bounty.
+type LedgerEntry struct {
+	ID         int64     `json:"id"`
+	BountyID   string    `json:"bounty_id"`
+	URL        string    `json:"url"`
+	Title      string    `json:"title"`
+	Platform   string    `json:"platform"`
+	Amount     float64   `json:"amount"`
+	Token      string    `json:"token"`
+	AmountUSD  float64   `json:"amount_usd"`
+	Reference  string    `json:"reference"` // tx hash or payment reference
+	Tier       string    `json:"tier"`      // PaymentPriority name of the received token
+	ReceivedAt time.Time `json:"received_at"`
+	RecordedAt time.Time `json:"recorded_at"`
+
+	// From the bounty's pipeline record, for reconciliation.
+	ExpectedPayout float64    `json:"expected_payout"`
+	PayoutCurrency string     `json:"payout_currency"`
+	ClaimedAt      *time.Time `json:"claimed_at,omitempty"`
+}
+
+// PrepareLedgerEntry normalizes e's token, derives its payment tier from the
+// token and the bounty's payment type, and values it in USD through oracle
+// when no USD amount was given. A missing price leaves AmountUSD at zero.
+func PrepareLedgerEntry(ctx context.Context, oracle PriceOracle, e *LedgerEntry, paymentType string) {
+	e.Token = strings.ToUpper(strings.TrimSpace(e.Token))
+	probe := Bounty{Currency: e.Token, PaymentType: paymentType}
+	e.Tier = probe.GetPaymentPriority().String()
+	if e.AmountUSD > 0 || oracle == nil || e.Token == "" {
+		return
+	}
+	if price, err := oracle.PriceUSD(ctx, e.Token); err == nil {
+		e.AmountUSD = e.Amount * price
+	}
+}
+
+// EarningsBucket totals payments sharing a month, platform or tier.
+type EarningsBucket struct {
+	Key      string             `json:"key"`
+	Payments int                `json:"payments"`
+	USD      float64            `json:"usd"`
+	ByToken  map[string]float64 `json:"by_token"`
+}
+
+// EarningsReport summarizes the ledger.
+type EarningsReport struct {
+	Payments   int                `json:"payments"`
+	TotalUSD   float64            `json:"total_usd"`
+	ByToken    map[string]float64 `json:"by_token"`
+	ByMonth    []EarningsBucket   `json:"by_month"`    // oldest first, "2006-01"
+	ByPlatform []EarningsBucket   `json:"by_platform"` // highest USD first
+	ByTier     []EarningsBucket   `json:"by_tier"`     // CryptoKing first
+
+	// Average from claim to the last payment received, over bounties whose
+	// claim time is known.
+	AvgClaimToPaidHours float64 `json:"avg_claim_to_paid_hours"`
+	ClaimToPaidSamples  int     `json:"claim_to_paid_samples"`
+
+	// Bounties marked paid with no ledger entry.
+	Unreconciled []Tracking `json:"unreconciled"`
+}
+
+// BuildEarningsReport aggregates ledger entries.
+func BuildEarningsReport(entries []LedgerEntry) EarningsReport {
+	report := EarningsReport{
+		ByToken:      map[string]float64{},
+		ByMonth:      []EarningsBucket{},
+		ByPlatform:   []EarningsBucket{},
+		ByTier:       []EarningsBucket{},
+		Unreconciled: []Tracking{},
+	}
+	months := map[string]*EarningsBucket{}
+	platforms := map[string]*EarningsBucket{}
+	tiers := map[string]*EarningsBucket{}
+	lastPaid := map[string]LedgerEntry{}
+
+	for _, e := range entries {
+		report.Payments++
+		report.TotalUSD += e.AmountUSD
+		report.ByToken[e.Token] += e.Amount
+		addToBucket(months, e.ReceivedAt.UTC().Format("2006-01"), e)
+		addToBucket(platforms, e.Platform, e)
+		addToBucket(tiers, e.Tier, e)
+		if last, ok := lastPaid[e.URL]; !ok || e.ReceivedAt.After(last.ReceivedAt) {
+			lastPaid[e.URL] = e
+		}
+	}
+
+	var total time.Duration
+	for _, e := range lastPaid {
+		if e.ClaimedAt == nil || e.ReceivedAt.Before(*e.ClaimedAt) {
+			continue
+		}
+		total += e.ReceivedAt.Sub(*e.ClaimedAt)
+		report.ClaimToPaidSamples++
+	}
+	if report.ClaimToPaidSamples > 0 {
+		report.AvgClaimToPaidHours = (total / time.Duration(report.ClaimToPaidSamples)).Hours()
+	}
+
+	report.ByMonth = sortedBuckets(months, func(a, b EarningsBucket) bool { return a.Key < b.Key })
+	report.ByPlatform = sortedBuckets(platforms, func(a, b EarningsBucket) bool {
+		if a.USD != b.USD {
+			return a.USD > b.USD
+		}
+		return a.Key < b.Key
+	})
+	report.ByTier = sortedBuckets(tiers, func(a, b EarningsBucket) bool { return tierRank(a.Key) < tierRank(b.Key) })
+	return report
+}
+
+func addToBucket(buckets map[string]*EarningsBucket, key string, e LedgerEntry) {
+	bucket, ok := buckets[key]
+	if !ok {
+		bucket = &EarningsBucket{Key: key, ByToken: map[string]float64{}}
+		buckets[key] = bucket
+	}
+	bucket.Payments++
+	bucket.USD += e.AmountUSD
+	bucket.ByToken[e.Token] += e.Amount
+}
+
+func sortedBuckets(buckets map[string]*EarningsBucket, less func(a, b EarningsBucket) bool) []EarningsBucket {
+	out := make([]EarningsBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		out = append(out, *bucket)
+	}
+	sort.Slice(out, func(i, j int) bool { return less(out[i], out[j]) })
+	return out
+}
+
+func tierRank(name string) int {
+	for p := CryptoKing; p <= LowPriority; p++ {
+		if p.String() == name {
+			return int(p)
+		}
+	}
+	return int(LowPriority) + 1
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type fixedOracle map[string]float64
+
+func (o fixedOracle) PriceUSD(_ context.Context, token string) (float64, error) {
+	if price, ok := o[token]; ok {
+		return price, nil
+	}
+	return 0, ErrPriceUnavailable
+}
+
+func TestPrepareLedgerEntry(t *testing.T) {
+	oracle := fixedOracle{"SOL": 150}
+
+	sol := LedgerEntry{Amount: 2, Token: " sol "}
+	PrepareLedgerEntry(context.Background(), oracle, &sol, "crypto")
+	if sol.Token != "SOL" || sol.Tier != "CryptoKing" || sol.AmountUSD != 300 {
+		t.Errorf("SOL entry = %+v", sol)
+	}
+
+	venmo := LedgerEntry{Amount: 40, Token: "venmo"}
+	PrepareLedgerEntry(context.Background(), oracle, &venmo, "p2p")
+	if venmo.Tier != "P2PPremium" || venmo.AmountUSD != 0 {
+		t.Errorf("Venmo entry = %+v", venmo)
+	}
+
+	given := LedgerEntry{Amount: 2, Token: "SOL", AmountUSD: 280}
+	PrepareLedgerEntry(context.Background(), oracle, &given, "crypto")
+	if given.AmountUSD != 280 {
+		t.Errorf("explicit AmountUSD overwritten: %v", given.AmountUSD)
+	}
+}
+
+func TestBuildEarningsReport(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	claimedA, claimedB := day("2024-04-28"), day("2024-05-01")
+
+	report := BuildEarningsReport([]LedgerEntry{
+		{URL: "a", Platform: "SUPERTEAM", Amount: 500, Token: "USDC", AmountUSD: 500, Tier: "CryptoKing", ReceivedAt: day("2024-04-30"), ClaimedAt: &claimedA},
+		{URL: "a", Platform: "SUPERTEAM", Amount: 250, Token: "USDC", AmountUSD: 250, Tier: "CryptoKing", ReceivedAt: day("2024-05-02"), ClaimedAt: &claimedA},
+		{URL: "b", Platform: "GITHUB", Amount: 100, Token: "PAYPAL", AmountUSD: 100, Tier: "FiatStandard", ReceivedAt: day("2024-05-03"), ClaimedAt: &claimedB},
+		{URL: "c", Platform: "GITHUB", Amount: 30, Token: "VENMO", AmountUSD: 30, Tier: "P2PPremium", ReceivedAt: day("2024-05-04")},
+	})
+
+	if report.Payments != 4 || report.TotalUSD != 880 || report.ByToken["USDC"] != 750 {
+		t.Errorf("totals = %d payments, $%v, %v", report.Payments, report.TotalUSD, report.ByToken)
+	}
+	if len(report.ByMonth) != 2 || report.ByMonth[0].Key != "2024-04" || report.ByMonth[1].USD != 380 {
+		t.Errorf("ByMonth = %+v", report.ByMonth)
+	}
+	if len(report.ByPlatform) != 2 || report.ByPlatform[0].Key != "SUPERTEAM" || report.ByPlatform[1].Payments != 2 {
+		t.Errorf("ByPlatform = %+v", report.ByPlatform)
+	}
+	tiers := []string{}
+	for _, b := range report.ByTier {
+		tiers = append(tiers, b.Key)
+	}
+	if len(tiers) != 3 || tiers[0] != "CryptoKing" || tiers[1] != "P2PPremium" || tiers[2] != "FiatStandard" {
+		t.Errorf("ByTier order = %v", tiers)
+	}
+	// a: Apr 28 -> May 2 (96h), b: May 1 -> May 3 (48h); c has no claim time.
+	if report.ClaimToPaidSamples != 2 || report.AvgClaimToPaidHours != 72 {
+		t.Errorf("claim to paid = %vh over %d", report.AvgClaimToPaidHours, report.ClaimToPaidSamples)
+	}
+}
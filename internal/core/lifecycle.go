@@ -0,0 +1,41 @@
+package core
+
+import (
+	"strings"
+	"time"
+)
+
+// BountyStatus is the lifecycle state of a listing at its source.
+type BountyStatus string
+
+const (
+	StatusOpen    BountyStatus = "open"    // listed and accepting work
+	StatusClosed  BountyStatus = "closed"  // no longer returned by its source
+	StatusExpired BountyStatus = "expired" // ExpiresAt has passed
+	StatusClaimed BountyStatus = "claimed" // the source reports it as taken
+)
+
+// ParseBountyStatus maps a source or API status onto a BountyStatus. Unknown
+// values are treated as open.
+func ParseBountyStatus(value string) BountyStatus {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "closed", "completed", "complete", "done", "cancelled", "canceled":
+		return StatusClosed
+	case "expired":
+		return StatusExpired
+	case "claimed", "in-progress", "inprogress", "assigned", "taken":
+		return StatusClaimed
+	default:
+		return StatusOpen
+	}
+}
+
+// BountyChange is one recorded difference between two observations of a
+// listing, or a status transition.
+type BountyChange struct {
+	URL       string    `json:"url"`
+	Field     string    `json:"field"`
+	OldValue  string    `json:"old_value"`
+	NewValue  string    `json:"new_value"`
+	ChangedAt time.Time `json:"changed_at"`
+}
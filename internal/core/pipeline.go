@@ -0,0 +1,14 @@
+package core
+
+// StageStats is the monitoring snapshot of one ingest pipeline stage.
+type StageStats struct {
+	Name          string  `json:"name"`
+	Workers       int     `json:"workers"`
+	QueueDepth    int     `json:"queue_depth"` // bounties waiting for a worker
+	QueueCapacity int     `json:"queue_capacity"`
+	Processed     int64   `json:"processed"`
+	Dropped       int64   `json:"dropped"` // processed but not passed on
+	AvgLatencyMS  float64 `json:"avg_latency_ms"`
+	MaxLatencyMS  float64 `json:"max_latency_ms"`
+	BlockedMS     int64   `json:"blocked_ms"` // waiting on a full downstream queue
+}
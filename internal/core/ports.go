@@ -21,3 +21,8 @@ type Storage interface {
 	GetRecent(limit int) ([]Bounty, error)
 	Close() error
 }
+
+// PriceOracle converts token symbols into USD prices
+type PriceOracle interface {
+	PriceUSD(ctx context.Context, token string) (float64, error)
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidQuery is returned for a search query the backend cannot parse.
+var ErrInvalidQuery = errors.New("invalid search query")
+
+// ErrInvalidCursor is returned for a page cursor that is malformed or was
+// issued for a different sort.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// SearchFilters narrows a full-text search. Zero values leave a filter off.
+type SearchFilters struct {
+	Platform      string       // prefix match, so "GITHUB" covers "GITHUB/BOUNTY"
+	PaymentType   string       // crypto, p2p, fiat
+	Status        BountyStatus // lifecycle status; empty matches all
+	MinScore      *int
+	MaxScore      *int
+	MinUSD        float64 // RewardUSD range
+	MaxUSD        float64
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	Limit  int // page size; defaults to 20
+	Offset int
+}
+
+// SearchResult is one page of search hits.
+type SearchResult struct {
+	Total    int      `json:"total"` // hits across all pages
+	Limit    int      `json:"limit"`
+	Offset   int      `json:"offset"`
+	Bounties []Bounty `json:"results"`
+}
+
+// BountySort is a field bounties can be ordered by.
+type BountySort string
+
+const (
+	SortScore     BountySort = "score"
+	SortCreatedAt BountySort = "created_at"
+	SortRewardUSD BountySort = "reward_usd"
+	SortExpiresAt BountySort = "expires_at"
+)
+
+// ParseBountySort validates a sort field; empty selects SortScore.
+func ParseBountySort(value string) (BountySort, error) {
+	switch sort := BountySort(strings.ToLower(strings.TrimSpace(value))); sort {
+	case "":
+		return SortScore, nil
+	case SortScore, SortCreatedAt, SortRewardUSD, SortExpiresAt:
+		return sort, nil
+	default:
+		return "", fmt.Errorf("unknown sort %q: want score, created_at, reward_usd or expires_at", value)
+	}
+}
+
+// BountyQuery selects a page of stored bounties. Zero values leave a filter
+// off, except Statuses, which defaults to open bounties only.
+type BountyQuery struct {
+	Platform      string // prefix match, so "GITHUB" covers "GITHUB/BOUNTY"
+	Currency      string // matches Currency or the parsed RewardToken
+	PaymentType   string
+	MinScore      *int
+	Tag           string
+	CreatedAfter  time.Time
+	ExpiresBefore time.Time // bounties without a deadline never match
+	Statuses      []BountyStatus
+
+	// Sort defaults to SortScore. Ascending reverses it; bounties without
+	// a deadline sort after those with one either way.
+	Sort      BountySort
+	Ascending bool
+
+	Limit  int    // page size; defaults to 50
+	Cursor string // NextCursor of the previous page; empty for the first
+}
+
+// BountyPage is one page of a BountyQuery. NextCursor is empty on the last
+// page.
+type BountyPage struct {
+	Bounties   []Bounty `json:"results"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
+
+// BountyStats summarises the bounties matching a BountyQuery.
+type BountyStats struct {
+	TotalCount  int            `json:"total_count"`
+	ByPlatform  map[string]int `json:"by_platform"`
+	AvgScore    float64        `json:"avg_score"`
+	CryptoCount int            `json:"crypto_count"`
+}
@@ -0,0 +1,32 @@
+package core
+
+import "time"
+
+// RetiredStatuses are the statuses whose bounties the retention policy
+// removes once they have held them long enough.
+var RetiredStatuses = []BountyStatus{StatusClosed, StatusExpired}
+
+// ArchivedBounty is a retired bounty with everything recorded about it, as
+// exported before deletion.
+type ArchivedBounty struct {
+	Bounty    Bounty         `json:"bounty"`
+	RetiredAt time.Time      `json:"retired_at"`
+	History   []BountyChange `json:"history"`
+	Sources   []BountySource `json:"sources"`
+}
+
+// Maintenance actions recorded in the audit log.
+const (
+	AuditArchive = "archive" // retired bounties exported, then deleted
+	AuditDelete  = "delete"  // retired bounties deleted without export
+	AuditVacuum  = "vacuum"  // database storage reclaimed
+)
+
+// AuditEntry records one maintenance action taken on stored data.
+type AuditEntry struct {
+	ID     int64     `json:"id"`
+	Action string    `json:"action"`
+	Count  int       `json:"count"`            // rows affected
+	Detail string    `json:"detail,omitempty"` // e.g. the archive file, or the error
+	At     time.Time `json:"at"`
+}
@@ -0,0 +1,260 @@
+package core
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RewardInfo is the structured form of a free-form reward string.
+type RewardInfo struct {
+	Min   float64 // lower bound; equals Max for exact amounts
+	Max   float64 // upper bound; 0 when no amount was found
+	Token string  // normalized symbol such as USD, USDC or ETH
+	Exact bool    // true when the listing names a single amount
+}
+
+// HasAmount reports whether any numeric amount was parsed.
+func (r RewardInfo) HasAmount() bool {
+	return r.Max > 0
+}
+
+var (
+	rewardNumberPattern = regexp.MustCompile(`(\d[\d,]*(?:\.\d+)?)(?:\s*([kKmM])\b)?`)
+	rewardRangePattern  = regexp.MustCompile(`(\d[\d,]*(?:\.\d+)?)\s*([kKmM])?\s*(?:-|–|—|to)\s*\$?\s*(\d[\d,]*(?:\.\d+)?)(?:\s*([kKmM])\b)?`)
+	rewardTokenPattern  = regexp.MustCompile(`[A-Za-z][A-Za-z0-9.]{1,9}`)
+	rewardUpToPattern   = regexp.MustCompile(`(?i)\b(up to|upto|max(?:imum)?|<=?)\s*\$?\s*\d`)
+	rewardBeforePattern = regexp.MustCompile(`(\$|[A-Za-z][A-Za-z0-9.]{1,9})\s*$`)
+	rewardAfterPattern  = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9.]{1,9})`)
+)
+
+// tokenAliases maps spellings seen in listings onto canonical symbols.
+var tokenAliases = map[string]string{
+	"$":       "USD",
+	"US$":     "USD",
+	"USD":     "USD",
+	"DOLLAR":  "USD",
+	"DOLLARS": "USD",
+	"ETHER":   "ETH",
+	"SOLANA":  "SOL",
+	"BITCOIN": "BTC",
+	"USDC.E":  "USDC",
+	"USDT0":   "USDT",
+}
+
+// rewardStopWords are words that look like symbols but never are.
+var rewardStopWords = map[string]bool{
+	"BOUNTY": true, "REWARD": true, "PRIZE": true, "FUNDED": true, "VARIABLE": true,
+	"UP": true, "TO": true, "MAX": true, "MAXIMUM": true, "IN": true, "OF": true,
+	"PER": true, "AND": true, "OR": true, "POOL": true, "TOTAL": true, "FOR": true,
+}
+
+// ParseReward extracts amounts and a token from reward and currency strings
+// such as "$500 bounty", "500-1000", "💎 Bounty $250" or "2.5k USDC". The
+// amount is the first one marked as money by an adjacent $ or token, so
+// "Top 3 split $3000" is 3000; failing that, the first standalone number.
+// A currency naming one token overrides the text; one listing several
+// ("USDC/ETH/SOL") only applies when the text names none, and then
+// resolves to the first. Labels with no amount ("Funded", "Variable")
+// return a zero RewardInfo carrying only the token.
+func ParseReward(reward, currency string) RewardInfo {
+	var info RewardInfo
+	text := strings.TrimSpace(reward)
+
+	if m := rewardAmount(text); m != nil {
+		if m.isRange {
+			info.Min, info.Max = m.low, m.high
+			info.Exact = m.low == m.high
+		} else {
+			info.Min, info.Max = m.high, m.high
+			info.Exact = m.high > 0
+			if rewardUpToPattern.MatchString(text) {
+				info.Min = 0
+				info.Exact = false
+			}
+		}
+	}
+
+	info.Token = rewardToken(text)
+	if info.Token == "" && strings.Contains(text, "$") {
+		info.Token = "USD"
+	}
+	if token := rewardToken(currency); token != "" && (info.Token == "" || !strings.ContainsAny(currency, "/,|")) {
+		info.Token = token
+	}
+	if !info.HasAmount() {
+		info.Min, info.Max, info.Exact = 0, 0, false
+	}
+	return info
+}
+
+// rewardMatch is an amount or range found in a reward string.
+type rewardMatch struct {
+	start, end int
+	low, high  float64
+	isRange    bool
+}
+
+// rewardAmount returns the first amount in text marked as money, else the
+// first standalone one, or nil. Ranges come before single amounts.
+func rewardAmount(text string) *rewardMatch {
+	var matches []rewardMatch
+	for _, idx := range rewardRangePattern.FindAllStringSubmatchIndex(text, -1) {
+		group := func(i int) string {
+			if idx[2*i] < 0 {
+				return ""
+			}
+			return text[idx[2*i]:idx[2*i+1]]
+		}
+		low := parseRewardNumber(group(1), group(2))
+		high := parseRewardNumber(group(3), group(4))
+		// "1-2k" means 1k-2k; carry the high suffix down when the low has none.
+		if group(2) == "" && group(4) != "" {
+			low = parseRewardNumber(group(1), group(4))
+		}
+		if low > high {
+			low, high = high, low
+		}
+		matches = append(matches, rewardMatch{start: idx[0], end: idx[1], low: low, high: high, isRange: true})
+	}
+	for _, idx := range rewardNumberPattern.FindAllStringSubmatchIndex(text, -1) {
+		inRange := false
+		for _, m := range matches {
+			if m.isRange && idx[0] < m.end && idx[1] > m.start {
+				inRange = true
+				break
+			}
+		}
+		if inRange {
+			continue
+		}
+		suffix := ""
+		if idx[4] >= 0 {
+			suffix = text[idx[4]:idx[5]]
+		}
+		amount := parseRewardNumber(text[idx[2]:idx[3]], suffix)
+		matches = append(matches, rewardMatch{start: idx[0], end: idx[1], low: amount, high: amount})
+	}
+
+	var standalone *rewardMatch
+	for i := range matches {
+		money, alone := rewardContext(text, matches[i].start, matches[i].end)
+		if money {
+			return &matches[i]
+		}
+		if alone && standalone == nil {
+			standalone = &matches[i]
+		}
+	}
+	return standalone
+}
+
+// rewardContext reports whether the amount at text[start:end] is next to a
+// $ or token, and whether it stands apart from the word after it, unlike
+// the 1 in "1st".
+func rewardContext(text string, start, end int) (money, standalone bool) {
+	if m := rewardBeforePattern.FindStringSubmatch(text[:start]); m != nil {
+		money = m[1] == "$" || tokenSymbol(strings.ToUpper(m[1])) != ""
+	}
+	if m := rewardAfterPattern.FindStringSubmatch(text[end:]); m != nil && tokenSymbol(strings.ToUpper(m[1])) != "" {
+		money = true
+	}
+	standalone = end == len(text) || !isLetter(text[end])
+	return money, standalone
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// ApplyReward fills the structured reward fields from Reward and Currency
+// unless a scanner already set them.
+func (b *Bounty) ApplyReward() {
+	if b.RewardMax > 0 {
+		if b.RewardToken == "" {
+			b.RewardToken = ParseReward(b.Reward, b.Currency).Token
+		}
+		return
+	}
+	info := ParseReward(b.Reward, b.Currency)
+	b.RewardMin = info.Min
+	b.RewardMax = info.Max
+	b.RewardToken = info.Token
+	b.RewardExact = info.Exact
+}
+
+func parseRewardNumber(digits, suffix string) float64 {
+	value, err := strconv.ParseFloat(strings.ReplaceAll(digits, ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	switch strings.ToLower(suffix) {
+	case "k":
+		value *= 1_000
+	case "m":
+		value *= 1_000_000
+	}
+	return value
+}
+
+// rewardToken returns the first recognizable token symbol in text.
+func rewardToken(text string) string {
+	upper := strings.ToUpper(strings.TrimSpace(text))
+	if upper == "" {
+		return ""
+	}
+	if alias, ok := tokenAliases[upper]; ok {
+		return alias
+	}
+	for _, candidate := range rewardTokenPattern.FindAllString(upper, -1) {
+		if token := tokenSymbol(candidate); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// tokenSymbol returns the canonical symbol for the upper-case word
+// candidate, or "" when it is not a token.
+func tokenSymbol(candidate string) string {
+	candidate = strings.TrimRight(candidate, ".")
+	if alias, ok := tokenAliases[candidate]; ok {
+		return alias
+	}
+	if rewardStopWords[candidate] || strings.HasSuffix(candidate, "K") && isDigits(strings.TrimSuffix(candidate, "K")) {
+		return ""
+	}
+	if isKnownToken(candidate) {
+		return candidate
+	}
+	return ""
+}
+
+// isKnownToken accepts symbols from the payment tiers plus common stablecoins
+// so arbitrary words in a title are not mistaken for currencies.
+func isKnownToken(symbol string) bool {
+	switch symbol {
+	case "USDC", "USDT", "DAI", "EUR", "GBP", "EURC", "PYUSD":
+		return true
+	}
+	for _, list := range [][]string{paymentConfig.CryptoCurrencies, paymentConfig.P2PMethods, paymentConfig.FiatMethods} {
+		for _, token := range list {
+			if strings.ReplaceAll(token, " ", "") == symbol {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
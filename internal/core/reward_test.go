@@ -0,0 +1,54 @@
+package core
+
+import "testing"
+
+func TestParseReward(t *testing.T) {
+	tests := []struct {
+		name     string
+		reward   string
+		currency string
+		want     RewardInfo
+	}{
+		{"Funded label", "Funded", "USDC/ETH/SOL", RewardInfo{Token: "USDC"}},
+		{"Variable label", "Variable", "", RewardInfo{}},
+		{"Dollar bounty", "$500 bounty", "", RewardInfo{Min: 500, Max: 500, Token: "USD", Exact: true}},
+		{"Plain range", "500-1000", "USDC", RewardInfo{Min: 500, Max: 1000, Token: "USDC"}},
+		{"Emoji label", "💎 Bounty $250", "", RewardInfo{Min: 250, Max: 250, Token: "USD", Exact: true}},
+		{"Token in reward", "2.5k USDC", "", RewardInfo{Min: 2500, Max: 2500, Token: "USDC", Exact: true}},
+		{"Thousands separator", "$1,250.50", "USD", RewardInfo{Min: 1250.5, Max: 1250.5, Token: "USD", Exact: true}},
+		{"Suffix carried to low bound", "1-2k", "SOL", RewardInfo{Min: 1000, Max: 2000, Token: "SOL"}},
+		{"Up to", "Up to $5,000", "", RewardInfo{Max: 5000, Token: "USD"}},
+		{"Currency alias", "0.5", "ether", RewardInfo{Min: 0.5, Max: 0.5, Token: "ETH", Exact: true}},
+		{"Text token beats currency list", "0.5 ETH", "USDC/ETH/SOL", RewardInfo{Min: 0.5, Max: 0.5, Token: "ETH", Exact: true}},
+		{"Dollar beats currency list", "$500", "USDC/ETH/SOL", RewardInfo{Min: 500, Max: 500, Token: "USD", Exact: true}},
+		{"Count before dollar amount", "Win 3 prizes of $100", "", RewardInfo{Min: 100, Max: 100, Token: "USD", Exact: true}},
+		{"Rank before dollar amount", "Top 3 split $3000", "", RewardInfo{Min: 3000, Max: 3000, Token: "USD", Exact: true}},
+		{"Count before money range", "5-10 winners get $100 each", "", RewardInfo{Min: 100, Max: 100, Token: "USD", Exact: true}},
+		{"Token without space", "500USDC", "", RewardInfo{Min: 500, Max: 500, Token: "USDC", Exact: true}},
+		{"Token before amount", "USDC 750", "", RewardInfo{Min: 750, Max: 750, Token: "USDC", Exact: true}},
+		{"Ordinal is not an amount", "1st place", "", RewardInfo{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseReward(tt.reward, tt.currency)
+			if got != tt.want {
+				t.Errorf("ParseReward(%q, %q) = %+v, want %+v", tt.reward, tt.currency, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyRewardKeepsScannerValues(t *testing.T) {
+	b := Bounty{Reward: "500-1000", Currency: "USDC", RewardMin: 500, RewardMax: 1000}
+	b.ApplyReward()
+	if b.RewardMin != 500 || b.RewardMax != 1000 || b.RewardToken != "USDC" || b.RewardExact {
+		t.Fatalf("unexpected reward fields: %+v", b)
+	}
+
+	b = Bounty{Reward: "$75", Currency: ""}
+	b.ApplyReward()
+	if b.RewardMax != 75 || b.RewardToken != "USD" || !b.RewardExact {
+		t.Fatalf("unexpected parsed reward fields: %+v", b)
+	}
+}
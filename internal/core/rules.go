@@ -0,0 +1,325 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Rule awards Weight points when every condition it sets matches a bounty.
+// A rule with no conditions always matches. Within a Group only the first
+// matching rule scores, which expresses tiers such as payment method or
+// recency buckets.
+type Rule struct {
+	Name     string `yaml:"name" json:"name"`
+	Weight   int    `yaml:"weight" json:"weight"`
+	Group    string `yaml:"group,omitempty" json:"group,omitempty"`
+	PerMatch bool   `yaml:"per_match,omitempty" json:"per_match,omitempty"` // score once per matching tag
+
+	Field      string   `yaml:"field,omitempty" json:"field,omitempty"` // field for regex/contains; defaults to title
+	Regex      string   `yaml:"regex,omitempty" json:"regex,omitempty"`
+	Contains   []string `yaml:"contains,omitempty" json:"contains,omitempty"`
+	Tags       []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Platforms  []string `yaml:"platforms,omitempty" json:"platforms,omitempty"`
+	Currencies []string `yaml:"currencies,omitempty" json:"currencies,omitempty"`
+	MinAge     string   `yaml:"min_age,omitempty" json:"min_age,omitempty"` // Go duration, e.g. "6h"
+	MaxAge     string   `yaml:"max_age,omitempty" json:"max_age,omitempty"`
+	MinUSD     float64  `yaml:"min_usd,omitempty" json:"min_usd,omitempty"`
+	MaxUSD     float64  `yaml:"max_usd,omitempty" json:"max_usd,omitempty"`
+}
+
+// ScoreContribution explains the points one rule added to a score.
+type ScoreContribution struct {
+	Rule   string `json:"rule"`
+	Match  string `json:"match"`
+	Points int    `json:"points"`
+}
+
+// String formats the contribution as "+50 payment-crypto (USDC)".
+func (c ScoreContribution) String() string {
+	text := fmt.Sprintf("%+d %s", c.Points, c.Rule)
+	if c.Match != "" {
+		text += " (" + c.Match + ")"
+	}
+	return text
+}
+
+// ScoreResult is a score together with the rules that produced it.
+type ScoreResult struct {
+	Total     int                 `json:"total"`
+	Breakdown []ScoreContribution `json:"breakdown"`
+}
+
+// RuleEngine evaluates a compiled rule list against bounties.
+type RuleEngine struct {
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	Rule
+	regex    *regexp.Regexp
+	contains []string
+	tags     []string
+	minAge   time.Duration
+	maxAge   time.Duration
+}
+
+var ruleFields = map[string]func(*Bounty) string{
+	"title":        func(b *Bounty) string { return b.Title },
+	"description":  func(b *Bounty) string { return b.Description },
+	"platform":     func(b *Bounty) string { return b.Platform },
+	"reward":       func(b *Bounty) string { return b.Reward },
+	"currency":     func(b *Bounty) string { return b.Currency },
+	"payment_type": func(b *Bounty) string { return b.PaymentType },
+	"reward_token": func(b *Bounty) string { return b.RewardToken },
+	"url":          func(b *Bounty) string { return b.URL },
+}
+
+// NewRuleEngine validates and compiles rules.
+func NewRuleEngine(rules []Rule) (*RuleEngine, error) {
+	engine := &RuleEngine{rules: make([]compiledRule, 0, len(rules))}
+	for i, rule := range rules {
+		compiled := compiledRule{Rule: rule}
+		if strings.TrimSpace(compiled.Name) == "" {
+			compiled.Name = fmt.Sprintf("rule-%d", i+1)
+		}
+		compiled.Field = strings.ToLower(strings.TrimSpace(compiled.Field))
+		if compiled.Field == "" {
+			compiled.Field = "title"
+		}
+		if _, ok := ruleFields[compiled.Field]; !ok {
+			return nil, fmt.Errorf("scoring rule %q: unknown field %q", compiled.Name, rule.Field)
+		}
+		if rule.Regex != "" {
+			re, err := regexp.Compile(rule.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("scoring rule %q: %w", compiled.Name, err)
+			}
+			compiled.regex = re
+		}
+		compiled.contains = normalizeUpperList(rule.Contains)
+		compiled.tags = normalizeUpperList(rule.Tags)
+		compiled.Platforms = normalizeUpperList(rule.Platforms)
+		compiled.Currencies = normalizeUpperList(rule.Currencies)
+
+		var err error
+		if compiled.minAge, err = parseRuleAge(rule.MinAge); err != nil {
+			return nil, fmt.Errorf("scoring rule %q: min_age: %w", compiled.Name, err)
+		}
+		if compiled.maxAge, err = parseRuleAge(rule.MaxAge); err != nil {
+			return nil, fmt.Errorf("scoring rule %q: max_age: %w", compiled.Name, err)
+		}
+		engine.rules = append(engine.rules, compiled)
+	}
+	return engine, nil
+}
+
+func parseRuleAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// Score evaluates every rule against b as of now.
+func (e *RuleEngine) Score(b *Bounty, now time.Time) ScoreResult {
+	result := ScoreResult{Breakdown: []ScoreContribution{}}
+	matchedGroups := make(map[string]bool)
+
+	for _, rule := range e.rules {
+		if rule.Group != "" && matchedGroups[rule.Group] {
+			continue
+		}
+		matches, ok := rule.match(b, now)
+		if !ok {
+			continue
+		}
+		if rule.Group != "" {
+			matchedGroups[rule.Group] = true
+		}
+		for _, match := range matches {
+			result.Total += rule.Weight
+			result.Breakdown = append(result.Breakdown, ScoreContribution{
+				Rule:   rule.Name,
+				Match:  match,
+				Points: rule.Weight,
+			})
+		}
+	}
+	return result
+}
+
+// match returns one description per scoring hit: a single entry normally,
+// or one per matching tag for PerMatch rules.
+func (r *compiledRule) match(b *Bounty, now time.Time) ([]string, bool) {
+	var parts []string
+
+	if r.regex != nil {
+		found := r.regex.FindString(ruleFields[r.Field](b))
+		if found == "" {
+			return nil, false
+		}
+		parts = append(parts, found)
+	}
+	if len(r.contains) > 0 {
+		keyword, ok := firstContained(strings.ToUpper(ruleFields[r.Field](b)), r.contains)
+		if !ok {
+			return nil, false
+		}
+		parts = append(parts, keyword)
+	}
+	if len(r.Platforms) > 0 {
+		platform, ok := firstContained(strings.ToUpper(b.Platform), r.Platforms)
+		if !ok {
+			return nil, false
+		}
+		parts = append(parts, platform)
+	}
+	if len(r.Currencies) > 0 {
+		if !containsCurrency(b.Currency, r.Currencies) {
+			return nil, false
+		}
+		parts = append(parts, strings.ToUpper(b.Currency))
+	}
+	if r.minAge > 0 || r.maxAge > 0 {
+		age := now.Sub(b.CreatedAt)
+		if (r.minAge > 0 && age < r.minAge) || (r.maxAge > 0 && age >= r.maxAge) {
+			return nil, false
+		}
+		// Describe the bucket rather than the exact age so explanations
+		// stay stable between re-scoring passes.
+		switch {
+		case r.minAge > 0 && r.maxAge > 0:
+			parts = append(parts, "age "+formatAge(r.minAge)+"-"+formatAge(r.maxAge))
+		case r.maxAge > 0:
+			parts = append(parts, "age < "+formatAge(r.maxAge))
+		default:
+			parts = append(parts, "age >= "+formatAge(r.minAge))
+		}
+	}
+	if r.MinUSD > 0 || r.MaxUSD > 0 {
+		if b.RewardUSD < r.MinUSD || (r.MaxUSD > 0 && b.RewardUSD >= r.MaxUSD) {
+			return nil, false
+		}
+		parts = append(parts, fmt.Sprintf("$%.0f", b.RewardUSD))
+	}
+
+	if len(r.tags) == 0 {
+		return []string{strings.Join(parts, ", ")}, true
+	}
+
+	var hits []string
+	for _, tag := range b.Tags {
+		if _, ok := firstContained(strings.ToUpper(tag), r.tags); ok {
+			hits = append(hits, strings.Join(append(append([]string(nil), parts...), "tag "+tag), ", "))
+			if !r.PerMatch {
+				break
+			}
+		}
+	}
+	return hits, len(hits) > 0
+}
+
+func formatAge(d time.Duration) string {
+	text := d.String()
+	if strings.HasSuffix(text, "m0s") {
+		text = strings.TrimSuffix(text, "0s")
+	}
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
+
+func firstContained(text string, keywords []string) (string, bool) {
+	for _, keyword := range keywords {
+		if keyword != "" && strings.Contains(text, keyword) {
+			return keyword, true
+		}
+	}
+	return "", false
+}
+
+// DefaultRules reproduces the built-in "Obsidian" weights from the current
+// ScoringConfig and PaymentConfig keyword lists.
+func DefaultRules() []Rule {
+	return []Rule{
+		// Payment method hierarchy (Crypto is King)
+		{Name: "payment-crypto", Group: "payment", Weight: 50, Currencies: paymentConfig.CryptoCurrencies},
+		{Name: "payment-p2p", Group: "payment", Weight: 45, Currencies: paymentConfig.P2PMethods},
+		{Name: "payment-fiat", Group: "payment", Weight: 25, Currencies: paymentConfig.FiatMethods},
+		{Name: "payment-other", Group: "payment", Weight: 5},
+
+		// Keyword triggers
+		{Name: "keyword-urgent", Weight: 30, Contains: scoringConfig.UrgencyKeywords},
+		{Name: "keyword-dev-task", Weight: 15, Contains: scoringConfig.DevTaskKeywords},
+		{Name: "keyword-automation", Weight: 20, Contains: scoringConfig.AutomationKeywords},
+		{Name: "keyword-security", Weight: 25, Contains: scoringConfig.SecurityKeywords},
+		{Name: "keyword-audit", Weight: 35, Contains: scoringConfig.AuditKeywords},
+
+		// Recency (the Sniper rule)
+		{Name: "recency-super-fresh", Group: "recency", Weight: 40, MaxAge: "1h"},
+		{Name: "recency-fresh", Group: "recency", Weight: 25, MaxAge: "6h"},
+		{Name: "recency-recent", Group: "recency", Weight: 10, MaxAge: "24h"},
+
+		// Platform priority
+		{Name: "platform-superteam", Weight: 15, Platforms: []string{"SUPERTEAM"}},
+		{Name: "platform-bountycaster", Weight: 10, Platforms: []string{"BOUNTYCASTER"}},
+		{Name: "platform-bug-bounty", Weight: 30, Platforms: []string{"IMMUNEFI", "HACKEN"}},
+
+		// Reward value (USD via PriceOracle)
+		{Name: "value-large", Group: "value", Weight: 30, MinUSD: 10000},
+		{Name: "value-high", Group: "value", Weight: 20, MinUSD: 1000},
+		{Name: "value-medium", Group: "value", Weight: 10, MinUSD: 250},
+
+		// Tag bonuses
+		{Name: "tag-urgent", Weight: 20, Tags: []string{"URGENT"}, PerMatch: true},
+		{Name: "tag-hot", Weight: 15, Tags: []string{"HOT"}, PerMatch: true},
+		{Name: "tag-deadline", Weight: 10, Tags: []string{"DEADLINE"}, PerMatch: true},
+	}
+}
+
+var (
+	customRules   atomic.Pointer[RuleEngine]
+	defaultEngine atomic.Pointer[RuleEngine]
+)
+
+// SetScoringRules replaces the built-in rules. An empty list restores them.
+func SetScoringRules(rules []Rule) error {
+	if len(rules) == 0 {
+		customRules.Store(nil)
+		return nil
+	}
+	engine, err := NewRuleEngine(rules)
+	if err != nil {
+		return err
+	}
+	customRules.Store(engine)
+	return nil
+}
+
+// activeEngine returns the configured rules, or the built-in rules compiled
+// from the current keyword and payment configuration.
+func activeEngine() *RuleEngine {
+	if engine := customRules.Load(); engine != nil {
+		return engine
+	}
+	if engine := defaultEngine.Load(); engine != nil {
+		return engine
+	}
+	engine, err := NewRuleEngine(DefaultRules())
+	if err != nil {
+		panic(err) // built-in rules are static and always compile
+	}
+	defaultEngine.Store(engine)
+	return engine
+}
+
+// resetDefaultEngine drops the compiled built-in rules after their keyword
+// or payment lists change.
+func resetDefaultEngine() {
+	defaultEngine.Store(nil)
+}
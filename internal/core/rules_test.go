@@ -0,0 +1,103 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRuleEngineScore(t *testing.T) {
+	engine, err := NewRuleEngine([]Rule{
+		{Name: "crypto", Group: "payment", Weight: 50, Currencies: []string{"USDC"}},
+		{Name: "other", Group: "payment", Weight: 5},
+		{Name: "rust", Weight: 12, Field: "description", Regex: `(?i)\brust\b`},
+		{Name: "fresh", Group: "age", Weight: 40, MaxAge: "1h"},
+		{Name: "recent", Group: "age", Weight: 10, MaxAge: "24h"},
+		{Name: "stale", Weight: -20, MinAge: "72h"},
+		{Name: "mid-value", Weight: 7, MinUSD: 100, MaxUSD: 1000},
+		{Name: "hot-tag", Weight: 3, Tags: []string{"hot"}, PerMatch: true},
+		{Name: "solana-platform", Weight: 9, Platforms: []string{"superteam"}},
+	})
+	if err != nil {
+		t.Fatalf("NewRuleEngine: %v", err)
+	}
+
+	now := time.Now()
+	b := Bounty{
+		Currency:    "USDC",
+		Description: "Port the indexer to Rust",
+		CreatedAt:   now.Add(-3 * time.Hour),
+		RewardUSD:   500,
+		Tags:        []string{"hot", "HOT-deal", "cold"},
+		Platform:    "GitHub",
+	}
+	result := engine.Score(&b, now)
+
+	want := []ScoreContribution{
+		{Rule: "crypto", Match: "USDC", Points: 50},
+		{Rule: "rust", Match: "Rust", Points: 12},
+		{Rule: "recent", Match: "age < 24h", Points: 10},
+		{Rule: "mid-value", Match: "$500", Points: 7},
+		{Rule: "hot-tag", Match: "tag hot", Points: 3},
+		{Rule: "hot-tag", Match: "tag HOT-deal", Points: 3},
+	}
+	if result.Total != 85 {
+		t.Errorf("Total = %d, want 85", result.Total)
+	}
+	if len(result.Breakdown) != len(want) {
+		t.Fatalf("Breakdown = %+v, want %+v", result.Breakdown, want)
+	}
+	for i := range want {
+		if result.Breakdown[i] != want[i] {
+			t.Errorf("Breakdown[%d] = %+v, want %+v", i, result.Breakdown[i], want[i])
+		}
+	}
+
+	old := Bounty{Currency: "ROCKS", CreatedAt: now.Add(-100 * time.Hour)}
+	if got := engine.Score(&old, now).Total; got != -15 {
+		t.Errorf("stale unknown-currency score = %d, want -15", got)
+	}
+}
+
+func TestNewRuleEngineRejectsInvalidRules(t *testing.T) {
+	cases := map[string]Rule{
+		"bad regex":    {Name: "r", Regex: "("},
+		"bad duration": {Name: "d", MaxAge: "soon"},
+		"bad field":    {Name: "f", Field: "owner", Contains: []string{"x"}},
+	}
+	for name, rule := range cases {
+		if _, err := NewRuleEngine([]Rule{rule}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestSetScoringRulesOverridesDefaults(t *testing.T) {
+	b := Bounty{Title: "Anything", Currency: "USDC", CreatedAt: time.Now().Add(-48 * time.Hour)}
+	if got := CalculateUrgency(&b); got != 50 {
+		t.Fatalf("default score = %d, want 50", got)
+	}
+
+	if err := SetScoringRules([]Rule{{Name: "flat", Weight: 7}}); err != nil {
+		t.Fatalf("SetScoringRules: %v", err)
+	}
+	defer SetScoringRules(nil)
+
+	result := ExplainUrgency(&b)
+	if result.Total != 7 || len(result.Breakdown) != 1 || result.Breakdown[0].Rule != "flat" {
+		t.Errorf("custom rules result = %+v", result)
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	cases := map[time.Duration]string{
+		24 * time.Hour:   "24h",
+		90 * time.Minute: "1h30m",
+		30 * time.Minute: "30m",
+		45 * time.Second: "45s",
+	}
+	for d, want := range cases {
+		if got := formatAge(d); got != want {
+			t.Errorf("formatAge(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -90,6 +90,18 @@ func TestCalculateUrgency(t *testing.T) {
 			minScore: 115, // 50 (Crypto) + 40 (Fresh) + 15 (Hot) + 10 (Deadline)
 			maxScore: 150,
 		},
+		{
+			name: "Reward Value Bonus",
+			bounty: Bounty{
+				Title:     "Simple Task",
+				Currency:  "USDC",
+				CreatedAt: time.Now().Add(-48 * time.Hour),
+				Platform:  "GitHub",
+				RewardUSD: 2500,
+			},
+			minScore: 70, // 50 (Crypto) + 20 (>= $1k)
+			maxScore: 70,
+		},
 	}
 
 	for _, tt := range tests {
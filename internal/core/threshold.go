@@ -0,0 +1,81 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FilterStage selects where reward thresholds are enforced.
+type FilterStage string
+
+const (
+	FilterBeforeSave  FilterStage = "save"  // rejected bounties are never stored
+	FilterBeforeAlert FilterStage = "alert" // rejected bounties are stored but not alerted
+)
+
+// RewardThresholds are the minimum reward requirements a bounty must meet.
+type RewardThresholds struct {
+	MinUSD           float64            // minimum RewardUSD; applies only to valued rewards
+	MinByToken       map[string]float64 // minimum RewardMax per token symbol
+	DropUnquantified bool               // reject "Funded", "Variable" and other amount-less rewards
+}
+
+// Rejection records why a bounty was filtered out.
+type Rejection struct {
+	URL        string      `json:"url"`
+	Title      string      `json:"title"`
+	Platform   string      `json:"platform"`
+	Reward     string      `json:"reward"`
+	Currency   string      `json:"currency"`
+	RewardUSD  float64     `json:"reward_usd"`
+	Reason     string      `json:"reason"`
+	Stage      FilterStage `json:"stage"`
+	RejectedAt time.Time   `json:"rejected_at"`
+}
+
+// NewRejection builds a Rejection for b at the current time.
+func NewRejection(b Bounty, reason string, stage FilterStage) Rejection {
+	return Rejection{
+		URL:        b.URL,
+		Title:      b.Title,
+		Platform:   b.Platform,
+		Reward:     b.Reward,
+		Currency:   b.Currency,
+		RewardUSD:  b.RewardUSD,
+		Reason:     reason,
+		Stage:      stage,
+		RejectedAt: time.Now(),
+	}
+}
+
+// Reject returns the reason b fails the thresholds, or "" if it passes.
+// Rewards that have an amount but could not be valued pass the USD check.
+func (t RewardThresholds) Reject(b *Bounty) string {
+	if b.RewardMax <= 0 {
+		if t.DropUnquantified {
+			label := strings.TrimSpace(b.Reward)
+			if label == "" {
+				label = "empty"
+			}
+			return fmt.Sprintf("reward %q has no amount", label)
+		}
+		return ""
+	}
+
+	token := strings.ToUpper(strings.TrimSpace(b.RewardToken))
+	if min, ok := t.MinByToken[token]; ok && token != "" && b.RewardMax < min {
+		return fmt.Sprintf("reward %s %s below %s minimum %s", formatThreshold(b.RewardMax), token, token, formatThreshold(min))
+	}
+
+	if t.MinUSD > 0 && b.RewardUSD > 0 && b.RewardUSD < t.MinUSD {
+		return fmt.Sprintf("reward $%s below MIN_USD_VALUE $%s", formatThreshold(b.RewardUSD), formatThreshold(t.MinUSD))
+	}
+	return ""
+}
+
+func formatThreshold(value float64) string {
+	formatted := fmt.Sprintf("%.2f", value)
+	formatted = strings.TrimRight(formatted, "0")
+	return strings.TrimRight(formatted, ".")
+}
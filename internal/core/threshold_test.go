@@ -0,0 +1,36 @@
+package core
+
+import "testing"
+
+func TestRewardThresholdsReject(t *testing.T) {
+	thresholds := RewardThresholds{
+		MinUSD:           100,
+		MinByToken:       map[string]float64{"SOL": 2},
+		DropUnquantified: true,
+	}
+
+	tests := []struct {
+		name   string
+		bounty Bounty
+		want   string
+	}{
+		{"funded label", Bounty{Reward: "Funded"}, `reward "Funded" has no amount`},
+		{"empty reward", Bounty{}, `reward "empty" has no amount`},
+		{"below token minimum", Bounty{RewardMax: 1.5, RewardToken: "sol", RewardUSD: 225}, "reward 1.5 SOL below SOL minimum 2"},
+		{"below usd minimum", Bounty{RewardMax: 50, RewardToken: "USDC", RewardUSD: 50}, "reward $50 below MIN_USD_VALUE $100"},
+		{"unpriced amount passes", Bounty{RewardMax: 50, RewardToken: "JUP"}, ""},
+		{"passes", Bounty{RewardMax: 5, RewardToken: "SOL", RewardUSD: 750}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := thresholds.Reject(&tt.bounty); got != tt.want {
+				t.Errorf("Reject() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := (RewardThresholds{}).Reject(&Bounty{Reward: "Variable"}); got != "" {
+		t.Errorf("zero thresholds rejected amount-less reward: %q", got)
+	}
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PipelineState is where a bounty stands in the user's own workflow,
+// independent of its Status at the source.
+type PipelineState string
+
+const (
+	StateWatch      PipelineState = "watch"
+	StateClaimed    PipelineState = "claimed"
+	StateInProgress PipelineState = "in-progress"
+	StateSubmitted  PipelineState = "submitted"
+	StatePaid       PipelineState = "paid"
+)
+
+// PipelineStates lists the workflow states in order.
+var PipelineStates = []PipelineState{StateWatch, StateClaimed, StateInProgress, StateSubmitted, StatePaid}
+
+// ParsePipelineState validates a workflow state name. Underscores and
+// spaces are accepted in place of the hyphen in "in-progress".
+func ParsePipelineState(value string) (PipelineState, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	normalized = strings.NewReplacer("_", "-", " ", "-").Replace(normalized)
+	if normalized == "inprogress" {
+		normalized = string(StateInProgress)
+	}
+	for _, state := range PipelineStates {
+		if normalized == string(state) {
+			return state, nil
+		}
+	}
+	return "", fmt.Errorf("unknown pipeline state %q", value)
+}
+
+// Tracking is the user's record of a bounty they are pursuing.
+type Tracking struct {
+	BountyID       string        `json:"bounty_id"`
+	URL            string        `json:"url"`
+	Title          string        `json:"title"`
+	Platform       string        `json:"platform"`
+	State          PipelineState `json:"state"`
+	Notes          string        `json:"notes"`
+	ExpectedPayout float64       `json:"expected_payout"`
+	PayoutCurrency string        `json:"payout_currency"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+
+	// Time each state was last entered; nil if it never was.
+	WatchedAt   *time.Time `json:"watched_at,omitempty"`
+	ClaimedAt   *time.Time `json:"claimed_at,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+	PaidAt      *time.Time `json:"paid_at,omitempty"`
+}
+
+// TrackingUpdate is a partial change to a Tracking; nil fields are kept.
+type TrackingUpdate struct {
+	State          *PipelineState `json:"state,omitempty"`
+	Notes          *string        `json:"notes,omitempty"`
+	ExpectedPayout *float64       `json:"expected_payout,omitempty"`
+	PayoutCurrency *string        `json:"payout_currency,omitempty"`
+}
+
+// Apply merges u into t, stamping the entry time of a newly entered state.
+// A new record with no state starts in StateWatch.
+func (t *Tracking) Apply(u TrackingUpdate, now time.Time) {
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = now
+	}
+	t.UpdatedAt = now
+
+	state := t.State
+	if u.State != nil {
+		state = *u.State
+	}
+	if state == "" {
+		state = StateWatch
+	}
+	if state != t.State {
+		t.State = state
+		if field := t.stateTime(state); field != nil {
+			entered := now
+			*field = &entered
+		}
+	}
+	if u.Notes != nil {
+		t.Notes = *u.Notes
+	}
+	if u.ExpectedPayout != nil {
+		t.ExpectedPayout = *u.ExpectedPayout
+	}
+	if u.PayoutCurrency != nil {
+		t.PayoutCurrency = strings.ToUpper(strings.TrimSpace(*u.PayoutCurrency))
+	}
+}
+
+// StateTime returns when state was last entered, or nil.
+func (t *Tracking) StateTime(state PipelineState) *time.Time {
+	if field := t.stateTime(state); field != nil {
+		return *field
+	}
+	return nil
+}
+
+func (t *Tracking) stateTime(state PipelineState) **time.Time {
+	switch state {
+	case StateWatch:
+		return &t.WatchedAt
+	case StateClaimed:
+		return &t.ClaimedAt
+	case StateInProgress:
+		return &t.StartedAt
+	case StateSubmitted:
+		return &t.SubmittedAt
+	case StatePaid:
+		return &t.PaidAt
+	}
+	return nil
+}
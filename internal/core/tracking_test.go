@@ -0,0 +1,57 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePipelineState(t *testing.T) {
+	tests := map[string]PipelineState{
+		"watch":       StateWatch,
+		" Claimed ":   StateClaimed,
+		"in_progress": StateInProgress,
+		"In Progress": StateInProgress,
+		"inprogress":  StateInProgress,
+		"PAID":        StatePaid,
+	}
+	for input, want := range tests {
+		got, err := ParsePipelineState(input)
+		if err != nil || got != want {
+			t.Errorf("ParsePipelineState(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParsePipelineState("abandoned"); err == nil {
+		t.Error("ParsePipelineState(abandoned) expected error")
+	}
+}
+
+func TestTrackingApply(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	var tracking Tracking
+
+	notes := "ask about scope"
+	tracking.Apply(TrackingUpdate{Notes: &notes}, start)
+	if tracking.State != StateWatch || tracking.WatchedAt == nil || !tracking.CreatedAt.Equal(start) {
+		t.Fatalf("new tracking = %+v, want watch stamped at %v", tracking, start)
+	}
+
+	claimed := StateClaimed
+	payout, token := 500.0, " usdc "
+	later := start.Add(time.Hour)
+	tracking.Apply(TrackingUpdate{State: &claimed, ExpectedPayout: &payout, PayoutCurrency: &token}, later)
+	if tracking.State != StateClaimed || !tracking.ClaimedAt.Equal(later) || !tracking.WatchedAt.Equal(start) {
+		t.Errorf("claimed tracking = %+v", tracking)
+	}
+	if tracking.ExpectedPayout != 500 || tracking.PayoutCurrency != "USDC" || tracking.Notes != notes {
+		t.Errorf("payout/notes = %v %q %q", tracking.ExpectedPayout, tracking.PayoutCurrency, tracking.Notes)
+	}
+
+	// Re-applying the current state keeps its original entry time.
+	tracking.Apply(TrackingUpdate{State: &claimed}, later.Add(time.Hour))
+	if got := tracking.StateTime(StateClaimed); got == nil || !got.Equal(later) {
+		t.Errorf("StateTime(claimed) = %v, want %v", got, later)
+	}
+	if !tracking.UpdatedAt.Equal(later.Add(time.Hour)) {
+		t.Errorf("UpdatedAt = %v", tracking.UpdatedAt)
+	}
+}
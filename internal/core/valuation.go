@@ -0,0 +1,30 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrPriceUnavailable is returned by a PriceOracle that has no price for a token.
+var ErrPriceUnavailable = errors.New("price unavailable")
+
+// EstimateUSD sets b.RewardUSD from the structured reward and the oracle's
+// price for its token. Ranges are valued at their upper bound, the figure
+// listings advertise. Rewards without an amount or token are left at zero.
+func EstimateUSD(ctx context.Context, oracle PriceOracle, b *Bounty) error {
+	b.RewardUSD = 0
+	if oracle == nil || b.RewardMax <= 0 {
+		return nil
+	}
+	token := strings.ToUpper(strings.TrimSpace(b.RewardToken))
+	if token == "" {
+		return nil
+	}
+	price, err := oracle.PriceUSD(ctx, token)
+	if err != nil {
+		return err
+	}
+	b.RewardUSD = b.RewardMax * price
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"bountyos-v8/internal/core"
@@ -35,6 +36,24 @@ func (n *DiscordNotifier) Alert(bounty core.Bounty) error {
 		color = 0xfbbf24 // Yellow
 	}
 
+	fields := []map[string]interface{}{
+		{"name": "Platform", "value": bounty.Platform, "inline": true},
+		{"name": "Reward", "value": fmt.Sprintf("%s %s", bounty.Reward, bounty.Currency), "inline": true},
+		{"name": "Score", "value": fmt.Sprintf("%d", bounty.Score), "inline": true},
+		{"name": "Payment", "value": bounty.PaymentType, "inline": true},
+	}
+	if bounty.RewardUSD > 0 {
+		fields = append(fields, map[string]interface{}{
+			"name": "Value (USD)", "value": fmt.Sprintf("≈ $%.0f", bounty.RewardUSD), "inline": true,
+		})
+	}
+
+	if len(bounty.ScoreBreakdown) > 0 {
+		fields = append(fields, map[string]interface{}{
+			"name": "Score breakdown", "value": formatBreakdown(bounty.ScoreBreakdown), "inline": false,
+		})
+	}
+
 	payload := map[string]interface{}{
 		"embeds": []map[string]interface{}{
 			{
@@ -42,12 +61,7 @@ func (n *DiscordNotifier) Alert(bounty core.Bounty) error {
 				"description": bounty.Title,
 				"url":         bounty.URL,
 				"color":       color,
-				"fields": []map[string]interface{}{
-					{"name": "Platform", "value": bounty.Platform, "inline": true},
-					{"name": "Reward", "value": fmt.Sprintf("%s %s", bounty.Reward, bounty.Currency), "inline": true},
-					{"name": "Score", "value": fmt.Sprintf("%d", bounty.Score), "inline": true},
-					{"name": "Payment", "value": bounty.PaymentType, "inline": true},
-				},
+				"fields":      fields,
 				"footer": map[string]interface{}{
 					"text": "BountyOS v8: Obsidian Sniper",
 				},
@@ -80,6 +94,25 @@ func (n *DiscordNotifier) Alert(bounty core.Bounty) error {
 	return nil
 }
 
+// formatBreakdown renders one contribution per line within Discord's
+// 1024-character field limit.
+func formatBreakdown(breakdown []core.ScoreContribution) string {
+	const limit = 1024
+	var sb strings.Builder
+	for i, c := range breakdown {
+		line := c.String()
+		if i > 0 {
+			line = "\n" + line
+		}
+		if sb.Len()+len(line) > limit-4 {
+			sb.WriteString("\n…")
+			break
+		}
+		sb.WriteString(line)
+	}
+	return sb.String()
+}
+
 func (n *DiscordNotifier) Notify(message string) error {
 	if n.webhookURL == "" {
 		return nil
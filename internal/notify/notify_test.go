@@ -3,6 +3,7 @@ package notify
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"bountyos-v8/internal/core"
@@ -35,3 +36,21 @@ func TestDiscordNotifier(t *testing.T) {
 		t.Errorf("Discord Notify failed: %v", err)
 	}
 }
+
+func TestFormatBreakdown(t *testing.T) {
+	breakdown := []core.ScoreContribution{
+		{Rule: "payment-crypto", Match: "USDC", Points: 50},
+		{Rule: "payment-other", Points: 5},
+	}
+	if got, want := formatBreakdown(breakdown), "+50 payment-crypto (USDC)\n+5 payment-other"; got != want {
+		t.Errorf("formatBreakdown() = %q, want %q", got, want)
+	}
+
+	long := make([]core.ScoreContribution, 100)
+	for i := range long {
+		long[i] = core.ScoreContribution{Rule: strings.Repeat("r", 30), Points: i}
+	}
+	if got := formatBreakdown(long); len(got) > 1024 || !strings.HasSuffix(got, "…") {
+		t.Errorf("formatBreakdown() not truncated: %d chars", len(got))
+	}
+}
@@ -0,0 +1,202 @@
+// Package pipeline runs bounties through a sequence of stages, each with its
+// own pool of workers, connected by bounded queues. A full queue blocks the
+// stage feeding it, so a slow stage slows ingest down instead of piling
+// bounties up in memory, and the stages ahead of it keep working.
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+// Handler processes one bounty and reports whether it moves on to the next
+// stage.
+type Handler func(ctx context.Context, b core.Bounty) (core.Bounty, bool)
+
+// Stage is one step of a Pipeline.
+type Stage struct {
+	Name    string
+	Workers int // bounties handled at once (default 1)
+	Queue   int // bounties waiting for a worker before senders block (default 100)
+	Handle  Handler
+
+	// Discard, if set, is called for each bounty bound for this stage that
+	// it never handles: one still queued, or still being sent, when ctx
+	// ends.
+	Discard func(b core.Bounty)
+}
+
+// Pipeline connects stages in order. It is safe to read Stats while it runs.
+type Pipeline struct {
+	stages []*stage
+}
+
+type stage struct {
+	Stage
+	in chan core.Bounty
+
+	processed atomic.Int64
+	dropped   atomic.Int64
+	busy      atomic.Int64 // nanoseconds spent in Handle
+	maxBusy   atomic.Int64
+	blocked   atomic.Int64 // nanoseconds spent waiting on the next queue
+}
+
+// New returns a Pipeline running stages in the order given.
+func New(stages ...Stage) *Pipeline {
+	p := &Pipeline{}
+	for _, s := range stages {
+		if s.Workers <= 0 {
+			s.Workers = 1
+		}
+		if s.Queue <= 0 {
+			s.Queue = 100
+		}
+		p.stages = append(p.stages, &stage{Stage: s, in: make(chan core.Bounty, s.Queue)})
+	}
+	return p
+}
+
+// Run feeds bounties from in through every stage until in is closed or ctx
+// is done, and returns once every stage has finished. Bounties still queued
+// when ctx is done are discarded unprocessed.
+func (p *Pipeline) Run(ctx context.Context, in <-chan core.Bounty) {
+	if len(p.stages) == 0 {
+		return
+	}
+
+	go func() {
+		defer close(p.stages[0].in)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case b, ok := <-in:
+				if !ok {
+					return
+				}
+				if !p.stages[0].send(ctx, b, nil) {
+					p.stages[0].discard(b)
+					return
+				}
+			}
+		}
+	}()
+
+	var last sync.WaitGroup
+	for i, s := range p.stages {
+		var next *stage
+		if i+1 < len(p.stages) {
+			next = p.stages[i+1]
+		}
+		var wg sync.WaitGroup
+		for w := 0; w < s.Workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.work(ctx, next)
+			}()
+		}
+		if next != nil {
+			go func() {
+				wg.Wait()
+				close(next.in)
+			}()
+		} else {
+			last.Add(1)
+			go func() {
+				defer last.Done()
+				wg.Wait()
+			}()
+		}
+	}
+	last.Wait()
+}
+
+func (s *stage) work(ctx context.Context, next *stage) {
+	for b := range s.in {
+		if ctx.Err() != nil {
+			s.discard(b)
+			continue
+		}
+
+		started := time.Now()
+		out, ok := s.Handle(ctx, b)
+		elapsed := int64(time.Since(started))
+		s.processed.Add(1)
+		s.busy.Add(elapsed)
+		storeMax(&s.maxBusy, elapsed)
+
+		if !ok {
+			s.dropped.Add(1)
+			continue
+		}
+		if next != nil && !next.send(ctx, out, s) {
+			next.discard(out)
+		}
+	}
+}
+
+func storeMax(v *atomic.Int64, n int64) {
+	for {
+		max := v.Load()
+		if n <= max || v.CompareAndSwap(max, n) {
+			return
+		}
+	}
+}
+
+func (s *stage) discard(b core.Bounty) {
+	if s.Discard != nil {
+		s.Discard(b)
+	}
+}
+
+// send queues b on s, charging any wait for a free slot to from. It reports
+// false if ctx ended first.
+func (s *stage) send(ctx context.Context, b core.Bounty, from *stage) bool {
+	select {
+	case s.in <- b:
+		return true
+	default:
+	}
+
+	started := time.Now()
+	defer func() {
+		if from != nil {
+			from.blocked.Add(int64(time.Since(started)))
+		}
+	}()
+	select {
+	case s.in <- b:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Stats returns a snapshot of every stage in order.
+func (p *Pipeline) Stats() []core.StageStats {
+	stats := make([]core.StageStats, len(p.stages))
+	for i, s := range p.stages {
+		processed := s.processed.Load()
+		stats[i] = core.StageStats{
+			Name:          s.Name,
+			Workers:       s.Workers,
+			QueueDepth:    len(s.in),
+			QueueCapacity: cap(s.in),
+			Processed:     processed,
+			Dropped:       s.dropped.Load(),
+			MaxLatencyMS:  float64(s.maxBusy.Load()) / float64(time.Millisecond),
+			BlockedMS:     s.blocked.Load() / int64(time.Millisecond),
+		}
+		if processed > 0 {
+			stats[i].AvgLatencyMS = float64(s.busy.Load()) / float64(processed) / float64(time.Millisecond)
+		}
+	}
+	return stats
+}
@@ -0,0 +1,188 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"bountyos-v8/internal/core"
+)
+
+func feed(n int) chan core.Bounty {
+	in := make(chan core.Bounty, n)
+	for i := 0; i < n; i++ {
+		in <- core.Bounty{URL: fmt.Sprintf("https://example.com/%d", i), Score: i}
+	}
+	close(in)
+	return in
+}
+
+func TestPipeline_RunsStagesInOrder(t *testing.T) {
+	var saved atomic.Int32
+	p := New(
+		Stage{Name: "filter", Workers: 3, Handle: func(ctx context.Context, b core.Bounty) (core.Bounty, bool) {
+			return b, b.Score%2 == 0
+		}},
+		Stage{Name: "tag", Workers: 2, Handle: func(ctx context.Context, b core.Bounty) (core.Bounty, bool) {
+			b.Title = "tagged"
+			return b, true
+		}},
+		Stage{Name: "save", Handle: func(ctx context.Context, b core.Bounty) (core.Bounty, bool) {
+			if b.Title != "tagged" {
+				t.Errorf("save got %+v before tag", b)
+			}
+			saved.Add(1)
+			return b, true
+		}},
+	)
+	p.Run(context.Background(), feed(10))
+
+	if saved.Load() != 5 {
+		t.Errorf("saved %d bounties, want 5", saved.Load())
+	}
+	stats := p.Stats()
+	if len(stats) != 3 || stats[0].Name != "filter" || stats[0].Processed != 10 || stats[0].Dropped != 5 || stats[0].Workers != 3 {
+		t.Errorf("filter stats = %+v", stats[0])
+	}
+	if stats[2].Processed != 5 || stats[2].QueueDepth != 0 || stats[2].QueueCapacity != 100 {
+		t.Errorf("save stats = %+v", stats[2])
+	}
+}
+
+func TestPipeline_SlowStageBlocksOnlyUpstream(t *testing.T) {
+	release := make(chan struct{})
+	var fetched atomic.Int32
+	p := New(
+		Stage{Name: "fetch", Queue: 1, Handle: func(ctx context.Context, b core.Bounty) (core.Bounty, bool) {
+			fetched.Add(1)
+			return b, true
+		}},
+		Stage{Name: "alert", Queue: 2, Workers: 1, Handle: func(ctx context.Context, b core.Bounty) (core.Bounty, bool) {
+			<-release
+			return b, true
+		}},
+	)
+	done := make(chan struct{})
+	go func() {
+		p.Run(context.Background(), feed(10))
+		close(done)
+	}()
+
+	// alert holds one bounty and queues two; fetch handles one more and
+	// then blocks handing it on.
+	deadline := time.Now().Add(2 * time.Second)
+	for fetched.Load() < 4 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if got := fetched.Load(); got != 4 {
+		t.Errorf("fetch handled %d bounties while alert was stuck, want 4", got)
+	}
+	if stats := p.Stats(); stats[1].QueueDepth != 2 {
+		t.Errorf("alert queue depth = %d, want 2", stats[1].QueueDepth)
+	}
+
+	close(release)
+	<-done
+	stats := p.Stats()
+	if stats[0].BlockedMS < 20 {
+		t.Errorf("fetch blocked for %dms, want at least 20", stats[0].BlockedMS)
+	}
+	if stats[1].Processed != 10 || stats[1].AvgLatencyMS <= 0 || stats[1].MaxLatencyMS < stats[1].AvgLatencyMS {
+		t.Errorf("alert stats = %+v", stats[1])
+	}
+}
+
+func TestPipeline_WorkersRunConcurrently(t *testing.T) {
+	var running, peak atomic.Int32
+	p := New(Stage{Name: "notify", Workers: 4, Handle: func(ctx context.Context, b core.Bounty) (core.Bounty, bool) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		storeMax32(&peak, n)
+		time.Sleep(20 * time.Millisecond)
+		return b, true
+	}})
+	p.Run(context.Background(), feed(8))
+
+	if got := peak.Load(); got != 4 {
+		t.Errorf("peak concurrency = %d, want 4", got)
+	}
+}
+
+func TestPipeline_StopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan core.Bounty)
+	p := New(Stage{Name: "save", Handle: func(ctx context.Context, b core.Bounty) (core.Bounty, bool) {
+		return b, true
+	}})
+	done := make(chan struct{})
+	go func() {
+		p.Run(ctx, in)
+		close(done)
+	}()
+
+	in <- core.Bounty{URL: "https://example.com/a"}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not return after the context ended")
+	}
+}
+
+func TestPipeline_DiscardsUnhandledBounties(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	var handled, discarded atomic.Int32
+	p := New(
+		Stage{Name: "fetch", Workers: 1, Handle: func(ctx context.Context, b core.Bounty) (core.Bounty, bool) {
+			return b, true
+		}},
+		Stage{Name: "save", Workers: 1, Handle: func(ctx context.Context, b core.Bounty) (core.Bounty, bool) {
+			if handled.Add(1) == 1 {
+				close(started)
+				<-ctx.Done()
+			}
+			return b, true
+		}, Discard: func(b core.Bounty) {
+			discarded.Add(1)
+		}},
+	)
+
+	const total = 5
+	in := make(chan core.Bounty, total)
+	for i := 0; i < total; i++ {
+		in <- core.Bounty{URL: "https://example.com/" + string(rune('a'+i))}
+	}
+	close(in)
+	done := make(chan struct{})
+	go func() {
+		p.Run(ctx, in)
+		close(done)
+	}()
+
+	<-started
+	for len(p.stages[0].in) > 0 || len(p.stages[1].in) < total-1 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not return after the context ended")
+	}
+	if got := handled.Load() + discarded.Load(); got != total {
+		t.Errorf("handled %d + discarded %d bounties, want %d", handled.Load(), discarded.Load(), total)
+	}
+}
+
+func storeMax32(v *atomic.Int32, n int32) {
+	for {
+		max := v.Load()
+		if n <= max || v.CompareAndSwap(max, n) {
+			return
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package security
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LinkCache keeps link check results across restarts.
+type LinkCache interface {
+	// LinkCheck returns the latest result for url recorded at or after
+	// since; found is false when there is none.
+	LinkCheck(url string, since time.Time) (reachable, found bool, err error)
+	SaveLinkCheck(url string, reachable bool, checkedAt time.Time) error
+}
+
+// LinkValidatorOptions tune a LinkValidator. Zero values pick the defaults.
+type LinkValidatorOptions struct {
+	Workers  int           // concurrent checks in total (default 8)
+	PerHost  int           // concurrent checks against one host (default 2)
+	Timeout  time.Duration // per check (default 5s)
+	CacheTTL time.Duration // how long a result is reused; 0 never reuses
+	Budget   int           // HTTP checks per cycle; 0 is unlimited
+}
+
+// LinkValidator checks link reachability on a pool of workers sharing one
+// HTTP client. Results are cached in a LinkCache, and each cycle, begun
+// with BeginCycle, may spend at most Budget HTTP checks.
+type LinkValidator struct {
+	opts   LinkValidatorOptions
+	cache  LinkCache
+	client *http.Client
+	queue  chan linkJob
+	stop   chan struct{} // closed once the workers stop
+
+	mu      sync.Mutex
+	hosts   map[string]*linkHost
+	spent   int
+	stopped bool
+	start   sync.Once
+}
+
+// linkHost tracks the checks against one host. Jobs beyond PerHost wait in
+// line instead of holding a worker; each finishing check starts the next.
+type linkHost struct {
+	active  int
+	waiting []linkJob
+}
+
+type linkJob struct {
+	ctx  context.Context
+	url  string
+	done func(reachable bool)
+}
+
+// NewLinkValidator returns a LinkValidator caching results in cache, which
+// may be nil. No checks run until Start.
+func NewLinkValidator(cache LinkCache, opts LinkValidatorOptions) *LinkValidator {
+	if opts.Workers <= 0 {
+		opts.Workers = 8
+	}
+	if opts.PerHost <= 0 {
+		opts.PerHost = 2
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	return &LinkValidator{
+		opts:   opts,
+		cache:  cache,
+		client: linkClient(),
+		queue:  make(chan linkJob, opts.Workers*64),
+		stop:   make(chan struct{}),
+		hosts:  make(map[string]*linkHost),
+	}
+}
+
+// Start runs the workers until ctx is done. Jobs still queued then, and
+// those submitted later, are reported reachable unchecked.
+func (v *LinkValidator) Start(ctx context.Context) {
+	v.start.Do(func() {
+		for i := 0; i < v.opts.Workers; i++ {
+			go v.work()
+		}
+		go func() {
+			<-ctx.Done()
+			v.mu.Lock()
+			v.stopped = true
+			v.mu.Unlock()
+			close(v.stop)
+		}()
+	})
+}
+
+// BeginCycle restores the full budget of HTTP checks.
+func (v *LinkValidator) BeginCycle() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.spent = 0
+}
+
+// Submit checks urlStr in the background and calls done with the verdict,
+// on a worker unless the verdict is known at once. It never waits for the
+// network: a cached result is reported immediately, and when the budget is
+// spent or the queue is full urlStr is reported reachable unchecked.
+func (v *LinkValidator) Submit(ctx context.Context, urlStr string, done func(reachable bool)) {
+	if reachable, found := v.cached(urlStr); found {
+		done(reachable)
+		return
+	}
+	if !v.spend() {
+		done(true)
+		return
+	}
+
+	// Queue under mu so no job lands after the workers drained the queue
+	v.mu.Lock()
+	queued := false
+	if !v.stopped {
+		select {
+		case v.queue <- linkJob{ctx: ctx, url: urlStr, done: done}:
+			queued = true
+		default:
+		}
+	}
+	v.mu.Unlock()
+	if !queued {
+		v.refund()
+		done(true)
+	}
+}
+
+// Unreachable checks urls and returns, sorted, those that are unreachable.
+// URLs left unchecked because the budget ran out or ctx ended are not
+// returned.
+func (v *LinkValidator) Unreachable(ctx context.Context, urls []string) []string {
+	var (
+		mu     sync.Mutex
+		failed []string
+		wg     sync.WaitGroup
+	)
+	fail := func(urlStr string) {
+		mu.Lock()
+		defer mu.Unlock()
+		failed = append(failed, urlStr)
+	}
+
+submit:
+	for _, urlStr := range urls {
+		if reachable, found := v.cached(urlStr); found {
+			if !reachable {
+				fail(urlStr)
+			}
+			continue
+		}
+		if !v.spend() {
+			continue
+		}
+		urlStr := urlStr
+		wg.Add(1)
+		job := linkJob{ctx: ctx, url: urlStr, done: func(reachable bool) {
+			defer wg.Done()
+			if !reachable {
+				fail(urlStr)
+			}
+		}}
+		select {
+		case v.queue <- job:
+		case <-ctx.Done():
+			wg.Done()
+			break submit
+		case <-v.stop:
+			wg.Done()
+			break submit
+		}
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-ctx.Done():
+	case <-v.stop:
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	result := append([]string(nil), failed...)
+	sort.Strings(result)
+	return result
+}
+
+func (v *LinkValidator) work() {
+	for {
+		select {
+		case <-v.stop:
+			// Nothing is queued after stop; report what is left
+			for {
+				select {
+				case job := <-v.queue:
+					job.done(true)
+				default:
+					return
+				}
+			}
+		case job := <-v.queue:
+			v.dispatch(job)
+		}
+	}
+}
+
+// dispatch runs job if its host has a free slot, and then every job that
+// queued behind it for the host meanwhile. Otherwise it leaves job in the
+// host's line and returns, so the worker moves on to other hosts.
+func (v *LinkValidator) dispatch(job linkJob) {
+	host := linkHostOf(job.url)
+
+	v.mu.Lock()
+	h, ok := v.hosts[host]
+	if !ok {
+		h = &linkHost{}
+		v.hosts[host] = h
+	}
+	if h.active >= v.opts.PerHost {
+		h.waiting = append(h.waiting, job)
+		v.mu.Unlock()
+		return
+	}
+	h.active++
+	v.mu.Unlock()
+
+	for {
+		v.check(job)
+
+		v.mu.Lock()
+		if len(h.waiting) == 0 {
+			h.active--
+			if h.active == 0 {
+				delete(v.hosts, host)
+			}
+			v.mu.Unlock()
+			return
+		}
+		job = h.waiting[0]
+		h.waiting = h.waiting[1:]
+		v.mu.Unlock()
+	}
+}
+
+// check runs one job. A job whose context has ended, or that is left when
+// the validator stops, is reported reachable and not cached.
+func (v *LinkValidator) check(job linkJob) {
+	select {
+	case <-v.stop:
+		job.done(true)
+		return
+	default:
+	}
+	if job.ctx.Err() != nil {
+		job.done(true)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(job.ctx, v.opts.Timeout)
+	reachable := checkReachable(ctx, v.client, job.url)
+	cancel()
+
+	if job.ctx.Err() != nil {
+		job.done(true)
+		return
+	}
+	if v.cache != nil {
+		if err := v.cache.SaveLinkCheck(job.url, reachable, time.Now()); err != nil {
+			GetLogger().Warn("Failed to cache link check for %s: %v", job.url, err)
+		}
+	}
+	job.done(reachable)
+}
+
+func (v *LinkValidator) cached(urlStr string) (reachable, found bool) {
+	if v.cache == nil || v.opts.CacheTTL <= 0 {
+		return false, false
+	}
+	reachable, found, err := v.cache.LinkCheck(urlStr, time.Now().Add(-v.opts.CacheTTL))
+	if err != nil {
+		GetLogger().Warn("Failed to read cached link check for %s: %v", urlStr, err)
+		return false, false
+	}
+	return reachable, found
+}
+
+func (v *LinkValidator) spend() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.opts.Budget > 0 && v.spent >= v.opts.Budget {
+		return false
+	}
+	v.spent++
+	return true
+}
+
+func (v *LinkValidator) refund() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.spent--
+}
+
+func linkHostOf(urlStr string) string {
+	if parsed, err := url.Parse(urlStr); err == nil {
+		return strings.ToLower(parsed.Hostname())
+	}
+	return urlStr
+}
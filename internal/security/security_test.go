@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -114,6 +116,192 @@ func TestValidateURLReachable(t *testing.T) {
 	}
 }
 
+// memoryLinkCache is a LinkCache for tests.
+type memoryLinkCache struct {
+	mu     sync.Mutex
+	checks map[string]bool
+}
+
+func (c *memoryLinkCache) LinkCheck(url string, since time.Time) (bool, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	reachable, found := c.checks[url]
+	return reachable, found, nil
+}
+
+func (c *memoryLinkCache) SaveLinkCheck(url string, reachable bool, checkedAt time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[url] = reachable
+	return nil
+}
+
+func TestLinkValidator_Unreachable(t *testing.T) {
+	t.Setenv("BOUNTYOS_ALLOW_LOCAL_URLS", "true")
+
+	var inFlight, maxInFlight, requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			max := maxInFlight.Load()
+			if n <= max || maxInFlight.CompareAndSwap(max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		if strings.HasPrefix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cache := &memoryLinkCache{checks: map[string]bool{ts.URL + "/cached-missing": false}}
+	links := NewLinkValidator(cache, LinkValidatorOptions{Workers: 8, PerHost: 2, CacheTTL: time.Hour})
+	links.Start(ctx)
+
+	urls := []string{ts.URL + "/cached-missing"}
+	for i := 0; i < 8; i++ {
+		urls = append(urls, ts.URL+"/ok/"+strings.Repeat("x", i), ts.URL+"/missing/"+strings.Repeat("x", i))
+	}
+	failed := links.Unreachable(ctx, urls)
+	if len(failed) != 9 || failed[0] != ts.URL+"/cached-missing" {
+		t.Fatalf("Unreachable() = %v, want the cached URL and 8 missing ones", failed)
+	}
+	if got := requests.Load(); got != 16 {
+		t.Errorf("server got %d requests, want 16", got)
+	}
+	if got := maxInFlight.Load(); got > 2 {
+		t.Errorf("%d concurrent requests to one host, want at most 2", got)
+	}
+
+	// Everything is cached now
+	if failed := links.Unreachable(ctx, urls); len(failed) != 9 || requests.Load() != 16 {
+		t.Errorf("second Unreachable() = %d URLs after %d requests, want 9 from the cache", len(failed), requests.Load())
+	}
+}
+
+func TestLinkValidator_Budget(t *testing.T) {
+	t.Setenv("BOUNTYOS_ALLOW_LOCAL_URLS", "true")
+
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	links := NewLinkValidator(nil, LinkValidatorOptions{Budget: 2})
+	links.Start(ctx)
+
+	urls := []string{ts.URL + "/a", ts.URL + "/b", ts.URL + "/c"}
+	if failed := links.Unreachable(ctx, urls); len(failed) != 2 {
+		t.Errorf("Unreachable() = %v, want only the 2 URLs within budget", failed)
+	}
+
+	// Over budget, Submit reports reachable at once without a request
+	verdict := make(chan bool, 1)
+	links.Submit(ctx, ts.URL+"/d", func(reachable bool) { verdict <- reachable })
+	if !<-verdict || requests.Load() != 2 {
+		t.Errorf("Submit() over budget made a request or reported unreachable")
+	}
+
+	links.BeginCycle()
+	links.Submit(ctx, ts.URL+"/d", func(reachable bool) { verdict <- reachable })
+	if <-verdict || requests.Load() != 3 {
+		t.Errorf("Submit() after BeginCycle did not check the URL")
+	}
+}
+
+func TestLinkValidator_BusyHostDoesNotStarveOthers(t *testing.T) {
+	t.Setenv("BOUNTYOS_ALLOW_LOCAL_URLS", "true")
+
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slow.Close()
+	defer close(release)
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer fast.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	links := NewLinkValidator(nil, LinkValidatorOptions{Workers: 2, PerHost: 1, Timeout: time.Minute})
+	links.Start(ctx)
+
+	for i := 0; i < 5; i++ {
+		links.Submit(ctx, slow.URL+"/"+strings.Repeat("x", i), func(bool) {})
+	}
+	verdict := make(chan bool, 1)
+	otherHost := strings.Replace(fast.URL, "127.0.0.1", "localhost", 1)
+	links.Submit(ctx, otherHost+"/ok", func(reachable bool) { verdict <- reachable })
+
+	select {
+	case reachable := <-verdict:
+		if !reachable {
+			t.Errorf("Submit() reported the other host unreachable")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("check for another host waited behind a busy one")
+	}
+}
+
+func TestLinkValidator_StopReportsQueuedJobs(t *testing.T) {
+	t.Setenv("BOUNTYOS_ALLOW_LOCAL_URLS", "true")
+
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	links := NewLinkValidator(nil, LinkValidatorOptions{Workers: 1, PerHost: 1, Timeout: 200 * time.Millisecond})
+	links.Start(ctx)
+
+	var wg sync.WaitGroup
+	var unreachable atomic.Int32
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		links.Submit(context.Background(), ts.URL+"/"+strings.Repeat("x", i), func(reachable bool) {
+			defer wg.Done()
+			if !reachable {
+				unreachable.Add(1)
+			}
+		})
+	}
+	cancel()
+
+	// Submitted after stopping: reported at once
+	wg.Add(1)
+	links.Submit(context.Background(), ts.URL+"/late", func(bool) { wg.Done() })
+
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("queued jobs were not reported after the validator stopped")
+	}
+	if n := unreachable.Load(); n > 1 {
+		t.Errorf("%d jobs checked after stopping, want at most the one in flight", n)
+	}
+}
+
 func TestSecureRequest(t *testing.T) {
 	req, _ := http.NewRequest("GET", "https://api.github.com", nil)
 	SecureRequest(req, "test-token")
@@ -10,6 +10,7 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -232,15 +233,34 @@ func ValidateURL(urlStr string) bool {
 
 // ValidateURLReachable checks if a URL responds with an acceptable HTTP status.
 func ValidateURLReachable(ctx context.Context, urlStr string, timeout time.Duration) bool {
-	if !ValidateURL(urlStr) {
-		return false
-	}
 	if timeout <= 0 {
 		timeout = 5 * time.Second
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return checkReachable(ctx, linkClient(), urlStr)
+}
+
+var (
+	linkClientOnce   sync.Once
+	sharedLinkClient *http.Client
+)
 
-	client := SecureHTTPClient()
-	client.Timeout = timeout
+// linkClient returns the client every link check shares, so connections to
+// the same host are reused. Checks bound their time through the context.
+func linkClient() *http.Client {
+	linkClientOnce.Do(func() {
+		sharedLinkClient = SecureHTTPClient()
+	})
+	return sharedLinkClient
+}
+
+// checkReachable is ValidateURLReachable with the client and deadline
+// supplied by the caller.
+func checkReachable(ctx context.Context, client *http.Client, urlStr string) bool {
+	if !ValidateURL(urlStr) {
+		return false
+	}
 
 	statusOK := func(code int) bool {
 		if code >= 200 && code < 400 {
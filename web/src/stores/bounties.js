@@ -5,6 +5,7 @@ const okTypes = new Set(['bounty'])
 export const useBountiesStore = defineStore('bounties', {
   state: () => ({
     bounties: [],
+    scannerHealth: [],
     connected: false,
     lastUpdated: null,
     error: null,
@@ -52,6 +53,16 @@ export const useBountiesStore = defineStore('bounties', {
         this.error = err.message
       }
     },
+    async fetchHealth() {
+      try {
+        const res = await fetch('/api/health')
+        if (!res.ok) throw new Error(`Failed to fetch scanner health: ${res.status}`)
+        const data = await res.json()
+        this.scannerHealth = data.scanners || []
+      } catch (err) {
+        this.error = err.message
+      }
+    },
     upsertBounty(bounty) {
       if (!bounty || !bounty.url) return
       const idx = this.bounties.findIndex((b) => b.url === bounty.url)